@@ -7,18 +7,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/bwmarrin/discordgo"
 	"github.com/joho/godotenv"
 	"github.com/latoulicious/HKTM/internal/commands"
 	"github.com/latoulicious/HKTM/internal/config"
 	"github.com/latoulicious/HKTM/internal/handlers"
 	"github.com/latoulicious/HKTM/internal/presence"
+	"github.com/latoulicious/HKTM/internal/shard"
 	"github.com/latoulicious/HKTM/pkg/audio"
 	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/database"
+	"github.com/latoulicious/HKTM/pkg/extractor"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
 	"gorm.io/gorm"
@@ -72,19 +75,29 @@ func initializeApplication() error {
 		log.Printf("Audio functionality may be limited. Please ensure ffmpeg and yt-dlp are installed.")
 	}
 
+	// Share one yt-dlp extractor and its metadata cache between commands and playback
+	initializeExtractor(db, cfg)
+
 	// Initialize audio pipeline system
 	if err := initializeAudioPipelineSystem(db); err != nil {
 		return fmt.Errorf("failed to initialize audio pipeline system: %w", err)
 	}
 
-	// Create a new Discord session using the provided token
-	dg, err := discordgo.New("Bot " + cfg.DiscordToken)
+	// Create a Discord session for every gateway shard this process runs
+	shardIDs, err := shard.ParseIDs(cfg.ShardIDs, cfg.ShardCount)
+	if err != nil {
+		return fmt.Errorf("invalid SHARD_IDS: %w", err)
+	}
+	shards, err = shard.NewManager("Bot "+cfg.DiscordToken, cfg.ShardCount, shardIDs)
 	if err != nil {
 		return fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
+	// Let commands route guilds to their shard
+	commands.SetShardManager(shards)
+
 	// Create presence manager
-	presenceManager := presence.NewPresenceManager(dg)
+	presenceManager := presence.NewPresenceManager(shards)
 
 	// Set the presence manager in the commands package
 	commands.SetPresenceManager(presenceManager)
@@ -99,20 +112,38 @@ func initializeApplication() error {
 	commands.InitializeCommandsWithDB(db)
 
 	// Register the message handler
-	dg.AddHandler(handlers.MessageHandler)
+	shards.AddHandler(handlers.MessageHandler)
 
 	// Register the slash command handler
-	dg.AddHandler(handlers.SlashCommandHandler)
+	shards.AddHandler(handlers.SlashCommandHandler)
+
+	// Initialize the command permission policy with database-backed role mappings
+	commands.InitializePermissions(db, cfg.OwnerID)
+	commands.InitializeSettings(db)
+	commands.InitializeCustomCommands(db)
+	commands.InitializeRateLimits(db)
+
+	// Configure vote-skip threshold
+	commands.SetVoteSkipThreshold(cfg.VoteSkipThreshold)
+
+	// Configure how often live now-playing progress bars are refreshed
+	commands.SetNowPlayingLiveInterval(cfg.NowPlayingLiveInterval)
+
+	// Configure how long the bot stays in a voice channel nobody is listening in
+	commands.SetEmptyChannelLeaveDelay(cfg.EmptyChannelLeaveDelay)
+
+	// Register the voice state handler for listener tracking, vote-skips and auto-pause
+	shards.AddHandler(handlers.VoiceStateUpdateHandler)
 
 	// Register the reaction handlers for Uma character image navigation
-	dg.AddHandler(handlers.ReactionAddHandler)
-	dg.AddHandler(handlers.ReactionRemoveHandler)
+	shards.AddHandler(handlers.ReactionAddHandler)
+	shards.AddHandler(handlers.ReactionRemoveHandler)
 
 	// Start health check HTTP server
 	healthServer := startHealthCheckServer()
 
-	// Open a websocket connection to Discord and begin listening.
-	if err := dg.Open(); err != nil {
+	// Open a websocket connection per shard to Discord and begin listening.
+	if err := shards.Open(); err != nil {
 		return fmt.Errorf("failed to open Discord session: %w", err)
 	}
 
@@ -124,7 +155,7 @@ func initializeApplication() error {
 
 	// Start idle monitor
 	idleMonitor := commands.GetIdleMonitor()
-	idleMonitor(dg)
+	idleMonitor()
 
 	common.EnforceGuildAndDev(cfg.OwnerID)
 
@@ -144,8 +175,8 @@ func initializeApplication() error {
 	// Shutdown audio pipeline system
 	shutdownAudioPipelineSystem()
 
-	// Cleanly close down the Discord session.
-	dg.Close()
+	// Cleanly close down the Discord sessions.
+	shards.Close()
 
 	// Stop the build ID manager cron job
 	if gametoraClient := handler.GetGametoraClient(); gametoraClient != nil {
@@ -183,6 +214,36 @@ func validateSystemDependencies() error {
 	return audio.ValidateSystemDependencies()
 }
 
+// initializeExtractor sets up the shared yt-dlp extractor using the audio configuration's binary path
+func initializeExtractor(db *gorm.DB, cfg *config.Config) {
+	binaryPath := audio.DefaultYtDlpConfig.BinaryPath
+	if audioConfig, err := audio.NewConfigManager(); err == nil {
+		binaryPath = audioConfig.GetYtDlpConfig().BinaryPath
+	} else {
+		log.Printf("Warning: Failed to load audio configuration, using default yt-dlp path: %v", err)
+	}
+
+	// Every yt-dlp and ffmpeg process waits for a slot in the shared budget
+	budget := audio.NewProcessBudget(cfg.MaxConcurrentStreams, cfg.MaxConcurrentExtractions,
+		time.Duration(cfg.ProcessQueueTimeout)*time.Second)
+	audio.SetGlobalProcessBudget(budget)
+
+	ytExtractor := extractor.New(binaryPath, db, time.Duration(cfg.MetadataCacheTTL)*time.Hour)
+	ytExtractor.Streams().SetRefreshMargin(time.Duration(cfg.StreamURLRefreshMargin) * time.Second)
+	ytExtractor.SetAdmission(budget)
+	extractor.SetGlobalExtractor(ytExtractor)
+
+	systemLogger := logging.GetGlobalLoggerFactory().CreateLogger("system")
+	systemLogger.Info("Metadata extractor initialized", map[string]interface{}{
+		"ytdlp_binary":          binaryPath,
+		"cache_ttl_hours":       cfg.MetadataCacheTTL,
+		"stream_refresh_margin": cfg.StreamURLRefreshMargin,
+		"max_streams":           cfg.MaxConcurrentStreams,
+		"max_extractions":       cfg.MaxConcurrentExtractions,
+		"process_queue_timeout": cfg.ProcessQueueTimeout,
+	})
+}
+
 // initializeAudioPipelineSystem initializes the audio pipeline system
 func initializeAudioPipelineSystem(db *gorm.DB) error {
 	// Initialize commands package with database for audio pipeline support
@@ -220,6 +281,9 @@ var (
 		StartTime: time.Now(),
 		Status:    "starting",
 	}
+
+	// Gateway shards run by this process, reported by /status
+	shards *shard.Manager
 )
 
 type SystemHealth struct {
@@ -313,11 +377,75 @@ func statusHandler(w http.ResponseWriter, r *http.Request) {
 		"components": {
 			"database": %t,
 			"audio_pipeline": %t,
-			"discord_connection": true
-		}
+			"discord_connection": %t
+		},
+		"processes": {%s},
+		"rate_limited": {%s},
+		"shards": [%s]
 	}`, systemHealth.Status, systemHealth.Uptime, 
 		systemHealth.StartTime.Format(time.RFC3339),
-		systemHealth.Database, systemHealth.Audio)
+		systemHealth.Database, systemHealth.Audio, discordConnected(),
+		formatProcessUsage(), formatRateLimitHits(), formatShardStatus())
+}
+
+// discordConnected reports whether every shard this process runs is connected
+func discordConnected() bool {
+	if shards == nil {
+		return false
+	}
+	for _, status := range shards.Status() {
+		if !status.Connected {
+			return false
+		}
+	}
+	return true
+}
+
+// formatShardStatus renders the shards this process runs as JSON array elements
+func formatShardStatus() string {
+	if shards == nil {
+		return ""
+	}
+
+	var elements []string
+	for _, status := range shards.Status() {
+		elements = append(elements, fmt.Sprintf(`
+			{"id": %d, "count": %d, "connected": %t, "guilds": %d, "latency": "%s"}`,
+			status.ID, shards.Count(), status.Connected, status.Guilds, status.Latency))
+	}
+	return strings.Join(elements, ",") + "\n\t\t"
+}
+
+// formatRateLimitHits renders how often each command's rate limit denied a run as JSON object members
+func formatRateLimitHits() string {
+	hits := commands.RateLimitHits()
+	if len(hits) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(hits))
+	for name := range hits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	members := make([]string, len(names))
+	for i, name := range names {
+		members[i] = fmt.Sprintf(`
+			"%s": %d`, name, hits[name])
+	}
+	return strings.Join(members, ",") + "\n\t\t"
+}
+
+// formatProcessUsage renders the process budget's slots as JSON object members
+func formatProcessUsage() string {
+	var members []string
+	for _, usage := range audio.GetGlobalProcessBudget().Usage() {
+		members = append(members, fmt.Sprintf(`
+			"%s": {"active": %d, "limit": %d, "waiting": %d}`,
+			usage.Kind, usage.Active, usage.Limit, usage.Waiting))
+	}
+	return strings.Join(members, ",") + "\n\t\t"
 }
 
 // checkAudioSystemHealth performs basic audio system health checks
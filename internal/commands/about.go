@@ -12,15 +12,15 @@ import (
 var startTime = time.Now()
 
 // AboutCommand displays bot information including version, uptime, memory usage, and Go version
-func AboutCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func AboutCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("about")
 	logger.Info("About command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
 	})
 	// Calculate uptime
 	uptime := time.Since(startTime)
@@ -83,7 +83,7 @@ func AboutCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 			},
 			{
 				Name:   "Ping",
-				Value:  fmt.Sprintf("%dms", s.HeartbeatLatency().Milliseconds()),
+				Value:  fmt.Sprintf("%dms", ctx.Session.HeartbeatLatency().Milliseconds()),
 				Inline: true,
 			},
 		},
@@ -92,11 +92,11 @@ func AboutCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 		},
 	}
 
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	err := ctx.ReplyEmbed(embed)
 	if err != nil {
 		logger.Error("Failed to send about embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
 	}
 }
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+const (
+	// playAutocompleteBudget leaves headroom inside Discord's 3 second autocomplete window
+	playAutocompleteBudget = 2500 * time.Millisecond
+	// playAutocompleteDebounce waits for the user to stop typing before searching YouTube
+	playAutocompleteDebounce = 400 * time.Millisecond
+	// playAutocompleteMinQuery is the shortest input worth a YouTube search
+	playAutocompleteMinQuery = 3
+	// playAutocompleteHistoryLimit caps suggestions from the guild's play history
+	playAutocompleteHistoryLimit = 5
+	// maxChoiceLength is Discord's limit for choice names and string values
+	maxChoiceLength = 100
+)
+
+var (
+	// Latest autocomplete request per user, used to debounce keystrokes
+	autocompleteSequence = make(map[string]uint64)
+	autocompleteMutex    sync.Mutex
+)
+
+// PlayAutocomplete suggests videos for /play as the user types: tracks from the guild's
+// play history first, then YouTube search results. Every value is a video URL.
+func PlayAutocomplete(guildID string, user *discordgo.User, input string) []*discordgo.ApplicationCommandOptionChoice {
+	ctx, cancel := context.WithTimeout(context.Background(), playAutocompleteBudget)
+	defer cancel()
+
+	input = strings.TrimSpace(input)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, playAutocompleteHistoryLimit+searchResultLimit)
+	seen := make(map[string]bool)
+
+	// A pasted link plays exactly as given
+	if common.IsYouTubeURL(input) {
+		if len(input) <= maxChoiceLength {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  truncateLabel("▶️ "+input, maxChoiceLength),
+				Value: input,
+			})
+		}
+		return choices
+	}
+
+	for _, entry := range playHistorySuggestions(ctx, guildID, user.ID, input) {
+		seen[entry.VideoID] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateLabel("🕘 "+entry.Title+suggestionDuration(time.Duration(entry.Duration)*time.Second), maxChoiceLength),
+			Value: common.YouTubeWatchURL(entry.VideoID),
+		})
+	}
+
+	if len([]rune(input)) < playAutocompleteMinQuery || !debounceAutocomplete(ctx, user.ID) {
+		return choices
+	}
+
+	results, err := cachedYouTubeSearch(ctx, input, searchResultLimit)
+	if err != nil {
+		// The search carries on in the background and is cached for the next keystroke
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("play").Debug("Autocomplete search unavailable", map[string]interface{}{
+			"guild_id": guildID,
+			"error":    err.Error(),
+		})
+		return choices
+	}
+
+	for _, result := range results {
+		if seen[result.VideoID] {
+			continue
+		}
+		seen[result.VideoID] = true
+
+		name := "🔎 " + result.Title
+		if result.Channel != "" {
+			name += " — " + result.Channel
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateLabel(name+suggestionDuration(result.Duration), maxChoiceLength),
+			Value: result.URL,
+		})
+	}
+
+	return choices
+}
+
+// suggestedTrack is a play history entry offered as a suggestion
+type suggestedTrack struct {
+	VideoID  string
+	Title    string
+	Duration int64
+	Own      bool // Requested by the user typing
+}
+
+// playHistorySuggestions returns matching tracks from the guild's history, the user's own requests first
+func playHistorySuggestions(ctx context.Context, guildID, userID, input string) []suggestedTrack {
+	if queueDB == nil || guildID == "" {
+		return nil
+	}
+
+	history, err := repository.NewPlayHistoryRepository(queueDB.WithContext(ctx)).SearchGuildHistory(guildID, input, playAutocompleteHistoryLimit*2)
+	if err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("play").Debug("Autocomplete history lookup failed", map[string]interface{}{
+			"guild_id": guildID,
+			"error":    err.Error(),
+		})
+		return nil
+	}
+
+	tracks := make([]suggestedTrack, len(history))
+	for i, entry := range history {
+		tracks[i] = suggestedTrack{
+			VideoID:  entry.VideoID,
+			Title:    entry.Title,
+			Duration: entry.Duration,
+			Own:      entry.RequesterID == userID,
+		}
+	}
+
+	sort.SliceStable(tracks, func(a, b int) bool {
+		return tracks[a].Own && !tracks[b].Own
+	})
+	if len(tracks) > playAutocompleteHistoryLimit {
+		tracks = tracks[:playAutocompleteHistoryLimit]
+	}
+	return tracks
+}
+
+// debounceAutocomplete waits briefly and reports whether this is still the user's latest keystroke
+func debounceAutocomplete(ctx context.Context, userID string) bool {
+	autocompleteMutex.Lock()
+	autocompleteSequence[userID]++
+	sequence := autocompleteSequence[userID]
+	autocompleteMutex.Unlock()
+
+	select {
+	case <-time.After(playAutocompleteDebounce):
+	case <-ctx.Done():
+		return false
+	}
+
+	autocompleteMutex.Lock()
+	defer autocompleteMutex.Unlock()
+
+	if autocompleteSequence[userID] != sequence {
+		return false
+	}
+	delete(autocompleteSequence, userID)
+	return true
+}
+
+// suggestionDuration renders a duration suffix for a suggestion, empty when unknown
+func suggestionDuration(duration time.Duration) string {
+	if duration <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", formatDuration(duration))
+}
@@ -0,0 +1,567 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/custom"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/internal/ratelimit"
+	"github.com/latoulicious/HKTM/internal/settings"
+	"github.com/latoulicious/HKTM/pkg/common"
+)
+
+func init() {
+	registerMusicCommands()
+	registerPlaybackCommands()
+	registerQueueCommands()
+	registerInformationCommands()
+	registerModerationCommands()
+	registerFunCommands()
+	registerOwnerCommands()
+}
+
+func registerMusicCommands() {
+	Register(&Command{
+		Name:        "play",
+		Aliases:     []string{"p"},
+		Description: "Add a song to the queue and play it",
+		Category:    CategoryMusic,
+		Args: []Arg{
+			{Name: "url", Description: "YouTube URL or search keywords", Type: ArgString, Required: true, Autocomplete: playAutocomplete},
+			{Name: "range", Description: "Only play part of the video, e.g. 1:20-3:45", Type: ArgString},
+		},
+		Usage: []Usage{
+			{"play <url>", "Play a YouTube video by URL"},
+			{"p <keywords>", "Search and play a YouTube video"},
+			{"play <url> <start-end>", "Play part of a video, e.g. `1:20-3:45` (`?t=` links also work)"},
+		},
+		Capability: permissions.QueueAdd,
+		// Every song resolved spawns yt-dlp
+		RateLimit: ratelimit.Limit{Uses: 3, Per: 15 * time.Second},
+		Slash:     true,
+		Run: func(ctx *Context) {
+			if ctx.IsInteraction() && ctx.String("range") != "" && !common.IsURL(ctx.String("url")) {
+				ctx.ReplyEphemeral("❌ A range can only be used with a video URL.")
+				return
+			}
+			PlayCommand(ctx)
+		},
+	})
+
+	Register(&Command{
+		Name:        "search",
+		Description: "Search YouTube and pick a song to queue",
+		Category:    CategoryMusic,
+		Args: []Arg{
+			{Name: "query", Description: "What to search for", Type: ArgText, Required: true},
+		},
+		Usage: []Usage{
+			{"search <keywords>", "Pick from the top YouTube results before queueing"},
+		},
+		Capability: permissions.QueueAdd,
+		RateLimit:  ratelimit.Limit{Uses: 3, Per: 30 * time.Second},
+		Slash:      true,
+		Run:        SearchCommand,
+	})
+
+	Register(&Command{
+		Name:        "nowplaying",
+		Aliases:     []string{"np"},
+		Description: "Show what's currently playing",
+		Category:    CategoryMusic,
+		Usage: []Usage{
+			{"nowplaying", "Show the player for the current track"},
+			{"nowplaying live <on|off>", "Keep the player's progress bar updating while songs play"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Toggling live progress changes the shared player
+			if len(args) > 1 && strings.ToLower(args[0]) == "live" {
+				return permissions.PlaybackControl
+			}
+			return permissions.None
+		},
+		Slash: true,
+		Run:   NowPlayingCommand,
+	})
+
+	Register(&Command{
+		Name:        "chapters",
+		Description: "List the current video's chapters",
+		Category:    CategoryMusic,
+		Slash:       true,
+		Run:         ChaptersCommand,
+	})
+
+	Register(&Command{
+		Name:        "chapter",
+		Description: "Jump to a chapter of the current video",
+		Category:    CategoryMusic,
+		Args: []Arg{
+			{Name: "chapter", Description: "next, prev or a chapter number", Type: ArgString, Required: true},
+		},
+		Usage: []Usage{
+			{"chapter <next|prev|number>", "Jump to a chapter of the current video"},
+		},
+		Capability: permissions.PlaybackControl,
+		Slash:      true,
+		Run:        ChapterCommand,
+	})
+}
+
+func registerPlaybackCommands() {
+	Register(&Command{
+		Name:        "pause",
+		Description: "Pause the current playback",
+		Category:    CategoryPlayback,
+		Capability:  permissions.PlaybackControl,
+		Slash:       true,
+		Run:         PauseCommand,
+	})
+
+	Register(&Command{
+		Name:        "resume",
+		Description: "Resume paused playback",
+		Category:    CategoryPlayback,
+		Capability:  permissions.PlaybackControl,
+		Slash:       true,
+		Run:         ResumeCommand,
+	})
+
+	Register(&Command{
+		Name:        "loop",
+		Description: "Repeat the current track or the whole queue",
+		Category:    CategoryPlayback,
+		Args: []Arg{
+			{Name: "mode", Description: "off, track or queue", Type: ArgString},
+		},
+		Usage: []Usage{
+			{"loop [off|track|queue]", "Repeat the current track or the whole queue"},
+		},
+		Capability: permissions.PlaybackControl,
+		Slash:      true,
+		Run:        LoopCommand,
+	})
+
+	Register(&Command{
+		Name:        "volume",
+		Aliases:     []string{"vol"},
+		Description: "Show or change the playback volume",
+		Category:    CategoryPlayback,
+		Args: []Arg{
+			{Name: "level", Description: "0-200, up or down", Type: ArgString},
+		},
+		Usage: []Usage{
+			{"volume [0-200|up|down]", "Show or change the playback volume"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may check the current volume
+			if len(args) == 0 {
+				return permissions.None
+			}
+			return permissions.PlaybackControl
+		},
+		Slash: true,
+		Run:   VolumeCommand,
+	})
+
+	Register(&Command{
+		Name:        "skip",
+		Description: "Skip the current song",
+		Category:    CategoryPlayback,
+		Usage: []Usage{
+			{"skip", "Vote to skip the current track (requesters skip their own songs instantly)"},
+			{"skip threshold <percent>", "Set the share of listeners needed to skip"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may vote; DJs skip instantly. The vote threshold is a guild setting
+			if len(args) > 1 && strings.ToLower(args[0]) == "threshold" {
+				return permissions.SettingsManage
+			}
+			return permissions.None
+		},
+		Slash: true,
+		Run:   SkipCommand,
+	})
+
+	Register(&Command{
+		Name:        "stop",
+		Description: "Stop playback and clear the queue",
+		Category:    CategoryPlayback,
+		Usage: []Usage{
+			{"stop", "Stop playback and disconnect from voice channel"},
+		},
+		Capability: permissions.PlaybackControl,
+		Slash:      true,
+		Run:        StopCommand,
+	})
+
+	Register(&Command{
+		Name:        "move",
+		Description: "Bring the bot to your voice channel without stopping the track",
+		Category:    CategoryPlayback,
+		Capability:  permissions.PlaybackControl,
+		Slash:       true,
+		Run:         MoveCommand,
+	})
+}
+
+func registerQueueCommands() {
+	Register(&Command{
+		Name:        "queue",
+		Aliases:     []string{"q"},
+		Description: "Manage the music queue",
+		Category:    CategoryQueue,
+		// Every prefix subcommand has a slash twin; only the `limits` alias is prefix-only
+		Subcommands: []Subcommand{
+			{Name: "add", Description: "Add a song to the queue", Args: []Arg{
+				{Name: "url", Description: "YouTube URL to add", Type: ArgString, Required: true},
+				{Name: "range", Description: "Only play part of the video, e.g. 1:20-3:45", Type: ArgString},
+			}},
+			{Name: "list", Description: "Show the current queue"},
+			{Name: "remove", Description: "Remove a song from the queue", Args: []Arg{
+				{Name: "index", Description: "Position of the song to remove (1-based)", Type: ArgInteger, Required: true},
+			}},
+			{Name: "clear", Description: "Clear the entire queue"},
+			{Name: "mode", Description: "Show or change how the queue is ordered", Args: []Arg{
+				{Name: "mode", Description: "fifo plays in order added, fair interleaves by requester", Type: ArgString, Choices: []string{"fifo", "fair"}},
+			}},
+			{Name: "limit", Description: "Show or change per-user queue caps", Args: []Arg{
+				{Name: "kind", Description: "Which cap to change", Type: ArgString, Choices: []string{"items", "duration"}},
+				{Name: "value", Description: "A song count or a duration like 30m; 0 or off disables it", Type: ArgString},
+			}},
+			{Name: "export", Description: "Download the queue as a file", Args: []Arg{
+				{Name: "format", Description: "File format, json by default", Type: ArgString, Choices: []string{"json", "m3u"}},
+			}},
+			{Name: "import", Description: "Add songs from a JSON, M3U or text file", Args: []Arg{
+				{Name: "file", Description: "A queue export, M3U playlist or one URL or search per line", Type: ArgAttachment, Required: true},
+			}},
+		},
+		Usage: []Usage{
+			{"queue add <url> [start-end]", "Add a YouTube video (or part of it) to the queue"},
+			{"queue list", "List the current queue"},
+			{"queue remove <position>", "Remove a track from the queue"},
+			{"queue mode <fifo|fair>", "Play in order added, or interleave by requester"},
+			{"queue limit <items|duration> <value>", "Set per-user queue caps"},
+			{"queue export [json|m3u]", "Download the queue as a file"},
+			{"queue import", "Add songs from an attached JSON, M3U or text file"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			if len(args) == 0 {
+				return permissions.None
+			}
+			switch strings.ToLower(args[0]) {
+			case "add", "import":
+				return permissions.QueueAdd
+			case "remove", "clear":
+				return permissions.QueueClear
+			case "mode", "limit", "limits":
+				// Anyone may view them; changing them changes guild settings
+				if len(args) > 1 {
+					return permissions.SettingsManage
+				}
+			}
+			return permissions.None
+		},
+		SubcommandLimits: map[string]ratelimit.Limit{
+			"add":    {Uses: 3, Per: 15 * time.Second},
+			"import": {Uses: 1, Per: time.Minute},
+		},
+		Slash: true,
+		Run:   QueueCommand,
+	})
+
+	Register(&Command{
+		Name:        "clear",
+		Description: "Clear the entire queue",
+		Category:    CategoryQueue,
+		Args: []Arg{
+			{Name: "answer", Description: "Answer the confirmation asked for long queues", Type: ArgString, Choices: []string{"confirm", "cancel"}},
+		},
+		Usage: []Usage{
+			{"clear [confirm|cancel]", "Clear the queue; long queues ask for confirmation first"},
+		},
+		Capability: permissions.QueueClear,
+		Slash:      true,
+		Run:        ClearCommand,
+	})
+
+	Register(&Command{
+		Name:        "shuffle",
+		Description: "Shuffle the queue",
+		Category:    CategoryQueue,
+		Args: []Arg{
+			{Name: "announce", Description: "Announce the new top song", Type: ArgString, Choices: []string{"announce"}},
+		},
+		Usage: []Usage{
+			{"shuffle [announce]", "Shuffle the queue and optionally announce the new top song"},
+		},
+		Capability: permissions.PlaybackControl,
+		Slash:      true,
+		Run:        ShuffleCommand,
+	})
+}
+
+func registerInformationCommands() {
+	Register(&Command{
+		Name:        "about",
+		Description: "Show bot info, uptime, and stats",
+		Category:    CategoryInformation,
+		Slash:       true,
+		Run:         AboutCommand,
+	})
+
+	Register(&Command{
+		Name:        "version",
+		Aliases:     []string{"v"},
+		Description: "Show bot version information",
+		Category:    CategoryInformation,
+		Slash:       true,
+		Run:         VersionCommand,
+	})
+
+	Register(&Command{
+		Name:        "help",
+		Aliases:     []string{"h"},
+		Description: "Show this help message",
+		Category:    CategoryInformation,
+		Slash:       true,
+		Run:         ShowHelpCommand,
+	})
+}
+
+func registerModerationCommands() {
+	Register(&Command{
+		Name:        "delete",
+		Description: "Delete the specified number of recent messages",
+		Category:    CategoryModeration,
+		Args: []Arg{
+			{Name: "number", Description: "How many messages to delete", Type: ArgInteger, Required: true},
+		},
+		Capability: permissions.ModerationDelete,
+		RateLimit:  ratelimit.Limit{Uses: 2, Per: 30 * time.Second},
+		Run:        DeleteCommand,
+	})
+
+	Register(&Command{
+		Name:        "perms",
+		Description: "Map command capabilities to roles",
+		Category:    CategoryModeration,
+		Usage: []Usage{
+			{"perms list", "Show which roles hold each command capability"},
+			{"perms grant|revoke <capability> <@role>", "Map a capability to a role"},
+			{"perms dj <@role>", "Make a role the DJ role (instant skip, playback and queue control)"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may view the current mappings
+			if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+				return permissions.None
+			}
+			return permissions.PermissionsManage
+		},
+		Run: PermsCommand,
+	})
+
+	kindChoices := make([]string, len(custom.Kinds))
+	for i, kind := range custom.Kinds {
+		kindChoices[i] = string(kind)
+	}
+
+	Register(&Command{
+		Name:        "custom",
+		Description: "Manage this server's custom commands and aliases",
+		Category:    CategoryModeration,
+		Subcommands: []Subcommand{
+			{Name: "list", Description: "Show this server's custom commands"},
+			{Name: "add", Description: "Add or replace a custom command", Args: []Arg{
+				{Name: "name", Description: "Name to invoke it by", Type: ArgString, Required: true},
+				{Name: "type", Description: "alias runs a command; text and embed reply", Type: ArgString, Required: true, Choices: kindChoices},
+				{Name: "content", Description: "Command to run, or the response; supports {user}, {channel}, {track}, {queue.size}", Type: ArgText, Required: true},
+			}},
+			{Name: "remove", Description: "Delete a custom command", Args: []Arg{
+				{Name: "name", Description: "Custom command to delete", Type: ArgString, Required: true},
+			}},
+		},
+		Usage: []Usage{
+			{"custom list", "Show this server's custom commands"},
+			{"custom add <name> alias|text|embed <content>", "Add a command alias or a canned reply"},
+			{"custom remove <name>", "Delete a custom command"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may see the custom commands
+			if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+				return permissions.None
+			}
+			return permissions.CustomManage
+		},
+		Slash: true,
+		Run:   CustomCommand,
+	})
+
+	Register(&Command{
+		Name:        "ratelimit",
+		Description: "View and override command rate limits",
+		Category:    CategoryModeration,
+		Subcommands: []Subcommand{
+			{Name: "list", Description: "Show the command rate limits"},
+			{Name: "set", Description: "Override a command's rate limit", Args: []Arg{
+				{Name: "command", Description: "Command to limit, e.g. play or uma sync", Type: ArgString, Required: true, Autocomplete: rateLimitAutocomplete},
+				{Name: "limit", Description: "Uses per window such as 5/30s, or off", Type: ArgString, Required: true},
+			}},
+			{Name: "reset", Description: "Restore a command's default rate limit", Args: []Arg{
+				{Name: "command", Description: "Command to reset", Type: ArgString, Required: true, Autocomplete: rateLimitAutocomplete},
+			}},
+		},
+		Usage: []Usage{
+			{"ratelimit list", "Show how often each command may be used"},
+			{"ratelimit set|reset <command> [uses/window]", "Override or restore a command's limit"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may see the limits
+			if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+				return permissions.None
+			}
+			return permissions.SettingsManage
+		},
+		Slash: true,
+		Run:   RateLimitCommand,
+	})
+
+	settingChoices := make([]string, len(settings.Keys))
+	for i, key := range settings.Keys {
+		settingChoices[i] = string(key)
+	}
+
+	Register(&Command{
+		Name:        "settings",
+		Description: "Show and change this server's settings",
+		Category:    CategoryModeration,
+		Subcommands: []Subcommand{
+			{Name: "show", Description: "Show this server's settings"},
+			{Name: "set", Description: "Change a setting", Args: []Arg{
+				{Name: "setting", Description: "Setting to change", Type: ArgString, Required: true, Choices: settingChoices},
+				{Name: "value", Description: "New value; use none to clear a channel setting", Type: ArgText, Required: true},
+			}},
+			{Name: "reset", Description: "Restore a setting, or all of them, to the default", Args: []Arg{
+				{Name: "setting", Description: "Setting to reset; all settings when left out", Type: ArgString, Choices: append(settingChoices, "all")},
+			}},
+			{Name: "history", Description: "Show recent setting changes"},
+		},
+		Usage: []Usage{
+			{"settings show", "Show this server's settings"},
+			{"settings set <setting> <value>", "Change a server setting"},
+			{"settings reset [setting]", "Restore settings to their defaults"},
+			{"settings history", "Show recent setting changes"},
+		},
+		Capability: permissions.SettingsManage,
+		Slash:      true,
+		Run:        SettingsCommand,
+	})
+}
+
+func registerFunCommands() {
+	Register(&Command{
+		Name:        "gremlin",
+		Description: "Post a random gremlin image",
+		Category:    CategoryFun,
+		Slash:       true,
+		Run:         GremlinCommand,
+	})
+
+	Register(&Command{
+		Name:        "uma",
+		Description: "Search Uma Musume characters and support cards",
+		Category:    CategoryFun,
+		Subcommands: []Subcommand{
+			{Name: "char", Description: "Search for a character", Args: []Arg{
+				{Name: "name", Description: "Character name in English or Japanese", Type: ArgText, Required: true, Autocomplete: umaCharacterAutocomplete},
+			}},
+			{Name: "support", Description: "Search for a support card", Args: []Arg{
+				{Name: "name", Description: "Support card title in English or Japanese", Type: ArgText, Required: true, Autocomplete: umaSupportAutocomplete},
+			}},
+			{Name: "skills", Description: "Get the skills of a support card", Args: []Arg{
+				{Name: "name", Description: "Support card title in English or Japanese", Type: ArgText, Required: true, Autocomplete: umaSupportAutocomplete},
+			}},
+			{Name: "sync", Description: "Sync all characters and support cards into the database"},
+			{Name: "refresh", Description: "Refresh the Gametora API build ID"},
+			{Name: "cache", Description: "Show how much Uma data is cached"},
+			{Name: "alias", Description: "Manage character and support card nicknames", Args: []Arg{
+				{Name: "action", Description: "List, add or remove nicknames", Type: ArgString, Required: true, Choices: []string{"list", "add", "remove"}},
+				{Name: "type", Description: "Whether the nickname stands for a character or a support card", Type: ArgString, Choices: []string{"character", "support"}},
+				{Name: "alias", Description: "The nickname", Type: ArgString},
+				{Name: "name", Description: "Character name or support card title it stands for", Type: ArgText},
+			}},
+		},
+		Usage: []Usage{
+			{"uma char <name>", "Search for Uma Musume characters"},
+			{"uma support <name>", "Search for Uma Musume support cards"},
+			{"uma skills <name>", "Get skills for a support card"},
+			{"uma alias list [character|support]", "List nicknames searches understand"},
+			{"uma alias add <character|support> <nickname> = <name>", "Teach searches a nickname (moderators)"},
+			{"uma alias remove <character|support> <nickname>", "Turn a nickname off in this server (moderators)"},
+		},
+		CapabilityFor: func(args []string) permissions.Capability {
+			// Anyone may list nicknames; changing them is moderation
+			if len(args) > 1 && strings.ToLower(args[0]) == "alias" {
+				switch strings.ToLower(args[1]) {
+				case "add", "remove":
+					return permissions.UmaAliasManage
+				}
+			}
+			return permissions.None
+		},
+		// Searches call the umapyoi and Gametora APIs; syncs fetch everything
+		RateLimit: ratelimit.Limit{Uses: 5, Per: 30 * time.Second},
+		SubcommandLimits: map[string]ratelimit.Limit{
+			"sync":    {Uses: 1, Per: 10 * time.Minute},
+			"refresh": {Uses: 1, Per: 5 * time.Minute},
+		},
+		Slash: true,
+		Run:   UmaCommand,
+	})
+}
+
+func registerOwnerCommands() {
+	Register(&Command{
+		Name:        "servers",
+		Description: "List servers the bot is connected to",
+		Category:    CategoryInformation,
+		Usage: []Usage{
+			{"servers", "List servers the bot is connected to (bot owner only)"},
+		},
+		Capability: permissions.OwnerServers,
+		Slash:      true,
+		Ephemeral:  true,
+		Run:        ServersCommand,
+	})
+
+	Register(&Command{
+		Name:        "utility",
+		Description: "Bot maintenance tools",
+		Category:    CategoryUtility,
+		Usage: []Usage{
+			{"utility cron", "Check cron job status"},
+			{"utility cron-refresh", "Manually trigger build ID refresh"},
+			{"utility streams", "Show stream URL cache statistics"},
+		},
+		Capability: permissions.OwnerUtility,
+		Run:        UtilityCommand,
+	})
+
+	Register(&Command{
+		Name:        "leave",
+		Description: "Force bot to leave a server by ID",
+		Category:    CategoryAdmin,
+		Args: []Arg{
+			{Name: "server_id", Description: "ID of the server to leave", Type: ArgString, Required: true},
+		},
+		Capability: permissions.OwnerLeave,
+		Run:        LeaveCommand,
+	})
+}
+
+// playAutocomplete suggests recent and queued songs for /play
+func playAutocomplete(i *discordgo.InteractionCreate, value string) []*discordgo.ApplicationCommandOptionChoice {
+	return PlayAutocomplete(i.GuildID, interactionUser(i), value)
+}
@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+const (
+	// maxChapterListLength keeps the chapter list under Discord's description limit
+	maxChapterListLength = 3800
+	// maxChapterWatchInterval bounds how long a pause or seek can go unnoticed
+	maxChapterWatchInterval = 15 * time.Second
+	// minChapterWatchInterval avoids spinning right at a chapter boundary
+	minChapterWatchInterval = time.Second
+)
+
+var (
+	// Stop channels for the running chapter watcher per guild
+	chapterWatcherStops = make(map[string]chan struct{})
+	chapterWatcherMutex sync.Mutex
+)
+
+// ChaptersCommand lists the chapters of the current track
+func ChaptersCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	// Update activity
+	updateActivity(guildID)
+
+	queue := getQueue(guildID)
+	if queue == nil || queue.Current() == nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.chapters.nothing_playing"), 0xff0000)
+		return
+	}
+
+	item := queue.Current()
+	if len(item.Chapters) == 0 {
+		replyEmbedMessage(ctx, ctx.T("commands.chapters.none.title"), ctx.T("commands.chapters.none.description", item.Title), 0x808080)
+		return
+	}
+
+	current := item.ChapterAt(queue.Elapsed())
+
+	var lines []string
+	length := 0
+	for i, chapter := range item.Chapters {
+		marker := "　"
+		if i == current {
+			marker = "▶️"
+		}
+		line := fmt.Sprintf("%s `%d.` `%s` %s", marker, i+1, common.FormatTimestamp(chapter.Start), chapter.Title)
+		if length+len(line) > maxChapterListLength {
+			lines = append(lines, ctx.T("commands.chapters.more", len(item.Chapters)-i))
+			break
+		}
+		lines = append(lines, line)
+		length += len(line) + 1
+	}
+
+	description := fmt.Sprintf("**%s**\n\n%s\n\n%s", item.Title, strings.Join(lines, "\n"),
+		ctx.T("commands.chapters.hint", commandDisplayName(ctx, "chapter")))
+	ctx.ReplyEmbed(embedsFor(ctx).Info(ctx.T("commands.chapters.title", len(item.Chapters)), description))
+}
+
+// ChapterCommand seeks the current track to the next, previous or a numbered chapter
+func ChapterCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("chapter")
+
+	// Update activity
+	updateActivity(guildID)
+
+	if len(ctx.Args) == 0 {
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.chapter.usage", commandDisplayName(ctx, "chapter")), 0xff0000)
+		return
+	}
+
+	queue := getQueue(guildID)
+	if queue == nil || queue.Current() == nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.chapters.nothing_playing"), 0xff0000)
+		return
+	}
+
+	item := queue.Current()
+	if len(item.Chapters) == 0 {
+		replyEmbedMessage(ctx, ctx.T("commands.chapters.none.title"), ctx.T("commands.chapters.none.description", item.Title), 0x808080)
+		return
+	}
+
+	index, err := resolveChapter(item, queue.Elapsed(), ctx.Args[0])
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), localizeError(ctx.Language, err), 0xff0000)
+		return
+	}
+
+	chapter := item.Chapters[index]
+	position := chapter.Start - item.StartOffset
+	if position < 0 {
+		// The chapter starts before a clipped item does
+		position = 0
+	}
+
+	if err := queue.Seek(position); err != nil {
+		logger.Error("Failed to seek to chapter", err, map[string]interface{}{
+			"guild_id": guildID,
+			"user_id":  ctx.Author.ID,
+			"chapter":  index + 1,
+		})
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.chapter.seek_failed", localizeError(ctx.Language, err)), 0xff0000)
+		return
+	}
+
+	logger.Info("Seeked to chapter", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  ctx.Author.ID,
+		"chapter":  index + 1,
+		"position": position.String(),
+	})
+
+	replyEmbedMessage(ctx, ctx.T("commands.chapter.jumped.title", index+1),
+		ctx.T("commands.chapter.jumped.description", chapter.Title, common.FormatTimestamp(chapter.Start)), 0x00ff00)
+
+	refreshNowPlayingPanel(ctx.Session, guildID)
+	startChapterWatcher(ctx.Session, guildID, queue)
+}
+
+// resolveChapter turns next, prev or a 1-based chapter number into a chapter index
+// the item's clip actually plays
+func resolveChapter(item *common.QueueItem, position time.Duration, target string) (int, error) {
+	current := item.ChapterAt(position)
+
+	var index int
+	switch strings.ToLower(target) {
+	case "next", "n":
+		index = current + 1
+		if index >= len(item.Chapters) {
+			return 0, i18n.Errorf("commands.chapter.error.last")
+		}
+	case "prev", "previous", "p", "back":
+		index = current - 1
+		if index < 0 {
+			index = 0
+		}
+	default:
+		number, err := strconv.Atoi(target)
+		if err != nil || number < 1 || number > len(item.Chapters) {
+			return 0, i18n.Errorf("commands.chapter.error.range", len(item.Chapters))
+		}
+		index = number - 1
+	}
+
+	// Clipped items can only jump within their clip
+	chapter := item.Chapters[index]
+	if chapter.End <= item.StartOffset || (item.Duration > 0 && chapter.Start >= item.StartOffset+item.Duration) {
+		return 0, i18n.Errorf("commands.chapter.error.outside_clip", index+1)
+	}
+	return index, nil
+}
+
+// loadChapters stores the current track's chapters on its queue item and starts
+// following them, so the panel and presence show the chapter playing
+func loadChapters(s *discordgo.Session, guildID string, queue *common.MusicQueue, item *common.QueueItem) {
+	if item.Chapters == nil && item.OriginalURL != "" && item.VideoID != "" {
+		chapters, err := common.GetYouTubeChapters(item.OriginalURL)
+		if err != nil {
+			logging.GetGlobalLoggerFactory().CreateCommandLogger("chapter").Warn("Failed to load chapters", map[string]interface{}{
+				"guild_id": guildID,
+				"video_id": item.VideoID,
+				"error":    err.Error(),
+			})
+			return
+		}
+		queue.SetChapters(item, chapters)
+	}
+
+	if len(item.Chapters) > 0 && queue.Current() == item {
+		startChapterWatcher(s, guildID, queue)
+	}
+}
+
+// startChapterWatcher (re)starts following the chapters of the guild's current track
+func startChapterWatcher(s *discordgo.Session, guildID string, queue *common.MusicQueue) {
+	stopChapterWatcher(guildID)
+
+	track := queue.Current()
+	if track == nil || len(track.Chapters) == 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	chapterWatcherMutex.Lock()
+	chapterWatcherStops[guildID] = stop
+	chapterWatcherMutex.Unlock()
+
+	go runChapterWatcher(s, guildID, queue, track, stop)
+}
+
+// stopChapterWatcher stops the guild's chapter watcher, if any
+func stopChapterWatcher(guildID string) {
+	chapterWatcherMutex.Lock()
+	defer chapterWatcherMutex.Unlock()
+
+	if stop, exists := chapterWatcherStops[guildID]; exists {
+		close(stop)
+		delete(chapterWatcherStops, guildID)
+	}
+}
+
+// runChapterWatcher updates the presence and panel whenever a new chapter starts,
+// sleeping until the next chapter boundary in between
+func runChapterWatcher(s *discordgo.Session, guildID string, queue *common.MusicQueue, track *common.QueueItem, stop chan struct{}) {
+	shown := -1
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+
+		// Stop cleanly once the track has ended or been replaced
+		if queue.Current() != track || !queue.IsPlaying() {
+			return
+		}
+
+		position := queue.Elapsed()
+		index := track.ChapterAt(position)
+		if index != shown {
+			shown = index
+			if index >= 0 {
+				if presenceManager != nil {
+					presenceManager.UpdateMusicPresence(guildID, fmt.Sprintf("%s · %s", track.Title, track.Chapters[index].Title))
+				}
+				// The panel already shows the chapter while live progress is on
+				if !isLiveProgressEnabled(guildID) {
+					editNowPlayingPanel(s, guildID, queue, discordgo.WithRetryOnRatelimit(false))
+				}
+			}
+		}
+
+		// Wake up when the next chapter starts; paused tracks are checked periodically
+		wait := maxChapterWatchInterval
+		if next := index + 1; next < len(track.Chapters) && !queue.IsPaused() {
+			if untilNext := track.Chapters[next].Start - track.StartOffset - position; untilNext < wait {
+				wait = untilNext
+			}
+		}
+		if wait < minChapterWatchInterval {
+			wait = minChapterWatchInterval
+		}
+		timer.Reset(wait)
+	}
+}
@@ -6,23 +6,24 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
 	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
 // ClearCommand handles the !clear command to empty the queue
-func ClearCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	guildID := m.GuildID
+func ClearCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("clear")
 	logger.Info("Clear command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
-		"args_count": len(args),
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
 
 	// Update activity
@@ -33,9 +34,9 @@ func ClearCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []strin
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "No queue found for this server.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Error", "No queue found for this server.", 0xff0000)
 		return
 	}
 
@@ -43,43 +44,43 @@ func ClearCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []strin
 	if queue.Size() == 0 && queue.Current() == nil {
 		logger.Info("Clear command called on empty queue", map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "📭 Queue Already Empty", "The queue is already empty.", 0x808080)
+		replyEmbedMessage(ctx, "📭 Queue Already Empty", "The queue is already empty.", 0x808080)
 		return
 	}
 
 	// Handle confirmation arguments
-	if len(args) > 0 {
-		arg := strings.ToLower(args[0])
+	if len(ctx.Args) > 0 {
+		arg := strings.ToLower(ctx.Args[0])
 		switch arg {
 		case "confirm":
 			// User confirmed the clear
 			logger.Info("Queue clear confirmed", map[string]interface{}{
 				"guild_id": guildID,
-				"user_id":  m.Author.ID,
+				"user_id":  ctx.Author.ID,
 			})
-			clearQueueInternal(s, m, queue, logger)
+			clearQueueInternal(ctx, queue, logger)
 			return
 		case "cancel":
 			// User cancelled the clear
 			logger.Info("Queue clear cancelled", map[string]interface{}{
 				"guild_id": guildID,
-				"user_id":  m.Author.ID,
+				"user_id":  ctx.Author.ID,
 			})
-			sendEmbedMessage(s, m.ChannelID, "❌ Cancelled", "Queue clear operation cancelled.", 0x808080)
+			replyEmbedMessage(ctx, "❌ Cancelled", "Queue clear operation cancelled.", 0x808080)
 			return
 		}
 	}
 
-	// Check if user has admin permissions
-	hasAdmin := hasAdminPermissions(s, m.GuildID, m.Author.ID)
+	// Check if user is an admin or holds a DJ-style grant for clearing
+	hasAdmin := hasCapabilityGrant(ctx.Session, ctx.GuildID, ctx.Author.ID, permissions.QueueClear)
 
 	// If user is not admin and there are multiple songs, ask for confirmation
 	if !hasAdmin && queue.Size() > 3 {
 		logger.Info("Requesting confirmation for queue clear", map[string]interface{}{
 			"guild_id":   guildID,
-			"user_id":    m.Author.ID,
+			"user_id":    ctx.Author.ID,
 			"queue_size": queue.Size(),
 			"has_admin":  hasAdmin,
 		})
@@ -100,33 +101,33 @@ func ClearCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []strin
 				},
 				{
 					Name:   "Requested By",
-					Value:  m.Author.Username,
+					Value:  ctx.Author.Username,
 					Inline: true,
 				},
 			},
 		}
-		_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		err := ctx.ReplyEmbed(embed)
 		if err != nil {
 			logger.Error("Failed to send confirmation embed", err, map[string]interface{}{
-				"channel_id": m.ChannelID,
-				"guild_id":   m.GuildID,
+				"channel_id": ctx.ChannelID,
+				"guild_id":   ctx.GuildID,
 			})
 		}
 		return
 	}
 
 	// Clear the queue
-	clearQueueInternal(s, m, queue, logger)
+	clearQueueInternal(ctx, queue, logger)
 }
 
 // clearQueueInternal performs the actual queue clearing
-func clearQueueInternal(s *discordgo.Session, m *discordgo.MessageCreate, queue *common.MusicQueue, logger logging.Logger) {
+func clearQueueInternal(ctx *Context, queue *common.MusicQueue, logger logging.Logger) {
 	// Get queue size before clearing for the message
 	queueSize := queue.Size()
 
 	logger.Info("Clearing queue", map[string]interface{}{
-		"guild_id":          m.GuildID,
-		"user_id":           m.Author.ID,
+		"guild_id":          ctx.GuildID,
+		"user_id":           ctx.Author.ID,
 		"queue_size_before": queueSize,
 	})
 
@@ -150,38 +151,16 @@ func clearQueueInternal(s *discordgo.Session, m *discordgo.MessageCreate, queue
 			},
 			{
 				Name:   "Cleared By",
-				Value:  m.Author.Username,
+				Value:  ctx.Author.Username,
 				Inline: true,
 			},
 		},
 	}
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	err := ctx.ReplyEmbed(embed)
 	if err != nil {
 		logger.Error("Failed to send queue cleared embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
 	}
 }
-
-// hasAdminPermissions checks if a user has admin permissions
-func hasAdminPermissions(s *discordgo.Session, guildID, userID string) bool {
-	// Get guild member
-	member, err := s.GuildMember(guildID, userID)
-	if err != nil {
-		return false
-	}
-
-	// Check if user has admin permission
-	for _, roleID := range member.Roles {
-		role, err := s.State.Role(guildID, roleID)
-		if err != nil {
-			continue
-		}
-		if role.Permissions&discordgo.PermissionAdministrator != 0 {
-			return true
-		}
-	}
-
-	return false
-}
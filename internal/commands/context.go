@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// Context is what a registered command runs with, whether it was typed as a prefix
+// command or invoked as a slash command. Replies go back the way the command came in.
+type Context struct {
+	Session   *discordgo.Session
+	GuildID   string
+	ChannelID string
+	Author    *discordgo.User
+	Command   *Command
+
+	// Language is the language replies are rendered in: the user's Discord client
+	// language for slash commands when there is a catalog for it, otherwise the
+	// guild's language setting
+	Language string
+
+	// Args are the prefix-style arguments, subcommand first; slash options are
+	// flattened into them in their declared order
+	Args []string
+
+	// MessageID is the message that invoked a prefix command; empty otherwise
+	MessageID string
+
+	options     map[string]string                       // Declared arguments by name
+	attachments map[string]*discordgo.MessageAttachment // Declared attachment arguments by name
+	interaction *discordgo.InteractionCreate
+	progress    *discordgo.Message // Status message of a prefix command, replaced by its first reply
+
+	mutex     sync.Mutex
+	deferred  bool // The interaction was acknowledged with a "thinking" response
+	responded bool // The interaction response was used for a reply
+}
+
+// newMessageContext creates the context for a prefix command
+func newMessageContext(s *discordgo.Session, m *discordgo.MessageCreate, cmd *Command, args []string) *Context {
+	ctx := &Context{
+		Session:     s,
+		GuildID:     m.GuildID,
+		ChannelID:   m.ChannelID,
+		Author:      m.Author,
+		Command:     cmd,
+		Args:        args,
+		Language:    GuildSettings(m.GuildID).Language,
+		MessageID:   m.ID,
+		options:     make(map[string]string),
+		attachments: make(map[string]*discordgo.MessageAttachment),
+	}
+
+	// Bind the words to the declared arguments; a text argument takes the rest.
+	// Attachment arguments take the message's attachments in order instead.
+	declared := cmd.Args
+	words, attachments := args, m.Attachments
+	if sub := cmd.subcommand(args); sub != nil {
+		declared = sub.Args
+		words = args[1:]
+	}
+	for _, arg := range declared {
+		switch {
+		case arg.Type == ArgAttachment:
+			if len(attachments) > 0 {
+				ctx.attachments[arg.Name] = attachments[0]
+				attachments = attachments[1:]
+			}
+		case len(words) == 0:
+		case arg.Type == ArgText:
+			ctx.options[arg.Name] = strings.Join(words, " ")
+			words = nil
+		default:
+			ctx.options[arg.Name] = words[0]
+			words = words[1:]
+		}
+	}
+
+	return ctx
+}
+
+// newInteractionContext creates the context for a slash command
+func newInteractionContext(s *discordgo.Session, i *discordgo.InteractionCreate, cmd *Command) *Context {
+	ctx := &Context{
+		Session:     s,
+		GuildID:     i.GuildID,
+		ChannelID:   i.ChannelID,
+		Author:      interactionUser(i),
+		Command:     cmd,
+		Language:    interactionLanguage(i),
+		options:     make(map[string]string),
+		attachments: make(map[string]*discordgo.MessageAttachment),
+		interaction: i,
+	}
+
+	data := i.ApplicationCommandData()
+	declared := cmd.Args
+	options := data.Options
+	if len(options) > 0 && options[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		ctx.Args = append(ctx.Args, options[0].Name)
+		if sub := cmd.subcommand(ctx.Args); sub != nil {
+			declared = sub.Args
+		}
+		options = options[0].Options
+	}
+
+	values := make(map[string]string)
+	for _, option := range options {
+		switch option.Type {
+		case discordgo.ApplicationCommandOptionInteger:
+			values[option.Name] = strconv.FormatInt(option.IntValue(), 10)
+		case discordgo.ApplicationCommandOptionAttachment:
+			// The value is the ID of an attachment resolved with the interaction
+			if id, ok := option.Value.(string); ok && data.Resolved != nil {
+				if attachment := data.Resolved.Attachments[id]; attachment != nil {
+					ctx.attachments[option.Name] = attachment
+				}
+			}
+		default:
+			values[option.Name] = option.StringValue()
+		}
+	}
+
+	for _, arg := range declared {
+		value, exists := values[arg.Name]
+		if !exists || arg.Type == ArgAttachment {
+			continue
+		}
+		ctx.options[arg.Name] = value
+		if arg.Type == ArgText {
+			ctx.Args = append(ctx.Args, strings.Fields(value)...)
+		} else {
+			ctx.Args = append(ctx.Args, value)
+		}
+	}
+
+	return ctx
+}
+
+// newComponentContext creates the context for a button or menu that runs a command on
+// the pressing user's behalf. The press is acknowledged on its own, so every reply is
+// a follow-up in the channel.
+func newComponentContext(s *discordgo.Session, i *discordgo.InteractionCreate, cmd *Command) *Context {
+	return &Context{
+		Session:     s,
+		GuildID:     i.GuildID,
+		ChannelID:   i.ChannelID,
+		Author:      interactionUser(i),
+		Command:     cmd,
+		Language:    interactionLanguage(i),
+		options:     make(map[string]string),
+		attachments: make(map[string]*discordgo.MessageAttachment),
+		interaction: i,
+		responded:   true,
+	}
+}
+
+// newChannelContext creates the context for a command the bot runs by itself, such as
+// a skip once enough voters remain; replies are posted to the channel
+func newChannelContext(s *discordgo.Session, guildID, channelID string, cmd *Command) *Context {
+	return &Context{
+		Session:     s,
+		GuildID:     guildID,
+		ChannelID:   channelID,
+		Author:      s.State.User,
+		Command:     cmd,
+		Language:    GuildSettings(guildID).Language,
+		options:     make(map[string]string),
+		attachments: make(map[string]*discordgo.MessageAttachment),
+	}
+}
+
+// IsInteraction reports whether the command was invoked as a slash command
+func (c *Context) IsInteraction() bool {
+	return c.interaction != nil
+}
+
+// awaitingReply reports whether a slash command hasn't replied yet, so its deferred
+// "thinking" response is still showing
+func (c *Context) awaitingReply() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.interaction != nil && !c.responded
+}
+
+// String returns a declared argument, or "" when it wasn't given
+func (c *Context) String(name string) string {
+	return c.options[name]
+}
+
+// Int returns a declared integer argument
+func (c *Context) Int(name string) (int, bool) {
+	value, err := strconv.Atoi(c.options[name])
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// Attachment returns a declared attachment argument, or nil when it wasn't given
+func (c *Context) Attachment(name string) *discordgo.MessageAttachment {
+	return c.attachments[name]
+}
+
+// T returns a message from the catalog in the context's language
+func (c *Context) T(key string, args ...interface{}) string {
+	return i18n.T(c.Language, key, args...)
+}
+
+// Reply sends a text reply
+func (c *Context) Reply(content string) error {
+	_, err := c.reply(&discordgo.MessageSend{Content: content}, false)
+	return err
+}
+
+// ReplyEmbed sends an embed reply
+func (c *Context) ReplyEmbed(embed *discordgo.MessageEmbed) error {
+	_, err := c.reply(&discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}}, false)
+	return err
+}
+
+// ReplyEmbedMessage sends an embed reply and returns the message it became, so
+// callers can react to it or edit it later
+func (c *Context) ReplyEmbedMessage(embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
+	return c.reply(&discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}}, false)
+}
+
+// ReplyEmbedComponents sends an embed reply with buttons or menus and returns the
+// message it became
+func (c *Context) ReplyEmbedComponents(embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) (*discordgo.Message, error) {
+	return c.reply(&discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, Components: components}, false)
+}
+
+// ReplyEmbedFile sends an embed reply with a file attached
+func (c *Context) ReplyEmbedFile(embed *discordgo.MessageEmbed, file *discordgo.File) error {
+	_, err := c.reply(&discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, Files: []*discordgo.File{file}}, false)
+	return err
+}
+
+// ReplyEphemeral sends a text reply only the invoking user sees. Prefix commands
+// can't hide replies, so they get a regular one.
+func (c *Context) ReplyEphemeral(content string) error {
+	_, err := c.reply(&discordgo.MessageSend{Content: content}, true)
+	return err
+}
+
+// ReplyEmbedEphemeral sends an embed reply only the invoking user sees, where possible
+func (c *Context) ReplyEmbedEphemeral(embed *discordgo.MessageEmbed) error {
+	_, err := c.reply(&discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}}, true)
+	return err
+}
+
+// Progress shows what a slow command is doing, e.g. "Searching...". Slash commands
+// show it in place of the "thinking" placeholder; prefix commands post it once and
+// edit it on later calls. The command's first reply replaces it either way.
+func (c *Context) Progress(content string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.interaction != nil {
+		if c.responded {
+			return nil
+		}
+		_, err := c.Session.InteractionResponseEdit(c.interaction.Interaction, &discordgo.WebhookEdit{Content: &content})
+		return err
+	}
+
+	if c.progress != nil {
+		_, err := c.Session.ChannelMessageEdit(c.ChannelID, c.progress.ID, content)
+		return err
+	}
+	msg, err := c.Session.ChannelMessageSend(c.ChannelID, content)
+	if err == nil {
+		c.progress = msg
+	}
+	return err
+}
+
+// reply routes a reply to the channel or the interaction
+func (c *Context) reply(send *discordgo.MessageSend, ephemeral bool) (*discordgo.Message, error) {
+	// Replies never ping anyone
+	send.AllowedMentions = &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.interaction == nil {
+		if c.progress != nil {
+			// The first reply takes over the status message
+			edit := discordgo.NewMessageEdit(c.ChannelID, c.progress.ID)
+			edit.Content = &send.Content
+			edit.Embeds = &send.Embeds
+			edit.AllowedMentions = send.AllowedMentions
+			edit.Files = send.Files
+			if len(send.Components) > 0 {
+				edit.Components = &send.Components
+			}
+			c.progress = nil
+			return c.Session.ChannelMessageEditComplex(edit)
+		}
+		return c.Session.ChannelMessageSendComplex(c.ChannelID, send)
+	}
+
+	// Replies sent before the command is deferred, such as denials, answer the
+	// interaction directly with the visibility they ask for
+	if !c.responded && !c.deferred {
+		c.responded = true
+		data := &discordgo.InteractionResponseData{
+			Content:         send.Content,
+			Embeds:          send.Embeds,
+			Components:      send.Components,
+			Files:           send.Files,
+			AllowedMentions: send.AllowedMentions,
+		}
+		if ephemeral {
+			data.Flags = discordgo.MessageFlagsEphemeral
+		}
+		err := c.Session.InteractionRespond(c.interaction.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.Session.InteractionResponse(c.interaction.Interaction)
+	}
+
+	// The deferred response keeps the visibility it was deferred with, so it only
+	// carries replies of the same kind; everything else becomes a follow-up
+	if !c.responded && ephemeral == c.Command.Ephemeral {
+		c.responded = true
+		edit := &discordgo.WebhookEdit{
+			Content:         &send.Content,
+			Embeds:          &send.Embeds,
+			Files:           send.Files,
+			AllowedMentions: send.AllowedMentions,
+		}
+		if len(send.Components) > 0 {
+			edit.Components = &send.Components
+		}
+		return c.Session.InteractionResponseEdit(c.interaction.Interaction, edit)
+	}
+
+	params := &discordgo.WebhookParams{
+		Content:         send.Content,
+		Embeds:          send.Embeds,
+		Components:      send.Components,
+		Files:           send.Files,
+		AllowedMentions: send.AllowedMentions,
+	}
+	if ephemeral {
+		params.Flags = discordgo.MessageFlagsEphemeral
+	}
+	return c.Session.FollowupMessageCreate(c.interaction.Interaction, true, params)
+}
+
+// deferResponse acknowledges a slash command so it may take longer than Discord's 3 seconds
+func (c *Context) deferResponse() error {
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}
+	if c.Command.Ephemeral {
+		response.Data = &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.deferred = true
+	return c.Session.InteractionRespond(c.interaction.Interaction, response)
+}
+
+// interactionUser returns the user behind an interaction in a server or a DM
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/custom"
+	"github.com/latoulicious/HKTM/internal/ratelimit"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+const (
+	// customPreviewLength is how much of a response `!custom list` shows
+	customPreviewLength = 60
+
+	// customResponseBucket is the rate limit bucket a guild's text and embed custom
+	// commands share; guilds change its limit with !ratelimit like any command's
+	customResponseBucket = "custom commands"
+)
+
+// customResponseLimit is how often one user may get custom responses by default
+var customResponseLimit = ratelimit.Limit{Uses: 5, Per: 30 * time.Second}
+
+// Global custom command service consulted when a message matches no built-in command
+var customService *custom.Service
+
+// InitializeCustomCommands sets up the custom command service with database storage
+func InitializeCustomCommands(db *gorm.DB) {
+	customService = custom.NewService(db)
+}
+
+// getCustomService returns the custom command service, falling back to one without storage
+func getCustomService() *custom.Service {
+	if customService == nil {
+		customService = custom.NewService(nil)
+	}
+	return customService
+}
+
+// DispatchCustom runs a guild's custom command, returning false if the guild has none with that name
+func DispatchCustom(s *discordgo.Session, m *discordgo.MessageCreate, name string, args []string) bool {
+	if m.GuildID == "" {
+		return false
+	}
+	command, exists := getCustomService().Get(m.GuildID, name)
+	if !exists {
+		return false
+	}
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("custom")
+	logger.Info("Custom command executed", map[string]interface{}{
+		"user_id":    m.Author.ID,
+		"guild_id":   m.GuildID,
+		"channel_id": m.ChannelID,
+		"name":       command.Name,
+		"kind":       string(command.Kind),
+	})
+
+	switch command.Kind {
+	case custom.KindAlias:
+		// Aliases only point at built-in commands, so this can't recurse
+		fields := strings.Fields(command.Content)
+		return DispatchMessage(s, m, fields[0], append(fields[1:], args...))
+	case custom.KindText, custom.KindEmbed:
+		runCommand(newMessageContext(s, m, customResponseCommand(command, m, logger), args))
+		return true
+	default:
+		return false
+	}
+}
+
+// customResponseCommand wraps a text or embed custom command so it passes the channel,
+// permission and rate limit checks built-in commands do. Responses showing the track or
+// queue count as queue commands and so follow the guild's music channel restriction.
+func customResponseCommand(command custom.Command, m *discordgo.MessageCreate, logger logging.Logger) *Command {
+	category := CategoryUtility
+	if command.ShowsMusic() {
+		category = CategoryQueue
+	}
+
+	return &Command{
+		Name:      customResponseBucket,
+		Category:  category,
+		RateLimit: customResponseLimit,
+		Run: func(ctx *Context) {
+			message := &discordgo.MessageSend{AllowedMentions: customAllowedMentions()}
+			values := customValues(m)
+			if command.Kind == custom.KindEmbed {
+				title, body := command.EmbedParts()
+				message.Embed = embedBuilder.Info(custom.Render(title, values), custom.Render(body, values))
+			} else {
+				message.Content = custom.Render(command.Content, values)
+			}
+
+			if _, err := ctx.Session.ChannelMessageSendComplex(ctx.ChannelID, message); err != nil {
+				logger.Error("Failed to send custom response", err, map[string]interface{}{
+					"guild_id": ctx.GuildID,
+					"name":     command.Name,
+				})
+			}
+		},
+	}
+}
+
+// customValues gathers the placeholder values for a custom response
+func customValues(m *discordgo.MessageCreate) custom.Values {
+	values := custom.Values{
+		User:     m.Author.Mention(),
+		UserName: m.Author.Username,
+		Channel:  fmt.Sprintf("<#%s>", m.ChannelID),
+	}
+	if m.Member != nil && m.Member.Nick != "" {
+		values.UserName = m.Member.Nick
+	}
+	if queue := getQueue(m.GuildID); queue != nil {
+		if current := queue.Current(); current != nil {
+			values.Track = current.Title
+		}
+		values.QueueSize = queue.Size()
+	}
+	return values
+}
+
+// customAllowedMentions lets custom responses ping users but never roles or everyone
+func customAllowedMentions() *discordgo.MessageAllowedMentions {
+	return &discordgo.MessageAllowedMentions{
+		Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+	}
+}
+
+// CustomCommand handles the !custom command to manage the guild's custom commands and aliases
+func CustomCommand(ctx *Context) {
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("custom")
+	logger.Info("Custom command management executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	if ctx.GuildID == "" {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.custom.server_only")))
+		return
+	}
+
+	prefix := GuildSettings(ctx.GuildID).Prefix
+	usage := ctx.T("commands.custom.usage", prefix) + "\n\n" + customPlaceholderList(ctx.Language)
+
+	if len(ctx.Args) == 0 || strings.ToLower(ctx.Args[0]) == "list" {
+		showCustomCommands(ctx, prefix)
+		return
+	}
+
+	switch strings.ToLower(ctx.Args[0]) {
+	case "add":
+		if len(ctx.Args) < 4 {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+			return
+		}
+
+		kind, err := custom.ParseKind(ctx.Args[2])
+		if err != nil {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.custom.invalid_type.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(ctx.Args[1], prefix))
+		if Lookup(name) != nil {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.custom.name_taken.title"),
+				ctx.T("commands.custom.name_taken.description", prefix+name)))
+			return
+		}
+
+		content := strings.TrimSpace(strings.Join(ctx.Args[3:], " "))
+		if kind == custom.KindAlias {
+			content = strings.TrimPrefix(content, prefix)
+			if fields := strings.Fields(content); len(fields) == 0 || Lookup(fields[0]) == nil {
+				ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.custom.invalid_alias.title"),
+					ctx.T("commands.custom.invalid_alias.description", prefix)))
+				return
+			}
+		}
+
+		err = getCustomService().Add(ctx.GuildID, custom.Command{
+			Name:      name,
+			Kind:      kind,
+			Content:   content,
+			CreatedBy: ctx.Author.ID,
+		})
+		if err != nil {
+			logger.Warn("Failed to add custom command", map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"name":     name,
+				"error":    err.Error(),
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.custom.saved.title"),
+			ctx.T("commands.custom.saved.description", prefix+name, describeCustomCommand(ctx.Language, prefix, custom.Command{Kind: kind, Content: content}))))
+	case "remove", "delete":
+		if len(ctx.Args) < 2 {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+			return
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(ctx.Args[1], prefix))
+		removed, err := getCustomService().Remove(ctx.GuildID, name, ctx.Author.ID)
+		if err != nil {
+			logger.Error("Failed to remove custom command", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"name":     name,
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+		if !removed {
+			ctx.ReplyEmbed(embedBuilder.Warning(ctx.T("commands.custom.not_found.title"), ctx.T("commands.custom.not_found.description", name)))
+			return
+		}
+
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.custom.removed.title"), ctx.T("commands.custom.removed.description", prefix+name)))
+	default:
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+	}
+}
+
+// showCustomCommands lists the guild's custom commands
+func showCustomCommands(ctx *Context, prefix string) {
+	list := getCustomService().List(ctx.GuildID)
+	if len(list) == 0 {
+		ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.custom.list.title"),
+			ctx.T("commands.custom.list.empty", prefix)))
+		return
+	}
+
+	lines := make([]string, len(list))
+	for i, command := range list {
+		lines[i] = fmt.Sprintf("• `%s%s` %s", prefix, command.Name, describeCustomCommand(ctx.Language, prefix, command))
+	}
+	ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.custom.list.count", len(list), custom.MaxPerGuild),
+		strings.Join(lines, "\n")))
+}
+
+// customPlaceholderList documents the placeholders custom responses may use
+func customPlaceholderList(lang string) string {
+	lines := []string{i18n.T(lang, "commands.custom.placeholders")}
+	for _, placeholder := range custom.Placeholders {
+		lines = append(lines, fmt.Sprintf("`{%s}` - %s", placeholder, i18n.T(lang, "custom.placeholder."+placeholder)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeCustomCommand summarizes what a custom command does in the language
+func describeCustomCommand(lang, prefix string, command custom.Command) string {
+	if command.Kind == custom.KindAlias {
+		return i18n.T(lang, "commands.custom.describe.alias", prefix+command.Content)
+	}
+
+	preview := strings.Join(strings.Fields(command.Content), " ")
+	if runes := []rune(preview); len(runes) > customPreviewLength {
+		preview = string(runes[:customPreviewLength]) + "…"
+	}
+	return i18n.T(lang, "commands.custom.describe."+string(command.Kind), strings.ReplaceAll(preview, "`", "'"))
+}
@@ -6,53 +6,54 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
 // DeleteCommand handles the !delete command to delete recent messages
-func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+func DeleteCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("delete")
 	logger.Info("Delete command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
-		"args_count": len(args),
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
-	// Check if user has manage messages permission
-	hasPermission := hasManageMessagesPermission(s, m.GuildID, m.Author.ID)
+	// Check if user holds the moderation.delete capability
+	hasPermission := hasCapability(ctx.Session, ctx.GuildID, ctx.Author.ID, permissions.ModerationDelete)
 	if !hasPermission {
 		logger.Warn("Delete command denied - insufficient permissions", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Permission Denied", "You need 'Manage Messages' permission to use this command.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Permission Denied", "You need the `moderation.delete` permission (Manage Messages by default) to use this command.", 0xff0000)
 		return
 	}
 
 	// Check if number of messages is provided
-	if len(args) == 0 {
+	if len(ctx.Args) == 0 {
 		logger.Warn("Delete command called without arguments", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Invalid Usage", "Usage: `!delete <number>` - Delete the specified number of recent messages.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Invalid Usage", "Usage: `!delete <number>` - Delete the specified number of recent messages.", 0xff0000)
 		return
 	}
 
 	// Parse the number of messages to delete
-	numStr := args[0]
+	numStr := ctx.Args[0]
 	num, err := strconv.Atoi(numStr)
 	if err != nil || num <= 0 {
 		logger.Warn("Delete command called with invalid number", map[string]interface{}{
-			"user_id":     m.Author.ID,
-			"guild_id":    m.GuildID,
+			"user_id":     ctx.Author.ID,
+			"guild_id":    ctx.GuildID,
 			"input":       numStr,
 			"parse_error": err,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Invalid Number", "Please provide a valid positive number of messages to delete.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Invalid Number", "Please provide a valid positive number of messages to delete.", 0xff0000)
 		return
 	}
 
@@ -62,21 +63,21 @@ func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 	}
 
 	logger.Info("Starting message deletion", map[string]interface{}{
-		"user_id":         m.Author.ID,
-		"guild_id":        m.GuildID,
-		"channel_id":      m.ChannelID,
+		"user_id":         ctx.Author.ID,
+		"guild_id":        ctx.GuildID,
+		"channel_id":      ctx.ChannelID,
 		"requested_count": num,
 	})
 
 	// Get recent messages from the channel
-	messages, err := s.ChannelMessages(m.ChannelID, num+1, "", "", "") // +1 to include the command message
+	messages, err := ctx.Session.ChannelMessages(ctx.ChannelID, num+1, "", "", "") // +1 to include the command message
 	if err != nil {
 		logger.Error("Failed to fetch messages from channel", err, map[string]interface{}{
-			"user_id":    m.Author.ID,
-			"guild_id":   m.GuildID,
-			"channel_id": m.ChannelID,
+			"user_id":    ctx.Author.ID,
+			"guild_id":   ctx.GuildID,
+			"channel_id": ctx.ChannelID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "Failed to fetch messages from the channel.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Error", "Failed to fetch messages from the channel.", 0xff0000)
 		return
 	}
 
@@ -87,7 +88,7 @@ func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 
 	for _, msg := range messages {
 		// Skip the command message itself
-		if msg.ID == m.ID {
+		if msg.ID == ctx.MessageID {
 			continue
 		}
 
@@ -106,26 +107,26 @@ func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 
 	// Delete messages in bulk if there are any to delete
 	if len(messageIDs) > 0 {
-		err = s.ChannelMessagesBulkDelete(m.ChannelID, messageIDs)
+		err = ctx.Session.ChannelMessagesBulkDelete(ctx.ChannelID, messageIDs)
 		if err != nil {
 			logger.Error("Failed to bulk delete messages", err, map[string]interface{}{
-				"user_id":       m.Author.ID,
-				"guild_id":      m.GuildID,
-				"channel_id":    m.ChannelID,
+				"user_id":       ctx.Author.ID,
+				"guild_id":      ctx.GuildID,
+				"channel_id":    ctx.ChannelID,
 				"message_count": len(messageIDs),
 			})
-			sendEmbedMessage(s, m.ChannelID, "❌ Error", "Failed to delete messages. Make sure I have 'Manage Messages' permission.", 0xff0000)
+			replyEmbedMessage(ctx, "❌ Error", "Failed to delete messages. Make sure I have 'Manage Messages' permission.", 0xff0000)
 			return
 		}
 	}
 
 	// Delete the command message itself
-	s.ChannelMessageDelete(m.ChannelID, m.ID)
+	ctx.Session.ChannelMessageDelete(ctx.ChannelID, ctx.MessageID)
 
 	logger.Info("Message deletion completed", map[string]interface{}{
-		"user_id":       m.Author.ID,
-		"guild_id":      m.GuildID,
-		"channel_id":    m.ChannelID,
+		"user_id":       ctx.Author.ID,
+		"guild_id":      ctx.GuildID,
+		"channel_id":    ctx.ChannelID,
 		"deleted_count": deletedCount,
 		"skipped_count": skippedCount,
 	})
@@ -147,7 +148,7 @@ func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 			},
 			{
 				Name:   "Deleted By",
-				Value:  m.Author.Username,
+				Value:  ctx.Author.Username,
 				Inline: true,
 			},
 		},
@@ -163,41 +164,13 @@ func DeleteCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 	}
 
 	// Send the confirmation message
-	confirmMsg, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	confirmMsg, err := ctx.ReplyEmbedMessage(embed)
 	if err != nil {
 		return
 	}
 
 	// Delete the confirmation message after 5 seconds
 	time.AfterFunc(5*time.Second, func() {
-		s.ChannelMessageDelete(m.ChannelID, confirmMsg.ID)
+		ctx.Session.ChannelMessageDelete(ctx.ChannelID, confirmMsg.ID)
 	})
 }
-
-// hasManageMessagesPermission checks if a user has manage messages permission
-func hasManageMessagesPermission(s *discordgo.Session, guildID, userID string) bool {
-	// Get guild member
-	member, err := s.GuildMember(guildID, userID)
-	if err != nil {
-		return false
-	}
-
-	// Check if user is the server owner
-	guild, err := s.Guild(guildID)
-	if err == nil && guild.OwnerID == userID {
-		return true
-	}
-
-	// Check if user has manage messages permission
-	for _, roleID := range member.Roles {
-		role, err := s.State.Role(guildID, roleID)
-		if err != nil {
-			continue
-		}
-		if role.Permissions&discordgo.PermissionManageMessages != 0 {
-			return true
-		}
-	}
-
-	return false
-}
@@ -27,22 +27,22 @@ var gremlinFooters = []string{
 }
 
 // GremlinCommand sends a random image from the gremlin collection
-func GremlinCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func GremlinCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("gremlin")
 	logger.Info("Gremlin command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
 	})
 	if len(gremlinImages) == 0 {
 		logger.Error("No gremlin images available", nil, map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "No Maachan gremlin images available!")
+		ctx.Reply("No Maachan gremlin images available!")
 		return
 	}
 
@@ -63,16 +63,16 @@ func GremlinCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 		},
 	}
 
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	err := ctx.ReplyEmbed(embed)
 	if err != nil {
 		logger.Error("Failed to send gremlin embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
 	} else {
 		logger.Debug("Gremlin image sent successfully", map[string]interface{}{
-			"user_id":         m.Author.ID,
-			"guild_id":        m.GuildID,
+			"user_id":         ctx.Author.ID,
+			"guild_id":        ctx.GuildID,
 			"selected_image":  randomImage,
 			"selected_footer": randomFooter,
 		})
@@ -1,107 +1,86 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
 // ShowHelpCommand displays all available commands with their descriptions using embeds
-func ShowHelpCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func ShowHelpCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("help")
 	logger.Info("Help command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":     ctx.Author.ID,
+		"username":    ctx.Author.Username,
+		"guild_id":    ctx.GuildID,
+		"channel_id":  ctx.ChannelID,
+		"interaction": ctx.IsInteraction(),
 	})
+
+	if err := ctx.ReplyEmbed(HelpEmbed(GuildSettings(ctx.GuildID).Prefix, ctx.Language)); err != nil {
+		logger.Error("Failed to send help embed", err, map[string]interface{}{
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
+		})
+	}
+}
+
+// HelpEmbed builds the help message from the command registry, one field per category,
+// showing prefix commands with the given prefix and the headings in the given language
+func HelpEmbed(prefix, lang string) *discordgo.MessageEmbed {
+	lines := make([][]string, len(categoryTitles))
+	for _, cmd := range Commands() {
+		for i, usage := range cmd.usageLines() {
+			syntax := fmt.Sprintf("`%s%s`", prefix, usage.Syntax)
+			if i == 0 {
+				// Aliases go on the command's first line
+				for _, alias := range cmd.Aliases {
+					syntax += fmt.Sprintf(" / `%s%s`", prefix, alias)
+				}
+			}
+			lines[cmd.Category] = append(lines[cmd.Category], fmt.Sprintf("• %s - %s", syntax, usage.Description))
+		}
+	}
+
 	// Create embed
 	embed := &discordgo.MessageEmbed{
-		Title: "Here are all the available commands for the bot:",
-		// Description: "Here are all the available commands for the bot:",
+		Title:     i18n.T(lang, "help.title"),
 		Color:     0x00ff00, // Green color
 		Timestamp: time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
 			Text:    "Hokko Tarumae",
 			IconURL: "https://cdn.discordapp.com/attachments/1378031194356060280/1402891387061403718/footer.gif?ex=68958feb&is=68943e6b&hm=21cdbed6dde8e956c55af9345d23755a617cf20f9f098fde6369a73164b67ca0&", // Replace with custom image URL
 		},
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name: "Music Commands",
-				Value: strings.Join([]string{
-					"• `!play <url>` / `!p <url>` - Play a YouTube video by URL",
-					"• `!p <keywords>` - Search and play a YouTube video",
-					"• `!nowplaying` / `!np` - Show the currently playing track",
-					"• `!queue add <url>` - Add a YouTube video to the queue",
-					"• `!queue list` - List the current queue",
-					"• `!queue remove <position>` - Remove a track from the queue",
-					"• `!clear` - Clear the entire queue",
-					"• `!shuffle` - Shuffle the queue",
-					"• `!pause` - Pause the current playback",
-					"• `!resume` - Resume paused playback",
-					"• `!skip` - Skip the currently playing track",
-					"• `!stop` - Stop playback and disconnect from voice channel",
-				}, "\n"),
-				Inline: false,
-			},
-			{
-				Name: "Information Commands",
-				Value: strings.Join([]string{
-					"• `!about` - Show bot info, uptime, and stats",
-					"• `!servers` - List servers the bot is connected to (bot owner only)",
-					"• `!help` / `!h` - Show this help message",
-				}, "\n"),
-				Inline: false,
-			},
-			{
-				Name: "Moderation Commands",
-				Value: strings.Join([]string{
-					"• `!delete <number>` - Delete the specified number of recent messages",
-				}, "\n"),
-				Inline: false,
-			},
-			{
-				Name:   "Fun Commands",
-				Value:  "• `!gremlin` - Post a random gremlin image\n• `!uma char <name>` - Search for Uma Musume characters\n• `!uma support <name>` - Search for Uma Musume support cards\n• `!uma skills <name>` - Get skills for a support card",
-				Inline: false,
-			},
-			{
-				Name: "Utility Commands (Bot Owner Only)",
-				Value: strings.Join([]string{
-					"• `!utility cron` - Check cron job status",
-					"• `!utility cron-refresh` - Manually trigger build ID refresh",
-				}, "\n"),
-				Inline: false,
-			},
-			{
-				Name: "Admin Commands (Bot Owner Only)",
-				Value: strings.Join([]string{
-					"• `!leave <server_id>` - Force bot to leave a server by ID",
-				}, "\n"),
-				Inline: false,
-			},
-			{
-				Name: "💡 Tips",
-				Value: strings.Join([]string{
-					"• Join a voice channel **before** using music commands",
-					"• Only **YouTube links and searches** are currently supported",
-				}, "\n"),
-				Inline: false,
-			},
-		},
 	}
 
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
-	if err != nil {
-		logger.Error("Failed to send help embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+	for category, title := range categoryTitles {
+		if len(lines[category]) == 0 {
+			continue
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, title),
+			Value:  strings.Join(lines[category], "\n"),
+			Inline: false,
 		})
 	}
+
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: i18n.T(lang, "help.tips.title"),
+		Value: strings.Join([]string{
+			i18n.T(lang, "help.tips.voice"),
+			i18n.T(lang, "help.tips.youtube"),
+			i18n.T(lang, "help.tips.slash"),
+		}, "\n"),
+		Inline: false,
+	})
+
+	return embed
 }
 
 // Unused commands
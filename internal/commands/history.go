@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// recordPlayHistory stores a started YouTube track so it can be suggested again later
+func recordPlayHistory(guildID string, item *common.QueueItem) {
+	if queueDB == nil || item.VideoID == "" {
+		return
+	}
+
+	entry := &models.PlayHistory{
+		GuildID:     guildID,
+		VideoID:     item.VideoID,
+		URL:         common.YouTubeWatchURL(item.VideoID),
+		Title:       item.Title,
+		Duration:    int64(item.Duration / time.Second),
+		RequestedBy: item.RequestedBy,
+		RequesterID: item.RequesterID,
+		PlayedAt:    time.Now(),
+	}
+
+	if err := repository.NewPlayHistoryRepository(queueDB).RecordPlay(entry); err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("play").Warn("Failed to record play history", map[string]interface{}{
+			"guild_id": guildID,
+			"video_id": item.VideoID,
+			"error":    err.Error(),
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// interactionLanguage resolves the language of an interaction: the user's Discord
+// client language when there is a catalog for it, otherwise the guild's setting
+func interactionLanguage(i *discordgo.InteractionCreate) string {
+	if lang, ok := i18n.FromDiscordLocale(i.Locale); ok {
+		return lang
+	}
+	return GuildSettings(i.GuildID).Language
+}
+
+// embedsFor returns the embed builder in the language of a command's replies
+func embedsFor(ctx *Context) embed.AudioEmbedBuilder {
+	return embedBuilder.WithLanguage(ctx.Language)
+}
+
+// Errors commands reply with, rendered from the catalog by localizeError
+var (
+	errBotNotInVoice     = errors.New("I'm not connected to a voice channel")
+	errVoterNotListening = errors.New("you must be in my voice channel to vote to skip")
+)
+
+// errorKeys holds the catalog key of each error above
+var errorKeys = map[error]string{
+	errBotNotInVoice:     "commands.voteskip.bot_not_in_voice",
+	errVoterNotListening: "commands.voteskip.not_listening",
+}
+
+// localizeError renders queue limit errors, catalog errors and the errors above in
+// the language; other errors keep their English message
+func localizeError(lang string, err error) string {
+	var limitErr *common.LimitError
+	if errors.As(err, &limitErr) {
+		return i18n.T(lang, limitErr.Key, limitErr.Args...)
+	}
+	var catalogErr *i18n.Error
+	if errors.As(err, &catalogErr) {
+		return i18n.T(lang, catalogErr.Key, catalogErr.Args...)
+	}
+	if key, ok := errorKeys[err]; ok {
+		return i18n.T(lang, key)
+	}
+	return capitalize(err.Error())
+}
+
+// formatWait renders a wait in whole seconds, or in whole minutes from two minutes on
+func formatWait(lang string, wait time.Duration) string {
+	seconds := int((wait + time.Second - 1) / time.Second)
+	switch {
+	case seconds == 1:
+		return i18n.T(lang, "duration.second")
+	case seconds >= 120:
+		return i18n.T(lang, "duration.minutes", (seconds+59)/60)
+	}
+	return i18n.T(lang, "duration.seconds", seconds)
+}
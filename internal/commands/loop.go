@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// LoopCommand handles the !loop command to replay the current track or the whole queue
+func LoopCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	// Initialize centralized logging for this command
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("loop")
+	logger.Info("Loop command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"guild_id":   guildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	// Initialize centralized embed builder
+	embedBuilder := embedsFor(ctx)
+
+	// Update activity for idle monitoring
+	updateActivity(guildID)
+
+	queue := getOrCreateQueue(guildID)
+
+	// Without an argument, cycle off -> track -> queue
+	mode := queue.GetLoopMode().Next()
+	if len(ctx.Args) > 0 {
+		parsed, err := common.ParseLoopMode(ctx.Args[0])
+		if err != nil {
+			errorEmbed := embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.loop.usage", commandDisplayName(ctx, "loop")))
+			ctx.ReplyEmbed(errorEmbed)
+			return
+		}
+		mode = parsed
+	}
+
+	queue.SetLoopMode(mode)
+
+	logger.Info("Loop mode changed", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  ctx.Author.ID,
+		"loop":     string(mode),
+	})
+
+	successEmbed := embedBuilder.Success(loopModeLabel(ctx.Language, mode), loopModeDescription(ctx, mode))
+	ctx.ReplyEmbed(successEmbed)
+
+	refreshNowPlayingPanel(ctx.Session, guildID)
+}
+
+// loopModeLabel returns a short title for a loop mode in the language
+func loopModeLabel(lang string, mode common.LoopMode) string {
+	switch mode {
+	case common.LoopTrack:
+		return i18n.T(lang, "commands.loop.track.title")
+	case common.LoopQueue:
+		return i18n.T(lang, "commands.loop.queue.title")
+	default:
+		return i18n.T(lang, "commands.loop.off.title")
+	}
+}
+
+// loopModeDescription explains what a loop mode does
+func loopModeDescription(ctx *Context, mode common.LoopMode) string {
+	switch mode {
+	case common.LoopTrack:
+		return ctx.T("commands.loop.track.description")
+	case common.LoopQueue:
+		return ctx.T("commands.loop.queue.description")
+	default:
+		return ctx.T("commands.loop.off.description", commandDisplayName(ctx, "loop"))
+	}
+}
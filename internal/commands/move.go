@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// voiceRecoveryDelay gives discordgo's own reconnect a chance before the bot rejoins
+const voiceRecoveryDelay = 5 * time.Second
+
+// MoveCommand brings the bot to the requester's voice channel without interrupting the track
+func MoveCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("move")
+	logger.Info("Move command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"guild_id":   guildID,
+		"channel_id": ctx.ChannelID,
+	})
+
+	// Update activity for idle monitoring
+	updateActivity(guildID)
+
+	queue := getQueue(guildID)
+	if queue == nil || !queue.IsPlaying() {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.move.nothing", commandDisplayName(ctx, "play")), 0xff0000)
+		return
+	}
+
+	channelID, err := common.FindUserVoiceChannel(ctx.Session, ctx.Author.ID, guildID)
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.move.join_first"), 0xff0000)
+		return
+	}
+	if channelID == common.GetBotVoiceChannelID(ctx.Session, guildID) {
+		replyEmbedMessage(ctx, ctx.T("commands.move.already.title"), ctx.T("commands.move.already.description"), 0x808080)
+		return
+	}
+
+	vc, err := common.JoinVoiceChannel(ctx.Session, guildID, channelID)
+	if err != nil {
+		logger.Error("Failed to move to voice channel", err, map[string]interface{}{
+			"guild_id":         guildID,
+			"user_id":          ctx.Author.ID,
+			"voice_channel_id": channelID,
+		})
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.move.failed", localizeError(ctx.Language, err)), 0xff0000)
+		return
+	}
+	queue.RebindVoiceConnection(vc)
+
+	logger.Info("Moved to voice channel", map[string]interface{}{
+		"guild_id":         guildID,
+		"user_id":          ctx.Author.ID,
+		"voice_channel_id": channelID,
+	})
+
+	replyEmbedMessage(ctx, ctx.T("commands.move.moved.title"), ctx.T("commands.move.moved.description", channelID), 0x00ff00)
+
+	updateStageForTrack(ctx.Session, ctx.ChannelID, guildID, queue.Current())
+}
+
+// recoverVoiceConnection rejoins the bot's channel when its voice connection didn't come
+// back by itself after a move, and rebinds the queue so the track resumes in place
+func recoverVoiceConnection(s *discordgo.Session, guildID, channelID string) {
+	time.Sleep(voiceRecoveryDelay)
+
+	queue := getQueue(guildID)
+	if queue == nil || !queue.IsPlaying() || common.GetBotVoiceChannelID(s, guildID) != channelID {
+		return
+	}
+
+	s.RLock()
+	vc, connected := s.VoiceConnections[guildID]
+	s.RUnlock()
+	if connected {
+		vc.RLock()
+		ready := vc.Ready
+		vc.RUnlock()
+		if ready {
+			// discordgo kept the same connection; make sure the queue uses it
+			queue.RebindVoiceConnection(vc)
+			return
+		}
+	}
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("voice")
+	logger.Warn("Voice connection not ready after move, rejoining", map[string]interface{}{
+		"guild_id":         guildID,
+		"voice_channel_id": channelID,
+	})
+
+	vc, err := common.JoinVoiceChannel(s, guildID, channelID)
+	if err != nil {
+		logger.Error("Failed to rejoin voice channel", err, map[string]interface{}{
+			"guild_id":         guildID,
+			"voice_channel_id": channelID,
+		})
+		return
+	}
+	queue.RebindVoiceConnection(vc)
+}
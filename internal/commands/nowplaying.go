@@ -2,29 +2,40 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
+// progressBarWidth is the number of segments in the now playing progress bar
+const progressBarWidth = 14
+
 // NowPlayingCommand handles the nowplaying command
-func NowPlayingCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func NowPlayingCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("nowplaying")
 	logger.Info("Now playing command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
+		"channel_id": ctx.ChannelID,
 	})
 
 	// Initialize centralized embed builder
-	embedBuilder := embed.GetGlobalAudioEmbedBuilder()
+	embedBuilder := embedsFor(ctx)
+
+	// Handle the live progress opt-in
+	if len(ctx.Args) > 0 && strings.ToLower(ctx.Args[0]) == "live" {
+		setLiveProgressCommand(ctx, ctx.Args[1:], embedBuilder, logger)
+		return
+	}
 
 	// Update activity for idle monitoring
 	updateActivity(guildID)
@@ -34,11 +45,11 @@ func NowPlayingCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		infoEmbed := embedBuilder.Info("🎵 Now Playing", "Nothing is currently playing. Use `/play` to start playing music.")
-		s.ChannelMessageSendEmbed(m.ChannelID, infoEmbed)
+		infoEmbed := embedBuilder.Info(ctx.T("audio.now_playing.title"), ctx.T("commands.nowplaying.nothing", commandDisplayName(ctx, "play")))
+		ctx.ReplyEmbed(infoEmbed)
 		return
 	}
 
@@ -47,19 +58,19 @@ func NowPlayingCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if currentItem == nil || !queue.IsPlaying() {
 		logger.Info("No current item playing", map[string]interface{}{
 			"guild_id":     guildID,
-			"user_id":      m.Author.ID,
+			"user_id":      ctx.Author.ID,
 			"has_current":  currentItem != nil,
 			"is_playing":   queue.IsPlaying(),
 		})
 		
-		infoEmbed := embedBuilder.Info("🎵 Now Playing", "Nothing is currently playing. Use `/play` to start playing music.")
-		s.ChannelMessageSendEmbed(m.ChannelID, infoEmbed)
+		infoEmbed := embedBuilder.Info(ctx.T("audio.now_playing.title"), ctx.T("commands.nowplaying.nothing", commandDisplayName(ctx, "play")))
+		ctx.ReplyEmbed(infoEmbed)
 		return
 	}
 
 	logger.Info("Displaying now playing info", map[string]interface{}{
 		"guild_id":     guildID,
-		"user_id":      m.Author.ID,
+		"user_id":      ctx.Author.ID,
 		"song_title":   currentItem.Title,
 		"requested_by": currentItem.RequestedBy,
 		"duration":     currentItem.Duration.String(),
@@ -70,19 +81,79 @@ func NowPlayingCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	voiceConn := queue.GetVoiceConnection()
 
 	// Send now playing embed using centralized system
-	sendNowPlayingEmbed(s, m.ChannelID, currentItem, pipeline, voiceConn, embedBuilder, logger)
+	nowPlayingEmbed := buildNowPlayingEmbed(ctx.Language, currentItem, pipeline, voiceConn, embedBuilder, logger)
+	if err := ctx.ReplyEmbed(nowPlayingEmbed); err != nil {
+		logger.Error("Failed to send now playing embed", err, map[string]interface{}{
+			"channel_id": ctx.ChannelID,
+			"song_title": currentItem.Title,
+		})
+	}
 }
 
-// sendNowPlayingEmbed sends a detailed now playing embed using centralized systems
-func sendNowPlayingEmbed(s *discordgo.Session, channelID string, item *common.QueueItem, pipeline interface{}, voiceConn *discordgo.VoiceConnection, embedBuilder embed.AudioEmbedBuilder, logger logging.Logger) {
+// setLiveProgressCommand shows or toggles live progress updates on the now-playing panel
+func setLiveProgressCommand(ctx *Context, args []string, embedBuilder embed.AudioEmbedBuilder, logger logging.Logger) {
+	guildID := ctx.GuildID
+
+	if len(args) == 0 {
+		state := ctx.T("commands.nowplaying.live.off")
+		if isLiveProgressEnabled(guildID) {
+			state = ctx.T("commands.nowplaying.live.on")
+		}
+		description := ctx.T("commands.nowplaying.live.description", state, commandDisplayName(ctx, "nowplaying live"))
+		ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.nowplaying.live.title"), description))
+		return
+	}
+
+	var enabled bool
+	switch strings.ToLower(args[0]) {
+	case "on", "enable", "true":
+		enabled = true
+	case "off", "disable", "false":
+		enabled = false
+	default:
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.nowplaying.live.usage", commandDisplayName(ctx, "nowplaying live"))))
+		return
+	}
+
+	setLiveProgress(guildID, enabled)
+
+	logger.Info("Live progress setting changed", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  ctx.Author.ID,
+		"enabled":  enabled,
+	})
+
+	// Apply the change to the track that is already playing
+	if queue := getQueue(guildID); queue != nil && enabled {
+		startLiveProgress(ctx.Session, guildID, queue)
+	} else {
+		stopLiveProgress(guildID)
+	}
+
+	if enabled {
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.nowplaying.live.enabled.title"), ctx.T("commands.nowplaying.live.enabled.description")))
+	} else {
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.nowplaying.live.disabled.title"), ctx.T("commands.nowplaying.live.disabled.description")))
+	}
+}
+
+// buildNowPlayingEmbed renders the now playing embed for an item in the language
+func buildNowPlayingEmbed(lang string, item *common.QueueItem, pipeline interface{}, voiceConn *discordgo.VoiceConnection, embedBuilder embed.AudioEmbedBuilder, logger logging.Logger) *discordgo.MessageEmbed {
 	// Determine connection status
-	var isPlaying bool
+	var isPlaying, isPaused bool
+	var position time.Duration
 
 	// Handle both old and new pipeline types
 	if pipeline != nil {
 		// Try new AudioPipeline interface first
 		if newPipeline, ok := pipeline.(interface{ IsPlaying() bool }); ok {
 			isPlaying = newPipeline.IsPlaying()
+		}
+		if pausable, ok := pipeline.(interface{ IsPaused() bool }); ok {
+			isPaused = pausable.IsPaused()
+		}
+		if positioned, ok := pipeline.(interface{ GetPosition() time.Duration }); ok {
+			position = positioned.GetPosition()
 		} else if oldPipeline, ok := pipeline.(*common.AudioPipeline); ok {
 			// Fallback to old AudioPipeline type
 			isPlaying = oldPipeline.IsPlaying()
@@ -112,37 +183,63 @@ func sendNowPlayingEmbed(s *discordgo.Session, channelID string, item *common.Qu
 
 	// Add additional fields for enhanced information
 	statusEmoji := "🔴"
-	statusText := "Stopped"
+	statusText := i18n.T(lang, "commands.nowplaying.status.stopped")
 	
-	if isPlaying {
+	if isPaused {
+		statusEmoji = "⏸️"
+		statusText = i18n.T(lang, "commands.nowplaying.status.paused")
+	} else if isPlaying {
 		if voiceConn != nil && voiceConn.Ready {
 			statusEmoji = "🟢"
-			statusText = "Playing"
+			statusText = i18n.T(lang, "commands.nowplaying.status.playing")
 		} else {
 			statusEmoji = "🟡"
-			statusText = "Connecting..."
+			statusText = i18n.T(lang, "commands.nowplaying.status.connecting")
 		}
 	}
 
 	// Add custom fields to the centralized embed
 	nowPlayingEmbed.Fields = append(nowPlayingEmbed.Fields, 
 		&discordgo.MessageEmbedField{
-			Name:   "Requested by",
+			Name:   i18n.T(lang, "commands.nowplaying.progress"),
+			Value:  embed.ProgressBar(position, item.Duration, progressBarWidth),
+			Inline: false,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.nowplaying.requested_by"),
 			Value:  item.RequestedBy,
 			Inline: true,
 		},
 		&discordgo.MessageEmbedField{
-			Name:   "Status",
+			Name:   i18n.T(lang, "commands.nowplaying.status"),
 			Value:  fmt.Sprintf("%s %s", statusEmoji, statusText),
 			Inline: true,
 		},
 		&discordgo.MessageEmbedField{
-			Name:   "Added to queue",
+			Name:   i18n.T(lang, "commands.nowplaying.added"),
 			Value:  item.AddedAt.Format("Jan 2, 2006 3:04 PM"),
 			Inline: false,
 		},
 	)
 
+	// Clipped items show which part of the video is playing
+	if clip := item.Clip(); !clip.IsZero() {
+		nowPlayingEmbed.Fields = append(nowPlayingEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.nowplaying.clip"),
+			Value:  fmt.Sprintf("✂️ %s", common.FormatClip(clip)),
+			Inline: true,
+		})
+	}
+
+	// Long mixes show which chapter is playing
+	if index := item.ChapterAt(position); index >= 0 {
+		nowPlayingEmbed.Fields = append(nowPlayingEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.nowplaying.chapter"),
+			Value:  fmt.Sprintf("📖 %d/%d · %s", index+1, len(item.Chapters), item.Chapters[index].Title),
+			Inline: false,
+		})
+	}
+
 	// Add YouTube thumbnail if video ID is available
 	if item.VideoID != "" {
 		thumbnailURL := common.GetYouTubeThumbnailURL(item.VideoID)
@@ -151,14 +248,7 @@ func sendNowPlayingEmbed(s *discordgo.Session, channelID string, item *common.Qu
 		}
 	}
 
-	// Send the embed
-	_, err := s.ChannelMessageSendEmbed(channelID, nowPlayingEmbed)
-	if err != nil {
-		logger.Error("Failed to send now playing embed", err, map[string]interface{}{
-			"channel_id": channelID,
-			"song_title": item.Title,
-		})
-	}
+	return nowPlayingEmbed
 }
 
 // formatDuration formats a duration into a human-readable string
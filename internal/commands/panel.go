@@ -0,0 +1,362 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// playerButtonPrefix identifies now-playing control panel button interactions
+const playerButtonPrefix = "player:"
+
+// Control panel button actions
+const (
+	playerActionPause   = "pause"
+	playerActionSkip    = "skip"
+	playerActionStop    = "stop"
+	playerActionLoop    = "loop"
+	playerActionShuffle = "shuffle"
+	playerActionVolDown = "voldown"
+	playerActionVolUp   = "volup"
+)
+
+// playerActionCommands maps each button to the command whose permission it requires
+var playerActionCommands = map[string]string{
+	playerActionPause:   "pause",
+	playerActionSkip:    "skip",
+	playerActionStop:    "stop",
+	playerActionLoop:    "loop",
+	playerActionShuffle: "shuffle",
+	playerActionVolDown: "volume",
+	playerActionVolUp:   "volume",
+}
+
+// nowPlayingPanel is the persistent now-playing message for a guild
+type nowPlayingPanel struct {
+	channelID string
+	messageID string
+}
+
+var (
+	nowPlayingPanels = make(map[string]*nowPlayingPanel)
+	panelMutex       sync.Mutex
+)
+
+// playerButtonID builds the custom ID for a control panel button
+func playerButtonID(action, guildID string) string {
+	return fmt.Sprintf("%s%s:%s", playerButtonPrefix, action, guildID)
+}
+
+// playerComponents builds the control panel buttons reflecting the queue's state
+func playerComponents(guildID string, queue *common.MusicQueue, disabled bool) []discordgo.MessageComponent {
+	lang := GuildSettings(guildID).Language
+	pauseLabel, pauseEmoji := i18n.T(lang, "commands.panel.pause"), "⏸️"
+	loopMode := common.LoopOff
+	if queue != nil {
+		if queue.IsPaused() {
+			pauseLabel, pauseEmoji = i18n.T(lang, "commands.panel.resume"), "▶️"
+		}
+		loopMode = queue.GetLoopMode()
+	}
+
+	loopStyle := discordgo.SecondaryButton
+	loopEmoji := "🔁"
+	if loopMode != common.LoopOff {
+		loopStyle = discordgo.SuccessButton
+		if loopMode == common.LoopTrack {
+			loopEmoji = "🔂"
+		}
+	}
+
+	button := func(action, label, emoji string, style discordgo.ButtonStyle) discordgo.Button {
+		return discordgo.Button{
+			Label:    label,
+			Style:    style,
+			CustomID: playerButtonID(action, guildID),
+			Disabled: disabled,
+			Emoji:    &discordgo.ComponentEmoji{Name: emoji},
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				button(playerActionPause, pauseLabel, pauseEmoji, discordgo.PrimaryButton),
+				button(playerActionSkip, i18n.T(lang, "commands.panel.skip"), "⏭️", discordgo.SecondaryButton),
+				button(playerActionStop, i18n.T(lang, "commands.panel.stop"), "⏹️", discordgo.DangerButton),
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				button(playerActionLoop, i18n.T(lang, "commands.panel.loop."+string(loopMode)), loopEmoji, loopStyle),
+				button(playerActionShuffle, i18n.T(lang, "commands.panel.shuffle"), "🔀", discordgo.SecondaryButton),
+				button(playerActionVolDown, i18n.T(lang, "commands.panel.volume"), "🔉", discordgo.SecondaryButton),
+				button(playerActionVolUp, i18n.T(lang, "commands.panel.volume"), "🔊", discordgo.SecondaryButton),
+			},
+		},
+	}
+}
+
+// buildPlayerEmbed renders the now playing embed with the panel's playback settings
+// in the guild's language
+func buildPlayerEmbed(guildID string, queue *common.MusicQueue, item *common.QueueItem, logger logging.Logger) *discordgo.MessageEmbed {
+	lang := GuildSettings(guildID).Language
+	playerEmbed := buildNowPlayingEmbed(lang, item, queue.GetPipeline(), queue.GetVoiceConnection(), embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang), logger)
+
+	upNext := i18n.T(lang, "commands.panel.up_next.empty")
+	if upcoming := queue.List(); len(upcoming) > 0 {
+		upNext = i18n.T(lang, "commands.panel.up_next.more", upcoming[0].Title, len(upcoming)-1)
+		if len(upcoming) == 1 {
+			upNext = fmt.Sprintf("**%s**", upcoming[0].Title)
+		}
+	}
+
+	playerEmbed.Fields = append(playerEmbed.Fields,
+		&discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.panel.volume_field"),
+			Value:  formatVolume(queue.GetVolume()),
+			Inline: true,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.panel.loop_field"),
+			Value:  loopModeLabel(lang, queue.GetLoopMode()),
+			Inline: true,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "commands.panel.up_next.field"),
+			Value:  upNext,
+			Inline: false,
+		},
+	)
+	return playerEmbed
+}
+
+// showNowPlayingPanel edits the guild's now-playing panel in place for the current track,
+// posting a new one only when none exists in the channel or the old message is gone
+func showNowPlayingPanel(s *discordgo.Session, channelID, guildID string, queue *common.MusicQueue) {
+	item := queue.Current()
+	if item == nil {
+		return
+	}
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("nowplaying")
+
+	panelMutex.Lock()
+	panel, exists := nowPlayingPanels[guildID]
+	panelMutex.Unlock()
+
+	if exists && panel.channelID == channelID {
+		err := editNowPlayingPanel(s, guildID, queue)
+		if err == nil {
+			return
+		}
+		logger.Debug("Now playing panel could not be edited, posting a new one", map[string]interface{}{
+			"guild_id":   guildID,
+			"message_id": panel.messageID,
+		})
+	} else if exists {
+		// Playback moved to another channel; retire the old panel
+		disablePanelMessage(s, panel, guildID)
+	}
+
+	playerEmbed := buildPlayerEmbed(guildID, queue, item, logger)
+	components := playerComponents(guildID, queue, false)
+	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{playerEmbed},
+		Components: components,
+	})
+	if err != nil {
+		logger.Error("Failed to send now playing panel", err, map[string]interface{}{
+			"guild_id":   guildID,
+			"channel_id": channelID,
+		})
+		return
+	}
+
+	panelMutex.Lock()
+	nowPlayingPanels[guildID] = &nowPlayingPanel{channelID: channelID, messageID: msg.ID}
+	panelMutex.Unlock()
+}
+
+// refreshNowPlayingPanel re-renders the existing panel after a playback setting changes
+func refreshNowPlayingPanel(s *discordgo.Session, guildID string) {
+	panelMutex.Lock()
+	panel, exists := nowPlayingPanels[guildID]
+	panelMutex.Unlock()
+
+	queue := getQueue(guildID)
+	if !exists || queue == nil || queue.Current() == nil {
+		return
+	}
+	showNowPlayingPanel(s, panel.channelID, guildID, queue)
+}
+
+// closeNowPlayingPanel disables the panel's buttons once playback has ended
+func closeNowPlayingPanel(s *discordgo.Session, guildID string) {
+	stopLiveProgress(guildID)
+	stopChapterWatcher(guildID)
+
+	panelMutex.Lock()
+	panel, exists := nowPlayingPanels[guildID]
+	delete(nowPlayingPanels, guildID)
+	panelMutex.Unlock()
+
+	if exists {
+		disablePanelMessage(s, panel, guildID)
+	}
+}
+
+// disablePanelMessage greys out a panel's buttons, leaving the last track visible
+func disablePanelMessage(s *discordgo.Session, panel *nowPlayingPanel, guildID string) {
+	components := playerComponents(guildID, nil, true)
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         panel.messageID,
+		Channel:    panel.channelID,
+		Components: &components,
+	})
+}
+
+// IsPlayerControlInteraction reports whether a component custom ID belongs to the control panel
+func IsPlayerControlInteraction(customID string) bool {
+	return strings.HasPrefix(customID, playerButtonPrefix)
+}
+
+// HandlePlayerControlButton handles presses of the now-playing control panel buttons
+func HandlePlayerControlButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("player")
+	lang := interactionLanguage(i)
+	embedBuilder := embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang)
+
+	if i.Member == nil {
+		respondEphemeral(s, i, i18n.T(lang, "commands.panel.server_only"))
+		return
+	}
+
+	user := i.Member.User
+	guildID := i.GuildID
+
+	action, panelGuildID, _ := strings.Cut(strings.TrimPrefix(i.MessageComponentData().CustomID, playerButtonPrefix), ":")
+	command, known := playerActionCommands[action]
+	if !known || panelGuildID != guildID {
+		respondEphemeral(s, i, i18n.T(lang, "commands.panel.expired"))
+		return
+	}
+
+	queue := getQueue(guildID)
+	if queue == nil || queue.Current() == nil || !queue.IsPlaying() {
+		respondEphemeral(s, i, i18n.T(lang, "commands.panel.nothing_playing"))
+		return
+	}
+
+	// Buttons are held to the same policy as the commands they stand in for
+	if allowed, denial := AuthorizeInteraction(s, i, command, []string{action}); !allowed {
+		respondEphemeral(s, i, denial)
+		return
+	}
+
+	updateActivity(guildID)
+
+	logger.Info("Player control pressed", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  user.ID,
+		"action":   action,
+	})
+
+	switch action {
+	case playerActionPause:
+		pipeline := queue.GetPipeline()
+		if pipeline == nil {
+			respondEphemeral(s, i, i18n.T(lang, "commands.panel.nothing_playing"))
+			return
+		}
+
+		var err error
+		if pipeline.IsPaused() {
+			err = pipeline.Resume()
+		} else {
+			err = pipeline.Pause()
+		}
+		if err != nil {
+			logger.Error("Failed to toggle pause from player", err, map[string]interface{}{
+				"guild_id": guildID,
+				"user_id":  user.ID,
+			})
+			respondEphemeral(s, i, "❌ "+localizeError(lang, err)+".")
+			return
+		}
+		respondWithPlayerUpdate(s, i, queue, logger)
+	case playerActionLoop:
+		queue.SetLoopMode(queue.GetLoopMode().Next())
+		respondWithPlayerUpdate(s, i, queue, logger)
+	case playerActionVolDown, playerActionVolUp:
+		step := volumeStep
+		if action == playerActionVolDown {
+			step = -volumeStep
+		}
+		queue.SetVolume(queue.GetVolume() + step)
+		respondWithPlayerUpdate(s, i, queue, logger)
+	case playerActionSkip:
+		track := queue.Current()
+		passed := track.IsRequestedBy(user.ID) ||
+			hasCapabilityGrant(s, guildID, user.ID, permissions.PlaybackControl)
+		if !passed {
+			result, err := castSkipVote(s, guildID, user.ID, track)
+			if err != nil {
+				respondEphemeral(s, i, "❌ "+localizeError(lang, err)+".")
+				return
+			}
+			if !result.passed {
+				respondEphemeral(s, i, i18n.T(lang, "commands.voteskip.recorded", result.votes, result.required))
+				sendVoteSkipProgress(newComponentContext(s, i, Lookup(command)), track, result, logger)
+				return
+			}
+		}
+		acknowledgeComponent(s, i)
+		performSkip(newComponentContext(s, i, Lookup(command)), queue, user.Username, embedBuilder, logger)
+	case playerActionStop:
+		acknowledgeComponent(s, i)
+		StopCommand(newComponentContext(s, i, Lookup(command)))
+	case playerActionShuffle:
+		acknowledgeComponent(s, i)
+		ShuffleCommand(newComponentContext(s, i, Lookup(command)))
+		refreshNowPlayingPanel(s, guildID)
+	}
+}
+
+// respondWithPlayerUpdate re-renders the panel as the interaction response
+func respondWithPlayerUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, queue *common.MusicQueue, logger logging.Logger) {
+	item := queue.Current()
+	if item == nil {
+		acknowledgeComponent(s, i)
+		return
+	}
+
+	components := playerComponents(i.GuildID, queue, false)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{buildPlayerEmbed(i.GuildID, queue, item, logger)},
+			Components: components,
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to update now playing panel", err, map[string]interface{}{
+			"guild_id": i.GuildID,
+		})
+	}
+}
+
+// acknowledgeComponent acknowledges a button press without changing the message
+func acknowledgeComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+}
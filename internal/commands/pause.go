@@ -1,25 +1,23 @@
 package commands
 
 import (
-	"github.com/bwmarrin/discordgo"
-	"github.com/latoulicious/HKTM/pkg/embed"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
-func PauseCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func PauseCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("pause")
 	logger.Info("Pause command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
+		"channel_id": ctx.ChannelID,
 	})
 
 	// Initialize centralized embed builder
-	embedBuilder := embed.GetGlobalAudioEmbedBuilder()
+	embedBuilder := embedsFor(ctx)
 
 	// Update activity for idle monitoring
 	updateActivity(guildID)
@@ -29,11 +27,11 @@ func PauseCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No queue found for this guild.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.no_queue"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
@@ -42,23 +40,41 @@ func PauseCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if pipeline == nil || !pipeline.IsPlaying() {
 		logger.Warn("No active audio pipeline found", map[string]interface{}{
 			"guild_id":   guildID,
-			"user_id":    m.Author.ID,
+			"user_id":    ctx.Author.ID,
 			"has_pipeline": pipeline != nil,
 			"is_playing": pipeline != nil && pipeline.IsPlaying(),
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No audio is currently playing.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.no_audio"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
-	// For now, pause is not implemented in the current audio pipeline
-	// This would require implementing pause/resume functionality in the AudioPipeline
-	logger.Warn("Pause functionality not yet implemented", map[string]interface{}{
-		"guild_id": guildID,
-		"user_id":  m.Author.ID,
+	if pipeline.IsPaused() {
+		infoEmbed := embedBuilder.Info(ctx.T("commands.pause.already.title"), ctx.T("commands.pause.already.description", commandDisplayName(ctx, "resume")))
+		ctx.ReplyEmbed(infoEmbed)
+		return
+	}
+
+	if err := pipeline.Pause(); err != nil {
+		logger.Error("Failed to pause playback", err, map[string]interface{}{
+			"guild_id": guildID,
+			"user_id":  ctx.Author.ID,
+		})
+
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.pause.failed"))
+		ctx.ReplyEmbed(errorEmbed)
+		return
+	}
+
+	logger.Info("Playback paused", map[string]interface{}{
+		"guild_id":  guildID,
+		"user_id":   ctx.Author.ID,
+		"paused_by": ctx.Author.Username,
 	})
-	
-	warningEmbed := embedBuilder.Warning("⏸️ Pause Not Available", "Pause functionality is not yet implemented. Use `!stop` to stop playback.")
-	s.ChannelMessageSendEmbed(m.ChannelID, warningEmbed)
+
+	successEmbed := embedBuilder.Success(ctx.T("commands.pause.paused.title"), ctx.T("commands.pause.paused.description", commandDisplayName(ctx, "resume")))
+	ctx.ReplyEmbed(successEmbed)
+
+	refreshNowPlayingPanel(ctx.Session, guildID)
 }
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// Global permission engine consulted before dispatching commands
+var permissionEngine *permissions.Engine
+
+// InitializePermissions sets up the permission engine with database storage for role mappings
+func InitializePermissions(db *gorm.DB, ownerID string) {
+	permissionEngine = permissions.NewEngine(db, ownerID)
+}
+
+// getPermissionEngine returns the permission engine, falling back to defaults-only checks
+func getPermissionEngine() *permissions.Engine {
+	if permissionEngine == nil {
+		permissionEngine = permissions.NewEngine(nil, os.Getenv("BOT_OWNER_ID"))
+	}
+	return permissionEngine
+}
+
+// AuthorizeInteraction checks a button or picker interaction against the permission policy
+// of the command it acts for, returning a denial message if the user lacks the capability
+func AuthorizeInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, command string, args []string) (bool, string) {
+	capability := RequiredCapability(command, args)
+	user := interactionUser(i)
+	if getPermissionEngine().Check(s, i.GuildID, user.ID, capability) {
+		return true, ""
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger(command).Warn("Interaction denied by permission policy", map[string]interface{}{
+		"user_id":    user.ID,
+		"guild_id":   i.GuildID,
+		"channel_id": i.ChannelID,
+		"capability": string(capability),
+	})
+	return false, "❌ " + permissionDeniedMessage(interactionLanguage(i), capability)
+}
+
+// permissionDeniedMessage explains which capability was missing
+func permissionDeniedMessage(lang string, capability permissions.Capability) string {
+	if capability.IsOwnerOnly() {
+		return i18n.T(lang, "commands.permission_denied.owner")
+	}
+	if capability == permissions.SettingsManage {
+		return i18n.T(lang, "commands.permission_denied.settings")
+	}
+	return i18n.T(lang, "commands.permission_denied.capability", capability, strings.ToLower(capability.Description(lang)))
+}
+
+// hasCapability checks a capability for a user without sending any message
+func hasCapability(s *discordgo.Session, guildID, userID string, capability permissions.Capability) bool {
+	return getPermissionEngine().Check(s, guildID, userID, capability)
+}
+
+// hasCapabilityGrant reports whether a user holds a capability through a role mapping or admin access
+func hasCapabilityGrant(s *discordgo.Session, guildID, userID string, capability permissions.Capability) bool {
+	return getPermissionEngine().HasGrant(s, guildID, userID, capability)
+}
+
+// PermsCommand handles the !perms command to map capabilities to roles
+func PermsCommand(ctx *Context) {
+	// Initialize centralized logging for this command
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("perms")
+	logger.Info("Perms command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	usage := ctx.T("commands.perms.usage", commandDisplayName(ctx, "perms"))
+
+	if ctx.GuildID == "" {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.perms.server_only"), 0xff0000)
+		return
+	}
+
+	if len(ctx.Args) == 0 || strings.ToLower(ctx.Args[0]) == "list" {
+		showPermissionMappings(ctx)
+		return
+	}
+
+	subcommand := strings.ToLower(ctx.Args[0])
+	switch subcommand {
+	case "grant", "revoke":
+		if len(ctx.Args) < 3 {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), usage, 0xff0000)
+			return
+		}
+
+		capability, err := permissions.ParseCapability(ctx.Args[1])
+		if err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.perms.unknown_capability.title"), localizeError(ctx.Language, err)+"\n\n"+capabilityList(ctx.Language), 0xff0000)
+			return
+		}
+
+		roleID, err := resolveRoleID(ctx.Session, ctx.GuildID, strings.Join(ctx.Args[2:], " "))
+		if err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.perms.unknown_role.title"), localizeError(ctx.Language, err), 0xff0000)
+			return
+		}
+
+		updateCapabilityMapping(ctx, subcommand == "grant", []permissions.Capability{capability}, roleID, logger)
+	case "dj", "undj":
+		if len(ctx.Args) < 2 {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), usage, 0xff0000)
+			return
+		}
+
+		roleID, err := resolveRoleID(ctx.Session, ctx.GuildID, strings.Join(ctx.Args[1:], " "))
+		if err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.perms.unknown_role.title"), localizeError(ctx.Language, err), 0xff0000)
+			return
+		}
+
+		updateCapabilityMapping(ctx, subcommand == "dj", permissions.DJCapabilities, roleID, logger)
+	default:
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), usage, 0xff0000)
+	}
+}
+
+// updateCapabilityMapping grants or revokes capabilities for a role and reports the result
+func updateCapabilityMapping(ctx *Context, grant bool, capabilities []permissions.Capability, roleID string, logger logging.Logger) {
+	engine := getPermissionEngine()
+
+	var changed []string
+	for _, capability := range capabilities {
+		if grant {
+			if err := engine.Grant(ctx.GuildID, capability, roleID, ctx.Author.ID); err != nil {
+				logger.Error("Failed to grant capability", err, map[string]interface{}{
+					"guild_id":   ctx.GuildID,
+					"capability": string(capability),
+					"role_id":    roleID,
+				})
+				replyEmbedMessage(ctx, ctx.T("commands.error.title"), localizeError(ctx.Language, err), 0xff0000)
+				return
+			}
+			changed = append(changed, fmt.Sprintf("`%s`", capability))
+			continue
+		}
+
+		removed, err := engine.Revoke(ctx.GuildID, capability, roleID)
+		if err != nil {
+			logger.Error("Failed to revoke capability", err, map[string]interface{}{
+				"guild_id":   ctx.GuildID,
+				"capability": string(capability),
+				"role_id":    roleID,
+			})
+			replyEmbedMessage(ctx, ctx.T("commands.error.title"), localizeError(ctx.Language, err), 0xff0000)
+			return
+		}
+		if removed {
+			changed = append(changed, fmt.Sprintf("`%s`", capability))
+		}
+	}
+
+	if len(changed) == 0 {
+		replyEmbedMessage(ctx, ctx.T("commands.perms.unchanged.title"), ctx.T("commands.perms.unchanged.description", roleID), 0x808080)
+		return
+	}
+
+	if grant {
+		replyEmbedMessage(ctx, ctx.T("commands.perms.updated.title"), ctx.T("commands.perms.granted", roleID, strings.Join(changed, ", ")), 0x00ff00)
+	} else {
+		replyEmbedMessage(ctx, ctx.T("commands.perms.updated.title"), ctx.T("commands.perms.revoked", strings.Join(changed, ", "), roleID), 0x00ff00)
+	}
+}
+
+// showPermissionMappings lists the capability to role mappings for the guild
+func showPermissionMappings(ctx *Context) {
+	grants := getPermissionEngine().Grants(ctx.GuildID)
+
+	var lines []string
+	for _, capability := range permissions.Assignable {
+		roles := grants[capability]
+		value := ctx.T("commands.perms.default_rule")
+		if len(roles) > 0 {
+			mentions := make([]string, len(roles))
+			for i, roleID := range roles {
+				mentions[i] = fmt.Sprintf("<@&%s>", roleID)
+			}
+			value = strings.Join(mentions, ", ")
+		}
+		lines = append(lines, fmt.Sprintf("• `%s` — %s", capability, value))
+	}
+
+	description := strings.Join(lines, "\n") + "\n\n" + ctx.T("commands.perms.list.footer")
+	replyEmbedMessage(ctx, ctx.T("commands.perms.list.title"), description, 0x0099ff)
+}
+
+// capabilityList renders the assignable capabilities with descriptions
+func capabilityList(lang string) string {
+	var lines []string
+	for _, capability := range permissions.Assignable {
+		lines = append(lines, fmt.Sprintf("• `%s` - %s", capability, capability.Description(lang)))
+	}
+	return i18n.T(lang, "commands.perms.available") + "\n" + strings.Join(lines, "\n")
+}
+
+// resolveRoleID resolves a role mention, ID or name to a role ID in the guild
+func resolveRoleID(s *discordgo.Session, guildID, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	roleID := strings.TrimSuffix(strings.TrimPrefix(input, "<@&"), ">")
+
+	if role, err := s.State.Role(guildID, roleID); err == nil {
+		return role.ID, nil
+	}
+
+	roles, err := s.GuildRoles(guildID)
+	if err != nil {
+		return "", i18n.Errorf("commands.perms.error.load_roles")
+	}
+	for _, role := range roles {
+		if role.ID == roleID || strings.EqualFold(role.Name, input) {
+			return role.ID, nil
+		}
+	}
+	return "", i18n.Errorf("commands.perms.error.no_role", input)
+}
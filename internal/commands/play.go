@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/audio"
 	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
@@ -61,63 +63,84 @@ func sendPlayCommandEmbed(s *discordgo.Session, channelID, title, description st
 }
 
 // PlayCommand handles the play command with queue integration
-func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+func PlayCommand(ctx *Context) {
 	// Initialize centralized systems if not already done
 	if playCommandEmbedBuilder == nil || playCommandLogger == nil {
 		InitializePlayCommand()
 	}
+	embedBuilder := playCommandEmbedBuilder.WithLanguage(ctx.Language)
 	
 	// Log command execution with centralized logging
 	playCommandLogger.Info("Play command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
-		"args_count": len(args),
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
 	
-	if len(args) < 1 {
+	if len(ctx.Args) < 1 {
 		// Use centralized embed system for error messages
-		embed := playCommandEmbedBuilder.Error("❌ Usage Error", "Please provide a YouTube URL or search query.")
-		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		embed := embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.play.no_query"))
+		ctx.ReplyEmbed(embed)
 		
 		playCommandLogger.Warn("Play command called without arguments", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
 		return
 	}
 
-	guildID := m.GuildID
+	guildID := ctx.GuildID
 
 	// Update activity for idle monitoring
 	updateActivity(guildID)
 
-	input := args[0]
+	input := ctx.Args[0]
 	var url, title string
 	var duration time.Duration
 	var videoURL string // Store the video URL for search results
+	var clip audio.Clip // Part of the video to play, from the URL or a range argument
 
 	// Check if input is a URL or search query
 	if common.IsURL(input) {
 		// Input is a URL, use existing logic
 		playCommandLogger.Debug("Processing direct URL", map[string]interface{}{
 			"url":      input,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 			"guild_id": guildID,
 		})
-		
+
+		// An optional range after the URL, e.g. `!play <url> 1:20-3:45`
+		rangeArg := ""
+		if len(ctx.Args) > 1 {
+			rangeArg = ctx.Args[1]
+		}
+		requestedClip, clipErr := common.ClipForRequest(input, rangeArg)
+		if clipErr != nil {
+			embed := embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.play.clip_usage", localizeError(ctx.Language, clipErr), commandDisplayName(ctx, "play")))
+			ctx.ReplyEmbed(embed)
+			return
+		}
+		if !requestedClip.IsZero() && !common.IsYouTubeURL(input) {
+			embed := embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.play.clip_youtube_only"))
+			ctx.ReplyEmbed(embed)
+			return
+		}
+		clip = requestedClip
+
+		notifyIfBusy(ctx, audio.ProcessExtraction, audio.PriorityPlayback)
 		streamURL, streamTitle, streamDuration, err := common.GetYouTubeAudioStreamWithMetadata(input)
 		if err != nil {
 			playCommandLogger.Error("Error fetching stream URL", err, map[string]interface{}{
 				"url":      input,
-				"user_id":  m.Author.ID,
+				"user_id":  ctx.Author.ID,
 				"guild_id": guildID,
 			})
 			
 			// Use centralized embed system for error messages
-			embed := playCommandEmbedBuilder.Error("❌ Error", "Failed to get audio stream. Please check the URL.")
-			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			embed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.play.stream_failed"))
+			ctx.ReplyEmbed(embed)
 			return
 		}
 		url = streamURL
@@ -126,25 +149,26 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 		videoURL = input // For direct URLs, use the input as video URL
 	} else {
 		// Input is a search query, search YouTube and get the first result
-		searchQuery := strings.Join(args, " ") // Join all args as search query
+		searchQuery := strings.Join(ctx.Args, " ") // Join all args as search query
 		playCommandLogger.Debug("Processing search query", map[string]interface{}{
 			"search_query": searchQuery,
-			"user_id":      m.Author.ID,
+			"user_id":      ctx.Author.ID,
 			"guild_id":     guildID,
 		})
 
 		// Search for the video and get its URL
+		notifyIfBusy(ctx, audio.ProcessExtraction, audio.PriorityMetadata)
 		foundVideoURL, _, _, searchErr := common.SearchYouTubeAndGetURL(searchQuery)
 		if searchErr != nil {
 			playCommandLogger.Error("Error searching YouTube", searchErr, map[string]interface{}{
 				"search_query": searchQuery,
-				"user_id":      m.Author.ID,
+				"user_id":      ctx.Author.ID,
 				"guild_id":     guildID,
 			})
 			
 			// Use centralized embed system for error messages
-			embed := playCommandEmbedBuilder.Error("❌ Search Error", "Failed to find any videos for your search query.")
-			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			embed := embedBuilder.Error(ctx.T("commands.search.failed.title"), ctx.T("commands.search.failed.description"))
+			ctx.ReplyEmbed(embed)
 			return
 		}
 
@@ -154,13 +178,13 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 			playCommandLogger.Error("Error fetching metadata from search result", metadataErr, map[string]interface{}{
 				"found_video_url": foundVideoURL,
 				"search_query":    searchQuery,
-				"user_id":         m.Author.ID,
+				"user_id":         ctx.Author.ID,
 				"guild_id":        guildID,
 			})
 			
 			// Use centralized embed system for error messages
-			embed := playCommandEmbedBuilder.Error("❌ Error", "Failed to get video metadata from search result.")
-			s.ChannelMessageSendEmbed(m.ChannelID, embed)
+			embed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.play.metadata_failed"))
+			ctx.ReplyEmbed(embed)
 			return
 		}
 
@@ -183,27 +207,51 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 		videoID = common.ExtractYouTubeVideoID(videoURL)
 		originalURL = videoURL
 		// Pass original YouTube URL - audio pipeline will extract stream URL just-in-time
-		queue.AddWithYouTubeData("", originalURL, videoID, title, m.Author.Username, duration)
-		
+		if err := queue.AddClipWithYouTubeData("", originalURL, videoID, title, ctx.Author.Username, ctx.Author.ID, duration, clip); err != nil {
+			playCommandLogger.Warn("Queue rejected YouTube video", map[string]interface{}{
+				"title":    title,
+				"user_id":  ctx.Author.ID,
+				"guild_id": guildID,
+				"reason":   err.Error(),
+			})
+
+			embed := embedBuilder.Error(ctx.T("commands.cant_add.title"), localizeError(ctx.Language, err))
+			ctx.ReplyEmbed(embed)
+			return
+		}
+
 		playCommandLogger.Info("Added YouTube video to queue", map[string]interface{}{
 			"title":        title,
 			"video_id":     videoID,
 			"original_url": originalURL,
 			"duration":     duration.String(),
-			"user_id":      m.Author.ID,
-			"username":     m.Author.Username,
+			"clip_start":   clip.Start.String(),
+			"clip_end":     clip.End.String(),
+			"user_id":      ctx.Author.ID,
+			"username":     ctx.Author.Username,
 			"guild_id":     guildID,
 			"queue_size":   queue.Size(),
 		})
 	} else {
 		// Use the original method for non-YouTube URLs
-		queue.Add(url, title, m.Author.Username)
-		
+		if err := queue.Add(url, title, ctx.Author.Username, ctx.Author.ID, duration); err != nil {
+			playCommandLogger.Warn("Queue limit rejected URL", map[string]interface{}{
+				"title":    title,
+				"user_id":  ctx.Author.ID,
+				"guild_id": guildID,
+				"reason":   err.Error(),
+			})
+
+			embed := embedBuilder.Error(ctx.T("commands.cant_add.title"), localizeError(ctx.Language, err))
+			ctx.ReplyEmbed(embed)
+			return
+		}
+
 		playCommandLogger.Info("Added non-YouTube URL to queue", map[string]interface{}{
 			"title":      title,
 			"url":        url,
-			"user_id":    m.Author.ID,
-			"username":   m.Author.Username,
+			"user_id":    ctx.Author.ID,
+			"username":   ctx.Author.Username,
 			"guild_id":   guildID,
 			"queue_size": queue.Size(),
 		})
@@ -211,9 +259,12 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 
 	// Send confirmation with centralized embed system
 	queueSize := queue.Size()
-	description := fmt.Sprintf("✅ Added **%s** to queue (Position: %d)", title, queueSize)
-	embed := playCommandEmbedBuilder.Success("🎵 Song Added", description)
-	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	description := ctx.T("commands.song_added.description", title, queue.PositionOf(ctx.Author.ID))
+	if !clip.IsZero() {
+		description += "\n" + describeClip(ctx.Language, clip, duration)
+	}
+	embed := embedBuilder.Success(ctx.T("commands.song_added.title"), description)
+	ctx.ReplyEmbed(embed)
 
 	// Check if we should start playing - only if the queue can start playing
 	if queue.CanStartPlaying() {
@@ -224,7 +275,7 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 			"has_pipeline":     queue.HasActivePipeline(),
 			"can_start":        queue.CanStartPlaying(),
 		})
-		startNextInQueue(s, m, queue)
+		startNextInQueue(ctx.Session, ctx.GuildID, ctx.ChannelID, ctx.Author.ID, queue)
 	} else {
 		playCommandLogger.Debug("Song added to queue but not starting playback", map[string]interface{}{
 			"guild_id":         guildID,
@@ -236,6 +287,33 @@ func PlayCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 	}
 }
 
+// describeClip summarises which part of a video of the given full length is queued
+func describeClip(lang string, clip audio.Clip, duration time.Duration) string {
+	description := i18n.T(lang, "commands.play.clip", common.FormatClip(clip))
+	if length := clip.Length(duration); length > 0 {
+		description += fmt.Sprintf(" (%s)", formatDuration(length))
+	}
+	return description
+}
+
+// notifyIfBusy tells the user their place in line when every process slot of the kind
+// is taken, so a slow reply doesn't look like the bot ignored them
+func notifyIfBusy(ctx *Context, kind audio.ProcessKind, priority audio.ProcessPriority) {
+	if notice := busyNotice(ctx.Language, kind, priority); notice != "" {
+		ctx.Progress("⏳ " + notice)
+	}
+}
+
+// busyNotice describes the caller's place in line for a process slot in the language,
+// or returns "" when one is free
+func busyNotice(lang string, kind audio.ProcessKind, priority audio.ProcessPriority) string {
+	position := audio.GetGlobalProcessBudget().Position(kind, priority)
+	if position == 0 {
+		return ""
+	}
+	return i18n.T(lang, "commands.play.busy", position)
+}
+
 // StatusCommand shows the current playback status
 func StatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
 	// Initialize centralized systems if not already done
@@ -244,6 +322,7 @@ func StatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 	}
 	
 	guildID := m.GuildID
+	lang := GuildSettings(guildID).Language
 	
 	// Log status command execution
 	playCommandLogger.Info("Status command executed", map[string]interface{}{
@@ -259,7 +338,7 @@ func StatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 
 	if !exists || !pipeline.IsPlaying() {
 		// Use centralized embed system
-		embed := playCommandEmbedBuilder.Info("🔇 No Audio", "No audio is currently playing.")
+		embed := playCommandEmbedBuilder.WithLanguage(lang).Info(i18n.T(lang, "commands.status.idle.title"), i18n.T(lang, "commands.no_audio"))
 		s.ChannelMessageSendEmbed(m.ChannelID, embed)
 		
 		playCommandLogger.Debug("Status check - no audio playing", map[string]interface{}{
@@ -271,7 +350,7 @@ func StatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 	}
 
 	// Use centralized embed system
-	embed := playCommandEmbedBuilder.Success("🎵 Audio Playing", "Audio is currently playing.")
+	embed := playCommandEmbedBuilder.WithLanguage(lang).Success(i18n.T(lang, "commands.status.playing.title"), i18n.T(lang, "commands.status.playing.description"))
 	s.ChannelMessageSendEmbed(m.ChannelID, embed)
 	
 	playCommandLogger.Debug("Status check - audio playing", map[string]interface{}{
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+const (
+	// minLiveProgressInterval keeps edits well inside Discord's per-channel limits
+	minLiveProgressInterval = 5 * time.Second
+	// maxLiveProgressBackoff caps how far updates slow down after rate limiting
+	maxLiveProgressBackoff = 2 * time.Minute
+)
+
+var (
+	// Guilds that opted in to live progress updates on the now-playing panel
+	liveProgressGuilds = make(map[string]bool)
+	// Stop channels for the running updater per guild
+	liveProgressStops    = make(map[string]chan struct{})
+	liveProgressMutex    sync.Mutex
+	liveProgressInterval = 15 * time.Second
+)
+
+// SetNowPlayingLiveInterval sets how many seconds pass between live progress updates
+func SetNowPlayingLiveInterval(seconds int) {
+	interval := time.Duration(seconds) * time.Second
+	if interval < minLiveProgressInterval {
+		return
+	}
+
+	liveProgressMutex.Lock()
+	defer liveProgressMutex.Unlock()
+	liveProgressInterval = interval
+}
+
+// isLiveProgressEnabled reports whether a guild opted in to live progress updates
+func isLiveProgressEnabled(guildID string) bool {
+	liveProgressMutex.Lock()
+	defer liveProgressMutex.Unlock()
+	return liveProgressGuilds[guildID]
+}
+
+// setLiveProgress turns live progress updates on or off for a guild
+func setLiveProgress(guildID string, enabled bool) {
+	liveProgressMutex.Lock()
+	defer liveProgressMutex.Unlock()
+
+	if enabled {
+		liveProgressGuilds[guildID] = true
+	} else {
+		delete(liveProgressGuilds, guildID)
+	}
+}
+
+// startLiveProgress (re)starts the progress updater for the guild's current track
+func startLiveProgress(s *discordgo.Session, guildID string, queue *common.MusicQueue) {
+	stopLiveProgress(guildID)
+
+	track := queue.Current()
+	if track == nil || !isLiveProgressEnabled(guildID) {
+		return
+	}
+
+	stop := make(chan struct{})
+	liveProgressMutex.Lock()
+	liveProgressStops[guildID] = stop
+	interval := liveProgressInterval
+	liveProgressMutex.Unlock()
+
+	go runLiveProgress(s, guildID, queue, track, interval, stop)
+}
+
+// stopLiveProgress stops the guild's progress updater, if any
+func stopLiveProgress(guildID string) {
+	liveProgressMutex.Lock()
+	defer liveProgressMutex.Unlock()
+
+	if stop, exists := liveProgressStops[guildID]; exists {
+		close(stop)
+		delete(liveProgressStops, guildID)
+	}
+}
+
+// runLiveProgress edits the now-playing panel until the track changes or playback ends,
+// backing off whenever Discord rate limits the edits
+func runLiveProgress(s *discordgo.Session, guildID string, queue *common.MusicQueue, track *common.QueueItem, base time.Duration, stop chan struct{}) {
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("nowplaying")
+
+	interval := base
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+
+		// Stop cleanly once the track has ended or been replaced
+		if queue.Current() != track || !queue.IsPlaying() {
+			return
+		}
+
+		// A paused track's position doesn't move, so there is nothing to redraw
+		if queue.IsPaused() {
+			timer.Reset(interval)
+			continue
+		}
+
+		err := editNowPlayingPanel(s, guildID, queue, discordgo.WithRetryOnRatelimit(false))
+
+		var rateLimit *discordgo.RateLimitError
+		switch {
+		case errors.As(err, &rateLimit):
+			interval *= 2
+			if interval > maxLiveProgressBackoff {
+				interval = maxLiveProgressBackoff
+			}
+			wait := interval
+			if rateLimit.RetryAfter > wait {
+				wait = rateLimit.RetryAfter
+			}
+
+			logger.Warn("Live progress update rate limited, backing off", map[string]interface{}{
+				"guild_id":    guildID,
+				"retry_after": rateLimit.RetryAfter.String(),
+				"next_update": wait.String(),
+			})
+			timer.Reset(wait)
+			continue
+		case err != nil:
+			// The panel was deleted or can't be edited anymore
+			logger.Debug("Live progress update failed, stopping", map[string]interface{}{
+				"guild_id": guildID,
+				"error":    err.Error(),
+			})
+			return
+		}
+
+		// Recover gradually after a backoff
+		if interval > base {
+			interval /= 2
+			if interval < base {
+				interval = base
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+// editNowPlayingPanel redraws the guild's existing panel in place
+func editNowPlayingPanel(s *discordgo.Session, guildID string, queue *common.MusicQueue, options ...discordgo.RequestOption) error {
+	panelMutex.Lock()
+	panel, exists := nowPlayingPanels[guildID]
+	panelMutex.Unlock()
+
+	item := queue.Current()
+	if !exists || item == nil {
+		return errors.New("no now playing panel")
+	}
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("nowplaying")
+	playerEmbed := buildPlayerEmbed(guildID, queue, item, logger)
+	components := playerComponents(guildID, queue, false)
+
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         panel.messageID,
+		Channel:    panel.channelID,
+		Embeds:     &[]*discordgo.MessageEmbed{playerEmbed},
+		Components: &components,
+	}, options...)
+	return err
+}
@@ -9,8 +9,11 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/internal/presence"
+	"github.com/latoulicious/HKTM/internal/settings"
+	"github.com/latoulicious/HKTM/pkg/audio"
 	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"gorm.io/gorm"
 )
@@ -40,24 +43,25 @@ func SetPresenceManager(pm *presence.PresenceManager) {
 }
 
 // InitializeEnhancedTimeout initializes the enhanced timeout system
-func InitializeEnhancedTimeout(session *discordgo.Session) {
+func InitializeEnhancedTimeout() {
 	// Initialize centralized systems
 	embedBuilder = embed.GetGlobalAudioEmbedBuilder()
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger = loggerFactory.CreateLogger("queue-commands")
-	
+
 	// Create timeout manager with presence manager interface
 	var pm common.PresenceManager
 	if presenceManager != nil {
 		pm = &presenceManagerAdapter{presenceManager}
 	}
-	
+
 	// Create queue getter adapter
 	queueGetter := &queueGetterAdapter{}
-	
-	timeoutManager = common.NewTimeoutManager(session, pm, queueGetter)
+
+	timeoutManager = common.NewTimeoutManager(sessionGetterAdapter{}, pm, queueGetter)
+	timeoutManager.SetSettingsGetter(settingsGetterAdapter{})
 	timeoutManager.StartMonitoring()
-	
+
 	logger.Info("Enhanced timeout system initialized", map[string]interface{}{
 		"has_presence_manager": presenceManager != nil,
 	})
@@ -68,12 +72,19 @@ type presenceManagerAdapter struct {
 	pm *presence.PresenceManager
 }
 
-func (pma *presenceManagerAdapter) ClearMusicPresence() {
+func (pma *presenceManagerAdapter) ClearMusicPresence(guildID string) {
 	if pma.pm != nil {
-		pma.pm.ClearMusicPresence()
+		pma.pm.ClearMusicPresence(guildID)
 	}
 }
 
+// sessionGetterAdapter routes the timeout manager to each guild's shard
+type sessionGetterAdapter struct{}
+
+func (sessionGetterAdapter) SessionForGuild(guildID string) *discordgo.Session {
+	return sessionForGuild(nil, guildID)
+}
+
 // queueGetterAdapter adapts the internal queue management to the common interface
 type queueGetterAdapter struct{}
 
@@ -86,7 +97,7 @@ func updateActivity(guildID string) {
 	if timeoutManager != nil {
 		timeoutManager.UpdateActivity(guildID)
 	}
-	
+
 	// Log activity update
 	if logger != nil {
 		logger.Debug("Updated activity for guild", map[string]interface{}{
@@ -96,46 +107,54 @@ func updateActivity(guildID string) {
 }
 
 // GetIdleMonitor returns the enhanced idle monitor initialization function
-func GetIdleMonitor() func(*discordgo.Session) {
+func GetIdleMonitor() func() {
 	return InitializeEnhancedTimeout
 }
 
 // QueueCommand handles the queue command
-func QueueCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	if len(args) < 1 {
+func QueueCommand(ctx *Context) {
+	if len(ctx.Args) < 1 {
 		// Show current queue
-		showQueue(s, m)
+		showQueue(ctx)
 		return
 	}
 
 	// Handle subcommands
-	subcommand := strings.ToLower(args[0])
+	subcommand := strings.ToLower(ctx.Args[0])
 	switch subcommand {
 	case "add":
-		if len(args) < 2 {
-			sendEmbedMessage(s, m.ChannelID, "❌ Usage Error", "Usage: `!queue add <youtube_url>`", 0xff0000)
+		if len(ctx.Args) < 2 {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.add.usage", commandDisplayName(ctx, "queue add")), 0xff0000)
 			return
 		}
-		addToQueue(s, m, args[1:])
+		addToQueue(ctx, ctx.Args[1:])
 	case "remove":
-		if len(args) < 2 {
-			sendEmbedMessage(s, m.ChannelID, "❌ Usage Error", "Usage: `!queue remove <index>`", 0xff0000)
+		if len(ctx.Args) < 2 {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.remove.usage", commandDisplayName(ctx, "queue remove")), 0xff0000)
 			return
 		}
-		removeFromQueue(s, m, args[1:])
+		removeFromQueue(ctx, ctx.Args[1:])
 	case "clear":
-		clearQueue(s, m)
+		clearQueue(ctx)
 	case "list":
-		showQueue(s, m)
+		showQueue(ctx)
+	case "mode":
+		setQueueMode(ctx, ctx.Args[1:])
+	case "limit", "limits":
+		setQueueLimits(ctx, ctx.Args[1:])
+	case "export":
+		exportQueue(ctx, ctx.Args[1:])
+	case "import":
+		importQueue(ctx)
 	default:
-		sendEmbedMessage(s, m.ChannelID, "❌ Usage Error", "Usage: `!queue [add|remove|clear|list] [args...]`", 0xff0000)
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.usage", commandDisplayName(ctx, "queue")), 0xff0000)
 	}
 }
 
 // sendEmbedMessage is a helper function to send embed messages using centralized embeds
 func sendEmbedMessage(s *discordgo.Session, channelID, title, description string, color int) {
 	var embed *discordgo.MessageEmbed
-	
+
 	// Use centralized embed builder based on color
 	switch color {
 	case 0x00ff00: // Green - Success
@@ -147,7 +166,7 @@ func sendEmbedMessage(s *discordgo.Session, channelID, title, description string
 	default: // Default - Info
 		embed = embedBuilder.Info(title, description)
 	}
-	
+
 	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send embed message", err, map[string]interface{}{
@@ -157,10 +176,34 @@ func sendEmbedMessage(s *discordgo.Session, channelID, title, description string
 	}
 }
 
+// replyEmbedMessage replies to a command with an embed styled by its color, like sendEmbedMessage
+func replyEmbedMessage(ctx *Context, title, description string, color int) {
+	builder := embedsFor(ctx)
+
+	var embed *discordgo.MessageEmbed
+	switch color {
+	case 0x00ff00: // Green - Success
+		embed = builder.Success(title, description)
+	case 0xff0000: // Red - Error
+		embed = builder.Error(title, description)
+	case 0xffa500: // Orange - Warning
+		embed = builder.Warning(title, description)
+	default: // Default - Info
+		embed = builder.Info(title, description)
+	}
+
+	if err := ctx.ReplyEmbed(embed); err != nil && logger != nil {
+		logger.Error("Failed to send embed reply", err, map[string]interface{}{
+			"channel_id": ctx.ChannelID,
+			"title":      title,
+		})
+	}
+}
+
 // sendSongFinishedEmbed sends an embed when a song finishes playing using centralized embeds
-func sendSongFinishedEmbed(s *discordgo.Session, channelID, songTitle, requestedBy string) {
-	embed := embedBuilder.SongFinished(songTitle, requestedBy)
-	
+func sendSongFinishedEmbed(s *discordgo.Session, guildID, channelID, songTitle, requestedBy string) {
+	embed := embedBuilder.WithLanguage(GuildSettings(guildID).Language).SongFinished(songTitle, requestedBy)
+
 	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send song finished embed", err, map[string]interface{}{
@@ -172,9 +215,9 @@ func sendSongFinishedEmbed(s *discordgo.Session, channelID, songTitle, requested
 }
 
 // sendQueueEndedEmbed sends an embed when the queue ends using centralized embeds
-func sendQueueEndedEmbed(s *discordgo.Session, channelID string) {
-	embed := embedBuilder.QueueEnded()
-	
+func sendQueueEndedEmbed(s *discordgo.Session, guildID, channelID string) {
+	embed := embedBuilder.WithLanguage(GuildSettings(guildID).Language).QueueEnded()
+
 	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send queue ended embed", err, map[string]interface{}{
@@ -184,9 +227,9 @@ func sendQueueEndedEmbed(s *discordgo.Session, channelID string) {
 }
 
 // sendSongSkippedEmbed sends an embed when a song is skipped using centralized embeds
-func sendSongSkippedEmbed(s *discordgo.Session, channelID, songTitle, requestedBy, skippedBy string) {
-	embed := embedBuilder.SongSkipped(songTitle, requestedBy, skippedBy)
-	
+func sendSongSkippedEmbed(s *discordgo.Session, guildID, channelID, songTitle, requestedBy, skippedBy string) {
+	embed := embedBuilder.WithLanguage(GuildSettings(guildID).Language).SongSkipped(songTitle, requestedBy, skippedBy)
+
 	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send song skipped embed", err, map[string]interface{}{
@@ -199,9 +242,9 @@ func sendSongSkippedEmbed(s *discordgo.Session, channelID, songTitle, requestedB
 }
 
 // sendBotStoppedEmbed sends an embed when the bot stops/disconnects using centralized embeds
-func sendBotStoppedEmbed(s *discordgo.Session, channelID, stoppedBy string) {
-	embed := embedBuilder.PlaybackStopped(stoppedBy)
-	
+func sendBotStoppedEmbed(s *discordgo.Session, guildID, channelID, stoppedBy string) {
+	embed := embedBuilder.WithLanguage(GuildSettings(guildID).Language).PlaybackStopped(stoppedBy)
+
 	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send playback stopped embed", err, map[string]interface{}{
@@ -212,8 +255,8 @@ func sendBotStoppedEmbed(s *discordgo.Session, channelID, stoppedBy string) {
 }
 
 // addToQueue adds a song to the queue
-func addToQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	guildID := m.GuildID
+func addToQueue(ctx *Context, args []string) {
+	guildID := ctx.GuildID
 	url := args[0]
 
 	// Update activity
@@ -227,49 +270,76 @@ func addToQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 	var originalURL string
 	var title string
 	var duration time.Duration
-	
+
+	// An optional range after the URL, e.g. `!queue add <url> 1:20-3:45`
+	rangeArg := ""
+	if len(args) > 1 {
+		rangeArg = args[1]
+	}
+	clip, err := common.ClipForRequest(url, rangeArg)
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.add.clip_usage", localizeError(ctx.Language, err), commandDisplayName(ctx, "queue add")), 0xff0000)
+		return
+	}
+
 	if common.IsYouTubeURL(url) {
 		// Get metadata only (no stream URL extraction to prevent expiration)
+		notifyIfBusy(ctx, audio.ProcessExtraction, audio.PriorityMetadata)
 		metadataTitle, metadataDuration, err := common.GetYouTubeMetadata(url)
 		if err != nil {
-			sendEmbedMessage(s, m.ChannelID, "❌ Error", "Failed to get video metadata. Please check the URL.", 0xff0000)
+			replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.queue.add.metadata_failed"), 0xff0000)
 			return
 		}
-		
+
 		videoID = common.ExtractYouTubeVideoID(url)
 		originalURL = url
 		title = metadataTitle
 		duration = metadataDuration
-		
+
 		// Pass original YouTube URL - audio pipeline will extract stream URL just-in-time
-		queue.AddWithYouTubeData("", originalURL, videoID, title, m.Author.Username, duration)
+		if err := queue.AddClipWithYouTubeData("", originalURL, videoID, title, ctx.Author.Username, ctx.Author.ID, duration, clip); err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.cant_add.title"), localizeError(ctx.Language, err), 0xff0000)
+			return
+		}
 	} else {
-		// For non-YouTube URLs, we still need to validate them
-		// But we don't pre-extract stream URLs
-		queue.Add(url, "Direct URL", m.Author.Username)
-		title = "Direct URL"
+		if !clip.IsZero() {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.play.clip_youtube_only"), 0xff0000)
+			return
+		}
+
+		// Look up the length so per-user duration caps can count it; the stream URL
+		// itself is still extracted just-in-time
+		title, duration = directURLMetadata(url, "")
+		if err := queue.Add(url, title, ctx.Author.Username, ctx.Author.ID, duration); err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.cant_add.title"), localizeError(ctx.Language, err), 0xff0000)
+			return
+		}
 	}
 
 	// Send confirmation with embed
 	queueSize := queue.Size()
-	description := fmt.Sprintf("✅ Added **%s** to queue (Position: %d)", title, queueSize)
-	sendEmbedMessage(s, m.ChannelID, "🎵 Song Added", description, 0x00ff00)
-	
+	position := queue.PositionOf(ctx.Author.ID)
+	description := ctx.T("commands.song_added.description", title, position)
+	if !clip.IsZero() {
+		description += "\n" + describeClip(ctx.Language, clip, duration)
+	}
+	replyEmbedMessage(ctx, ctx.T("commands.song_added.title"), description, 0x00ff00)
+
 	// Log queue operation with centralized logging
 	queue.LogQueueOperation("song_added", map[string]interface{}{
 		"title":        title,
 		"url":          url,
-		"requested_by": m.Author.Username,
-		"user_id":      m.Author.ID,
-		"channel_id":   m.ChannelID,
-		"position":     queueSize,
+		"requested_by": ctx.Author.Username,
+		"user_id":      ctx.Author.ID,
+		"channel_id":   ctx.ChannelID,
+		"position":     position,
 	})
 
 	// Check if we should start playing - only if the queue can start playing
 	if queue.CanStartPlaying() {
 		log.Printf("Starting playback for guild %s - queue size: %d, isPlaying: %v, hasPipeline: %v",
 			guildID, queueSize, queue.IsPlaying(), queue.HasActivePipeline())
-		startNextInQueue(s, m, queue)
+		startNextInQueue(ctx.Session, ctx.GuildID, ctx.ChannelID, ctx.Author.ID, queue)
 	} else {
 		log.Printf("Song added to queue for guild %s but not starting playback - queue size: %d, isPlaying: %v, hasPipeline: %v",
 			guildID, queueSize, queue.IsPlaying(), queue.HasActivePipeline())
@@ -277,8 +347,8 @@ func addToQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []string)
 }
 
 // removeFromQueue removes a song from the queue
-func removeFromQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	guildID := m.GuildID
+func removeFromQueue(ctx *Context, args []string) {
+	guildID := ctx.GuildID
 
 	// Update activity
 	updateActivity(guildID)
@@ -286,7 +356,7 @@ func removeFromQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []st
 	queue := getQueue(guildID)
 
 	if queue == nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "No queue found for this server.", 0xff0000)
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.no_queue"), 0xff0000)
 		return
 	}
 
@@ -294,7 +364,7 @@ func removeFromQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []st
 	var index int
 	_, err := fmt.Sscanf(args[0], "%d", &index)
 	if err != nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "Invalid index. Use `!queue list` to see queue positions.", 0xff0000)
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.queue.remove.invalid_index", commandDisplayName(ctx, "queue list")), 0xff0000)
 		return
 	}
 
@@ -303,23 +373,23 @@ func removeFromQueue(s *discordgo.Session, m *discordgo.MessageCreate, args []st
 
 	err = queue.Remove(index)
 	if err != nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", err.Error(), 0xff0000)
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.queue.remove.invalid_index", commandDisplayName(ctx, "queue list")), 0xff0000)
 		return
 	}
 
-	sendEmbedMessage(s, m.ChannelID, "✅ Success", "Removed song from queue.", 0x00ff00)
-	
+	replyEmbedMessage(ctx, ctx.T("commands.success.title"), ctx.T("commands.queue.remove.removed"), 0x00ff00)
+
 	// Log queue operation with centralized logging
 	queue.LogQueueOperation("song_removed", map[string]interface{}{
 		"index":      index + 1, // Convert back to 1-based for logging
-		"user_id":    m.Author.ID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"channel_id": ctx.ChannelID,
 	})
 }
 
 // clearQueue clears the entire queue
-func clearQueue(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func clearQueue(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Update activity
 	updateActivity(guildID)
@@ -327,25 +397,143 @@ func clearQueue(s *discordgo.Session, m *discordgo.MessageCreate) {
 	queue := getQueue(guildID)
 
 	if queue == nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "No queue found for this server.", 0xff0000)
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.no_queue"), 0xff0000)
 		return
 	}
 
 	queueSizeBefore := queue.Size()
 	queue.Clear()
-	sendEmbedMessage(s, m.ChannelID, "✅ Success", "Queue cleared.", 0x00ff00)
-	
+	replyEmbedMessage(ctx, ctx.T("commands.success.title"), ctx.T("commands.queue.cleared"), 0x00ff00)
+
 	// Log queue operation with centralized logging
 	queue.LogQueueOperation("queue_cleared", map[string]interface{}{
 		"items_cleared": queueSizeBefore,
-		"user_id":       m.Author.ID,
-		"channel_id":    m.ChannelID,
+		"user_id":       ctx.Author.ID,
+		"channel_id":    ctx.ChannelID,
 	})
 }
 
+// setQueueMode shows or changes the queue ordering mode for the guild
+func setQueueMode(ctx *Context, args []string) {
+	guildID := ctx.GuildID
+
+	// Update activity
+	updateActivity(guildID)
+
+	queue := getOrCreateQueue(guildID)
+
+	if len(args) == 0 {
+		description := ctx.T("commands.queue.mode.description", GuildSettings(guildID).QueueMode, commandDisplayName(ctx, "queue mode"))
+		replyEmbedMessage(ctx, ctx.T("commands.queue.mode.title"), description, 0x0099ff)
+		return
+	}
+
+	mode, err := common.ParseQueueMode(args[0])
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.mode.usage", commandDisplayName(ctx, "queue mode")), 0xff0000)
+		return
+	}
+
+	// The mode is a guild setting so it survives restarts
+	updated, err := getSettingsService().Set(guildID, settings.QueueMode, string(mode), ctx.Author.ID)
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), localizeError(ctx.Language, err)+".", 0xff0000)
+		return
+	}
+	settingsChanged(updated)
+
+	description := ctx.T("commands.queue.mode.fifo")
+	if mode == common.QueueModeFair {
+		description = ctx.T("commands.queue.mode.fair")
+	}
+	replyEmbedMessage(ctx, ctx.T("commands.queue.mode.changed", mode), description, 0x00ff00)
+
+	queue.LogQueueOperation("mode_changed", map[string]interface{}{
+		"mode":       string(mode),
+		"user_id":    ctx.Author.ID,
+		"channel_id": ctx.ChannelID,
+	})
+}
+
+// setQueueLimits shows or changes the per-user queue caps for the guild
+func setQueueLimits(ctx *Context, args []string) {
+	guildID := ctx.GuildID
+
+	// Update activity
+	updateActivity(guildID)
+
+	queue := getOrCreateQueue(guildID)
+	guildSettings := GuildSettings(guildID)
+	maxItems, maxDuration := guildSettings.MaxUserSongs, guildSettings.MaxUserDuration
+
+	usage := ctx.T("commands.queue.limits.usage", commandDisplayName(ctx, "queue limit"))
+
+	if len(args) == 0 {
+		description := ctx.T("commands.queue.limits.current", formatItemLimit(ctx.Language, maxItems), formatDurationLimit(ctx.Language, maxDuration)) + "\n\n" + usage
+		replyEmbedMessage(ctx, ctx.T("commands.queue.limits.title"), description, 0x0099ff)
+		return
+	}
+
+	if len(args) < 2 {
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), usage, 0xff0000)
+		return
+	}
+
+	value := strings.ToLower(args[1])
+	if value == "off" || value == "none" {
+		value = "0"
+	}
+
+	// Limits are guild settings so they survive restarts
+	var key settings.Key
+	switch strings.ToLower(args[0]) {
+	case "items", "songs", "count":
+		key = settings.MaxUserSongs
+	case "duration", "time", "length":
+		key = settings.MaxUserDuration
+	default:
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"), usage, 0xff0000)
+		return
+	}
+
+	updated, err := getSettingsService().Set(guildID, key, value, ctx.Author.ID)
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), localizeError(ctx.Language, err)+".", 0xff0000)
+		return
+	}
+	settingsChanged(updated)
+	maxItems, maxDuration = updated.MaxUserSongs, updated.MaxUserDuration
+
+	description := ctx.T("commands.queue.limits.current", formatItemLimit(ctx.Language, maxItems), formatDurationLimit(ctx.Language, maxDuration))
+	replyEmbedMessage(ctx, ctx.T("commands.queue.limits.updated"), description, 0x00ff00)
+
+	queue.LogQueueOperation("limits_changed", map[string]interface{}{
+		"max_items":    maxItems,
+		"max_duration": maxDuration.String(),
+		"user_id":      ctx.Author.ID,
+		"channel_id":   ctx.ChannelID,
+	})
+}
+
+// formatItemLimit renders a per-user item cap for display in the language
+func formatItemLimit(lang string, limit int) string {
+	if limit <= 0 {
+		return i18n.T(lang, "commands.queue.limits.unlimited")
+	}
+	return i18n.T(lang, "commands.queue.limits.songs", limit)
+}
+
+// formatDurationLimit renders a per-user duration cap for display in the language
+func formatDurationLimit(lang string, limit time.Duration) string {
+	if limit <= 0 {
+		return i18n.T(lang, "commands.queue.limits.unlimited")
+	}
+	return limit.String()
+}
+
 // showQueue shows the current queue using centralized embeds and logging
-func showQueue(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func showQueue(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Update activity
 	updateActivity(guildID)
@@ -353,42 +541,47 @@ func showQueue(s *discordgo.Session, m *discordgo.MessageCreate) {
 	queue := getQueue(guildID)
 
 	if queue == nil || (queue.Size() == 0 && queue.Current() == nil) {
-		sendEmbedMessage(s, m.ChannelID, "📭 Queue Empty", "No songs in the queue.", 0x808080)
-		
+		replyEmbedMessage(ctx, ctx.T("commands.queue.empty.title"), ctx.T("audio.queue.empty"), 0x808080)
+
 		// Log queue status request
 		if logger != nil {
 			logger.Info("Queue status requested - empty queue", map[string]interface{}{
 				"guild_id":   guildID,
-				"user_id":    m.Author.ID,
-				"channel_id": m.ChannelID,
+				"user_id":    ctx.Author.ID,
+				"channel_id": ctx.ChannelID,
 			})
 		}
 		return
 	}
 
 	// Use centralized queue status embed
-	embed := queue.GetQueueStatusEmbed()
-	
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	embed := queue.GetQueueStatusEmbed(ctx.Language)
+
+	err := ctx.ReplyEmbed(embed)
 	if err != nil && logger != nil {
 		logger.Error("Failed to send queue status embed", err, map[string]interface{}{
 			"guild_id":   guildID,
-			"channel_id": m.ChannelID,
+			"channel_id": ctx.ChannelID,
 		})
 	} else if logger != nil {
 		// Log successful queue status display
 		logger.Info("Queue status displayed", map[string]interface{}{
-			"guild_id":     guildID,
-			"user_id":      m.Author.ID,
-			"channel_id":   m.ChannelID,
-			"queue_size":   queue.Size(),
-			"has_current":  queue.Current() != nil,
+			"guild_id":    guildID,
+			"user_id":     ctx.Author.ID,
+			"channel_id":  ctx.ChannelID,
+			"queue_size":  queue.Size(),
+			"has_current": queue.Current() != nil,
 		})
 	}
 }
 
-// startNextInQueue starts playing the next song in the queue
-func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *common.MusicQueue) {
+// startNextInQueue starts playing the next song in the queue. Messages about the
+// playback go to the channel it was requested in unless the guild has an announce
+// channel; when not connected yet, the bot joins userID's voice channel.
+func startNextInQueue(s *discordgo.Session, guildID, channelID, userID string, queue *common.MusicQueue) {
+	// Skip votes never carry over to the next track
+	resetSkipVotes(guildID)
+
 	// Check if there's already an active pipeline and clean it up
 	if queue.HasActivePipeline() {
 		log.Printf("Cleaning up existing pipeline before starting new one")
@@ -400,19 +593,21 @@ func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *c
 		queue.SetPlaying(false)
 		// Clear presence when no more songs
 		if presenceManager != nil {
-			presenceManager.ClearMusicPresence()
+			presenceManager.ClearMusicPresence(guildID)
 		}
-		// Send queue ended embed
-		sendQueueEndedEmbed(s, m.ChannelID)
+		// Retire the control panel and send queue ended embed
+		closeNowPlayingPanel(s, guildID)
+		sendQueueEndedEmbed(s, guildID, announceChannel(guildID, channelID))
 		return
 	}
 
 	queue.SetPlaying(true)
 
 	// Find user's voice channel and connect
-	vc, err := common.FindAndJoinUserVoiceChannel(s, m.Author.ID, m.GuildID)
+	vc, err := common.FindAndJoinUserVoiceChannel(s, userID, guildID)
 	if err != nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", err.Error(), 0xff0000)
+		lang := GuildSettings(guildID).Language
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.error.title"), localizeError(lang, err), 0xff0000)
 		queue.SetPlaying(false)
 		return
 	}
@@ -422,15 +617,11 @@ func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *c
 	// Update bot presence to show current song
 	if presenceManager != nil {
 		log.Printf("Updating presence to show: %s", item.Title)
-		presenceManager.UpdateMusicPresence(item.Title)
+		presenceManager.UpdateMusicPresence(guildID, item.Title)
 	} else {
 		log.Printf("Warning: presenceManager is nil, cannot update presence")
 	}
 
-	// Send now playing message with embed
-	description := item.Title
-	sendEmbedMessage(s, m.ChannelID, "🎶 Now Playing", description, 0x00ff00)
-
 	// Use the new pipeline system with enhanced error handling
 	// Always pass the original YouTube URL to prevent URL expiration issues
 	// The audio pipeline will extract fresh URLs just-in-time
@@ -445,16 +636,33 @@ func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *c
 	}
 
 	// Start playback using the new pipeline system
-	err = queue.StartPlayback(playbackURL, vc)
+	lang := GuildSettings(guildID).Language
+	if notice := busyNotice(lang, audio.ProcessStream, audio.PriorityPlayback); notice != "" {
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.play.busy.title"), notice, 0xffa500)
+	}
+	err = queue.StartPlayback(playbackURL, item.Clip(), vc)
 	if err != nil {
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "Failed to start audio playback.", 0xff0000)
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.error.title"), i18n.T(lang, "commands.play.start_failed"), 0xff0000)
 		queue.StopAndCleanup()
 		if presenceManager != nil {
-			presenceManager.ClearMusicPresence()
+			presenceManager.ClearMusicPresence(guildID)
 		}
 		return
 	}
 
+	// Show the track on the guild's control panel, editing it in place when possible
+	showNowPlayingPanel(s, announceChannel(guildID, channelID), guildID, queue)
+	startLiveProgress(s, guildID, queue)
+
+	// Remember the track for /play suggestions
+	go recordPlayHistory(guildID, item)
+
+	// Follow the track's chapters in the panel and presence
+	go loadChapters(s, guildID, queue, item)
+
+	// On a stage, show the track as the topic
+	go updateStageForTrack(s, channelID, guildID, item)
+
 	// Monitor the pipeline and handle completion
 	go func() {
 		// Get the pipeline for monitoring
@@ -471,7 +679,10 @@ func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *c
 
 		// Only send song finished embed if the song wasn't skipped
 		if !queue.WasSkipped() {
-			sendSongFinishedEmbed(s, m.ChannelID, item.Title, item.RequestedBy)
+			sendSongFinishedEmbed(s, guildID, announceChannel(guildID, channelID), item.Title, item.RequestedBy)
+
+			// Looping replays finished songs; skipped songs always move on
+			queue.RequeueFinished(item)
 		}
 
 		// Clean up the pipeline
@@ -479,7 +690,7 @@ func startNextInQueue(s *discordgo.Session, m *discordgo.MessageCreate, queue *c
 		queue.SetSkipped(false) // Reset the skipped flag
 
 		// Play next song in queue
-		startNextInQueue(s, m, queue)
+		startNextInQueue(s, guildID, channelID, userID, queue)
 	}()
 }
 
@@ -504,7 +715,8 @@ func getOrCreateQueue(guildID string) *common.MusicQueue {
 		// Fallback to basic queue if no database connection available
 		queue = common.NewMusicQueue(guildID)
 	}
-	
+	applyQueueSettings(queue, GuildSettings(guildID))
+
 	queues[guildID] = queue
 	return queue
 }
@@ -524,6 +736,7 @@ func getOrCreateQueueWithDB(guildID string, db *gorm.DB) *common.MusicQueue {
 
 	// Create new queue with database connection
 	queue := common.NewMusicQueueWithDB(guildID, db)
+	applyQueueSettings(queue, GuildSettings(guildID))
 	queues[guildID] = queue
 	return queue
 }
@@ -544,14 +757,14 @@ func InitializeQueueCommands(db *gorm.DB) {
 // InitializeCommandsWithDB initializes the commands package with database connection for audio pipeline support
 func InitializeCommandsWithDB(db *gorm.DB) {
 	queueDB = db
-	
+
 	// Initialize centralized systems
 	embedBuilder = embed.GetGlobalAudioEmbedBuilder()
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger = loggerFactory.CreateLogger("commands")
-	
+
 	logger.Info("Commands package initialized with database connection", map[string]interface{}{
-		"database_connected": true,
+		"database_connected":     true,
 		"audio_pipeline_support": true,
 	})
 }
@@ -560,25 +773,25 @@ func InitializeCommandsWithDB(db *gorm.DB) {
 func ShutdownAllAudioPipelines() {
 	queueMutex.Lock()
 	defer queueMutex.Unlock()
-	
+
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	shutdownLogger := loggerFactory.CreateLogger("shutdown")
-	
+
 	shutdownCount := 0
 	for guildID, queue := range queues {
 		if queue != nil && queue.HasActivePipeline() {
 			shutdownLogger.Info("Shutting down audio pipeline", map[string]interface{}{
 				"guild_id": guildID,
 			})
-			
+
 			// Stop current playbook
 			queue.StopAndCleanup()
 			shutdownCount++
 		}
 	}
-	
+
 	shutdownLogger.Info("Audio pipeline shutdown complete", map[string]interface{}{
 		"pipelines_shutdown": shutdownCount,
-		"total_queues": len(queues),
+		"total_queues":       len(queues),
 	})
 }
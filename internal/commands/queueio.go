@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/audio"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+const (
+	// maxImportFileSize caps how much of an attachment is downloaded
+	maxImportFileSize = 1 << 20
+	// maxImportEntries caps how many lines a single import resolves
+	maxImportEntries = 100
+	// maxImportFailuresShown keeps the summary embed under Discord's description limit
+	maxImportFailuresShown = 15
+)
+
+// attachmentClient downloads import files from Discord's CDN
+var attachmentClient = &http.Client{Timeout: 15 * time.Second}
+
+// exportQueue uploads the current queue as a JSON or extended M3U attachment
+func exportQueue(ctx *Context, args []string) {
+	guildID := ctx.GuildID
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("queue")
+
+	// Update activity
+	updateActivity(guildID)
+
+	format := common.PlaylistFormatJSON
+	if len(args) > 0 {
+		parsed, err := common.ParsePlaylistFormat(args[0])
+		if err != nil {
+			replyEmbedMessage(ctx, ctx.T("commands.usage_error"), ctx.T("commands.queue.export.usage", commandDisplayName(ctx, "queue export")), 0xff0000)
+			return
+		}
+		format = parsed
+	}
+
+	queue := getQueue(guildID)
+	if queue == nil || (queue.Size() == 0 && queue.Current() == nil) {
+		replyEmbedMessage(ctx, ctx.T("commands.queue.empty.title"), ctx.T("commands.queue.export.empty"), 0x808080)
+		return
+	}
+
+	snapshot := queue.Snapshot()
+	data, err := snapshot.Encode(format)
+	if err != nil {
+		logger.Error("Failed to encode queue export", err, map[string]interface{}{
+			"guild_id": guildID,
+			"format":   string(format),
+		})
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.queue.export.failed"), 0xff0000)
+		return
+	}
+
+	description := ctx.T("commands.queue.export.description", len(snapshot.Queue), format)
+	if snapshot.NowPlaying != nil {
+		description += "\n" + ctx.T("commands.queue.export.now_playing", snapshot.NowPlaying.Title,
+			formatDuration(time.Duration(snapshot.NowPlaying.Elapsed)*time.Second))
+	}
+	description += "\n\n" + ctx.T("commands.queue.export.restore", commandDisplayName(ctx, "queue import"))
+
+	fileName := fmt.Sprintf("queue-%s-%s.%s", guildID, snapshot.ExportedAt.Format("20060102-150405"), format.Extension())
+	err = ctx.ReplyEmbedFile(embedsFor(ctx).Success(ctx.T("commands.queue.export.title"), description), &discordgo.File{
+		Name:        fileName,
+		ContentType: format.ContentType(),
+		Reader:      bytes.NewReader(data),
+	})
+	if err != nil {
+		logger.Error("Failed to upload queue export", err, map[string]interface{}{
+			"guild_id":   guildID,
+			"channel_id": ctx.ChannelID,
+		})
+		return
+	}
+
+	logger.Info("Queue exported", map[string]interface{}{
+		"guild_id":    guildID,
+		"user_id":     ctx.Author.ID,
+		"format":      string(format),
+		"queue_size":  len(snapshot.Queue),
+		"has_current": snapshot.NowPlaying != nil,
+	})
+}
+
+// importQueue adds every URL or search query from an attached playlist file to the queue
+func importQueue(ctx *Context) {
+	guildID := ctx.GuildID
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("queue")
+
+	// Update activity
+	updateActivity(guildID)
+
+	attachment := ctx.Attachment("file")
+	if attachment == nil {
+		replyEmbedMessage(ctx, ctx.T("commands.usage_error"),
+			ctx.T("commands.queue.import.usage", commandDisplayName(ctx, "queue import")), 0xff0000)
+		return
+	}
+
+	if attachment.Size > maxImportFileSize {
+		replyEmbedMessage(ctx, ctx.T("commands.queue.import.too_large.title"), ctx.T("commands.queue.import.too_large.description"), 0xff0000)
+		return
+	}
+
+	data, err := downloadAttachment(attachment.URL)
+	if err != nil {
+		logger.Error("Failed to download import file", err, map[string]interface{}{
+			"guild_id": guildID,
+			"filename": attachment.Filename,
+		})
+		replyEmbedMessage(ctx, ctx.T("commands.error.title"), ctx.T("commands.queue.import.download_failed"), 0xff0000)
+		return
+	}
+
+	entries, err := common.ParsePlaylistImport(data)
+	if err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.queue.import.failed.title"), ctx.T("commands.queue.import.unreadable", attachment.Filename, localizeError(ctx.Language, err)), 0xff0000)
+		return
+	}
+
+	var failures []string
+	if len(entries) > maxImportEntries {
+		failures = append(failures, ctx.T("commands.queue.import.truncated", maxImportEntries, len(entries)))
+		entries = entries[:maxImportEntries]
+	}
+
+	// Resolving searches takes a while, so let the user know we're working
+	ctx.Progress(ctx.T("commands.queue.import.progress", len(entries)))
+
+	queue := getOrCreateQueue(guildID)
+	added := 0
+	for _, entry := range entries {
+		if err := importEntry(queue, entry, ctx.Author.Username, ctx.Author.ID); err != nil {
+			failures = append(failures, ctx.T("commands.queue.import.line", entry.Line, truncateImportValue(entry.Value), localizeError(ctx.Language, err)))
+			continue
+		}
+		added++
+	}
+
+	logger.Info("Queue import completed", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  ctx.Author.ID,
+		"filename": attachment.Filename,
+		"entries":  len(entries),
+		"added":    added,
+		"failed":   len(entries) - added,
+	})
+
+	replyImportSummary(ctx, attachment.Filename, added, len(entries), failures)
+
+	if added > 0 && queue.CanStartPlaying() {
+		startNextInQueue(ctx.Session, ctx.GuildID, ctx.ChannelID, ctx.Author.ID, queue)
+	}
+}
+
+// importEntry validates and resolves a single import line, then adds it to the queue
+func importEntry(queue *common.MusicQueue, entry common.ImportEntry, requestedBy, requesterID string) error {
+	if !common.IsURL(entry.Value) {
+		url, title, duration, err := common.SearchYouTubeAndGetURL(entry.Value)
+		if err != nil || url == "" {
+			return i18n.Errorf("commands.queue.import.no_results")
+		}
+		return queue.AddWithYouTubeData("", url, common.ExtractYouTubeVideoID(url), title, requestedBy, requesterID, duration)
+	}
+
+	if err := audio.ValidateURL(entry.Value); err != nil {
+		return err
+	}
+
+	if !common.IsYouTubeURL(entry.Value) {
+		title, duration := entry.Title, entry.Duration
+		if duration == 0 {
+			title, duration = directURLMetadata(entry.Value, title)
+		}
+		return queue.Add(entry.Value, title, requestedBy, requesterID, duration)
+	}
+
+	clip := entry.Clip
+	if clip.IsZero() {
+		clip = common.ClipFromURL(entry.Value)
+	}
+
+	// Exports carry title and duration, so only look up metadata when they're missing.
+	// A clipped item's exported duration is the clipped length, not the video's.
+	title, duration := entry.Title, entry.Duration
+	if !clip.IsZero() {
+		duration = 0
+	}
+	if title == "" || duration == 0 {
+		metadataTitle, metadataDuration, err := common.GetYouTubeMetadata(entry.Value)
+		if err != nil {
+			return i18n.Errorf("commands.queue.import.unavailable")
+		}
+		title, duration = metadataTitle, metadataDuration
+	}
+
+	return queue.AddClipWithYouTubeData("", entry.Value, common.ExtractYouTubeVideoID(entry.Value), title, requestedBy, requesterID, duration, clip)
+}
+
+// directURLMetadata looks up the title and length of a non-YouTube URL, keeping the known
+// title when there is one. Streams yt-dlp can't read are queued as "Direct URL" of
+// unknown length.
+func directURLMetadata(url, knownTitle string) (string, time.Duration) {
+	title, duration, err := common.GetYouTubeMetadata(url)
+	if err != nil || knownTitle != "" {
+		title = knownTitle
+	}
+	if title == "" {
+		title = "Direct URL"
+	}
+	return title, duration
+}
+
+// replyImportSummary reports the import result and every failed line in one embed
+func replyImportSummary(ctx *Context, filename string, added, total int, failures []string) {
+	description := ctx.T("commands.queue.import.summary", added, total, filename)
+
+	if len(failures) > 0 {
+		shown := failures
+		if len(shown) > maxImportFailuresShown {
+			shown = shown[:maxImportFailuresShown]
+		}
+		description += "\n\n" + ctx.T("commands.queue.import.failures") + "\n• " + strings.Join(shown, "\n• ")
+		if hidden := len(failures) - len(shown); hidden > 0 {
+			description += "\n" + ctx.T("commands.queue.import.more_failures", hidden)
+		}
+	}
+
+	switch {
+	case added == 0:
+		replyEmbedMessage(ctx, ctx.T("commands.queue.import.failed.title"), description, 0xff0000)
+	case len(failures) > 0:
+		replyEmbedMessage(ctx, ctx.T("commands.queue.import.partial.title"), description, 0xffa500)
+	default:
+		replyEmbedMessage(ctx, ctx.T("commands.queue.import.done.title"), description, 0x00ff00)
+	}
+}
+
+// downloadAttachment fetches an attachment body, refusing anything over the size cap
+func downloadAttachment(url string) ([]byte, error) {
+	resp, err := attachmentClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportFileSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", maxImportFileSize)
+	}
+	return data, nil
+}
+
+// truncateImportValue shortens long lines for the summary embed
+func truncateImportValue(value string) string {
+	const maxLength = 60
+	value = strings.ReplaceAll(value, "`", "'")
+	if len([]rune(value)) > maxLength {
+		return string([]rune(value)[:maxLength-1]) + "…"
+	}
+	return value
+}
@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/ratelimit"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// Global limiter consulted before every command runs
+var rateLimiter *ratelimit.Limiter
+
+// InitializeRateLimits sets up the command rate limiter with database-backed guild overrides
+func InitializeRateLimits(db *gorm.DB) {
+	rateLimiter = ratelimit.NewLimiter(db)
+}
+
+// getRateLimiter returns the rate limiter, falling back to one without guild overrides
+func getRateLimiter() *ratelimit.Limiter {
+	if rateLimiter == nil {
+		rateLimiter = ratelimit.NewLimiter(nil)
+	}
+	return rateLimiter
+}
+
+// RateLimitHits returns how many runs each command's rate limit has denied since startup
+func RateLimitHits() map[string]int64 {
+	return getRateLimiter().Hits()
+}
+
+// RateLimitBuckets returns every command and rate limited subcommand by bucket name,
+// with its default limit
+func RateLimitBuckets() map[string]ratelimit.Limit {
+	buckets := make(map[string]ratelimit.Limit)
+	for _, cmd := range registry {
+		buckets[cmd.Name] = cmd.RateLimit
+		for sub, limit := range cmd.SubcommandLimits {
+			buckets[cmd.Name+" "+sub] = limit
+		}
+	}
+	buckets[customResponseBucket] = customResponseLimit
+	return buckets
+}
+
+// withinRateLimit takes a run from the user's bucket for the command, telling them
+// how long to wait once they run out. The bot owner and guild admins are never limited.
+func withinRateLimit(ctx *Context) bool {
+	name, defaultLimit := ctx.Command.rateLimit(ctx.Args)
+	limiter := getRateLimiter()
+	if limiter.Effective(ctx.GuildID, name, defaultLimit).Unlimited() {
+		return true
+	}
+
+	engine := getPermissionEngine()
+	if engine.IsOwner(ctx.Author.ID) || (ctx.GuildID != "" && engine.IsGuildAdmin(ctx.Session, ctx.GuildID, ctx.Author.ID)) {
+		return true
+	}
+
+	result := limiter.Allow(ctx.GuildID, ctx.Author.ID, name, defaultLimit)
+	if result.Allowed {
+		return true
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger(ctx.Command.Name).Warn("Command rate limited", map[string]interface{}{
+		"user_id":     ctx.Author.ID,
+		"guild_id":    ctx.GuildID,
+		"channel_id":  ctx.ChannelID,
+		"bucket":      name,
+		"retry_after": result.RetryAfter.String(),
+		"notified":    result.Notify,
+	})
+
+	// Slash replies are private, so they can always say how long is left; prefix
+	// commands only say it once per cooldown to keep spam from doubling
+	if result.Notify || ctx.IsInteraction() {
+		ctx.ReplyEmbedEphemeral(embedBuilder.WithLanguage(ctx.Language).Cooldown(commandDisplayName(ctx, name), result.RetryAfter))
+	}
+	return false
+}
+
+// commandDisplayName renders a bucket as the user would type it
+func commandDisplayName(ctx *Context, name string) string {
+	if ctx.IsInteraction() {
+		return "/" + name
+	}
+	return GuildSettings(ctx.GuildID).Prefix + name
+}
+
+// RateLimitCommand handles the !ratelimit command to view and override command rate limits
+func RateLimitCommand(ctx *Context) {
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("ratelimit")
+	logger.Info("Rate limit command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	if ctx.GuildID == "" {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.ratelimit.server_only")))
+		return
+	}
+
+	prefix := GuildSettings(ctx.GuildID).Prefix
+	usage := ctx.T("commands.ratelimit.usage", prefix)
+
+	if len(ctx.Args) == 0 || strings.ToLower(ctx.Args[0]) == "list" {
+		showRateLimits(ctx, prefix)
+		return
+	}
+
+	switch strings.ToLower(ctx.Args[0]) {
+	case "set":
+		if len(ctx.Args) < 3 {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+			return
+		}
+
+		name, ok := parseRateLimitBucket(ctx, prefix, ctx.Args[1:len(ctx.Args)-1])
+		if !ok {
+			return
+		}
+
+		limit, err := ratelimit.ParseLimit(ctx.Args[len(ctx.Args)-1])
+		if err != nil {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.ratelimit.invalid.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		if err := getRateLimiter().SetOverride(ctx.GuildID, name, limit, ctx.Author.ID); err != nil {
+			logger.Error("Failed to set rate limit", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"bucket":   name,
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.ratelimit.updated.title"),
+			ctx.T("commands.ratelimit.updated.description", prefix+name, limit.Localized(ctx.Language))))
+	case "reset":
+		if len(ctx.Args) < 2 {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+			return
+		}
+
+		name, ok := parseRateLimitBucket(ctx, prefix, ctx.Args[1:])
+		if !ok {
+			return
+		}
+
+		removed, err := getRateLimiter().RemoveOverride(ctx.GuildID, name, ctx.Author.ID)
+		if err != nil {
+			logger.Error("Failed to reset rate limit", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"bucket":   name,
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+		if !removed {
+			ctx.ReplyEmbed(embedBuilder.Warning(ctx.T("commands.ratelimit.not_overridden.title"), ctx.T("commands.ratelimit.not_overridden.description", prefix+name)))
+			return
+		}
+
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.ratelimit.reset.title"),
+			ctx.T("commands.ratelimit.reset.description", prefix+name, RateLimitBuckets()[name].Localized(ctx.Language))))
+	default:
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+	}
+}
+
+// parseRateLimitBucket resolves the command words of !ratelimit to a bucket name,
+// replying with an error if there is no such command
+func parseRateLimitBucket(ctx *Context, prefix string, words []string) (string, bool) {
+	name := strings.ToLower(strings.TrimPrefix(strings.Join(words, " "), prefix))
+	if fields := strings.Fields(name); len(fields) > 0 {
+		// Aliases share their command's bucket
+		if cmd := Lookup(fields[0]); cmd != nil {
+			fields[0] = cmd.Name
+		}
+		name = strings.Join(fields, " ")
+	}
+
+	if _, exists := RateLimitBuckets()[name]; !exists {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.ratelimit.unknown.title"),
+			ctx.T("commands.ratelimit.unknown.description", prefix+name, prefix)))
+		return "", false
+	}
+	return name, true
+}
+
+// showRateLimits lists every limited command with the guild's overrides
+func showRateLimits(ctx *Context, prefix string) {
+	buckets := RateLimitBuckets()
+	overrides := getRateLimiter().Overrides(ctx.GuildID)
+
+	var names []string
+	for name, limit := range buckets {
+		if _, overridden := overrides[name]; overridden || !limit.Unlimited() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("• `%s%s` - %s", prefix, name, buckets[name].Localized(ctx.Language))
+		if override, overridden := overrides[name]; overridden {
+			lines[i] = ctx.T("commands.ratelimit.list.overridden", prefix+name, override.Localized(ctx.Language), buckets[name].Localized(ctx.Language))
+		}
+	}
+
+	embed := embedBuilder.Info(ctx.T("commands.ratelimit.list.title"), strings.Join(lines, "\n"))
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  ctx.T("help.tips.title"),
+		Value: ctx.T("commands.ratelimit.list.tips", prefix),
+	})
+	ctx.ReplyEmbed(embed)
+}
+
+// rateLimitAutocomplete suggests commands for /ratelimit
+func rateLimitAutocomplete(i *discordgo.InteractionCreate, value string) []*discordgo.ApplicationCommandOptionChoice {
+	var names []string
+	for name := range RateLimitBuckets() {
+		if strings.Contains(name, strings.ToLower(value)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, name := range names {
+		if len(choices) == 25 {
+			break
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: name})
+	}
+	return choices
+}
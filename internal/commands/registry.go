@@ -0,0 +1,351 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/internal/ratelimit"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// ArgType is the type of a command argument
+type ArgType int
+
+const (
+	// ArgString is a single word, or any text in a slash command
+	ArgString ArgType = iota
+	// ArgInteger is a whole number
+	ArgInteger
+	// ArgText takes the rest of the message; it must be the last argument
+	ArgText
+	// ArgAttachment is a file; prefix commands take it from the message's attachments
+	ArgAttachment
+)
+
+// Arg declares a command argument
+type Arg struct {
+	Name        string
+	Description string
+	Type        ArgType
+	Required    bool
+
+	// Choices limits the argument to fixed values in slash commands
+	Choices []string
+
+	// Autocomplete suggests values for the argument in slash commands
+	Autocomplete func(i *discordgo.InteractionCreate, value string) []*discordgo.ApplicationCommandOptionChoice
+}
+
+// Subcommand declares a subcommand offered as a slash subcommand
+type Subcommand struct {
+	Name        string
+	Description string
+	Args        []Arg
+}
+
+// Usage is a line of the help message
+type Usage struct {
+	Syntax      string
+	Description string
+}
+
+// Category groups commands in the help message
+type Category int
+
+const (
+	CategoryMusic Category = iota
+	CategoryPlayback
+	CategoryQueue
+	CategoryInformation
+	CategoryModeration
+	CategoryFun
+	CategoryUtility
+	CategoryAdmin
+)
+
+// categoryTitles are the catalog keys of the help field names, in the order they're shown
+var categoryTitles = []string{
+	CategoryMusic:       "help.category.music",
+	CategoryPlayback:    "help.category.playback",
+	CategoryQueue:       "help.category.queue",
+	CategoryInformation: "help.category.information",
+	CategoryModeration:  "help.category.moderation",
+	CategoryFun:         "help.category.fun",
+	CategoryUtility:     "help.category.utility",
+	CategoryAdmin:       "help.category.admin",
+}
+
+// Command is a registered command. The prefix and slash handlers, the help message
+// and the slash command definitions are all generated from these.
+type Command struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Category    Category
+
+	Args        []Arg
+	Subcommands []Subcommand
+
+	// Usage lines for the help message; generated from Args when empty
+	Usage []Usage
+
+	// Capability is required to run the command; CapabilityFor overrides it
+	// for commands whose subcommands need different capabilities
+	Capability    permissions.Capability
+	CapabilityFor func(args []string) permissions.Capability
+
+	// RateLimit limits how often one user may run the command; SubcommandLimits
+	// give costlier subcommands limits, and buckets, of their own. Guilds can
+	// override either with !ratelimit.
+	RateLimit        ratelimit.Limit
+	SubcommandLimits map[string]ratelimit.Limit
+
+	// Slash registers the command as a slash command; Ephemeral hides its
+	// interaction replies from everyone but the invoking user
+	Slash     bool
+	Ephemeral bool
+
+	Run func(ctx *Context)
+}
+
+// RequiredCapability returns the capability invoking the command with args requires
+func (c *Command) RequiredCapability(args []string) permissions.Capability {
+	if c.CapabilityFor != nil {
+		return c.CapabilityFor(args)
+	}
+	return c.Capability
+}
+
+// rateLimit returns the bucket invoking the command with args draws from and its default limit
+func (c *Command) rateLimit(args []string) (string, ratelimit.Limit) {
+	if len(args) > 0 {
+		sub := strings.ToLower(args[0])
+		if limit, exists := c.SubcommandLimits[sub]; exists {
+			return c.Name + " " + sub, limit
+		}
+	}
+	return c.Name, c.RateLimit
+}
+
+// subcommand returns the declared subcommand args start with, if any
+func (c *Command) subcommand(args []string) *Subcommand {
+	if len(args) == 0 {
+		return nil
+	}
+	for i := range c.Subcommands {
+		if strings.EqualFold(c.Subcommands[i].Name, args[0]) {
+			return &c.Subcommands[i]
+		}
+	}
+	return nil
+}
+
+var (
+	// Registered commands in help order, and every name and alias they answer to
+	registry       []*Command
+	registryLookup = make(map[string]*Command)
+)
+
+// Register adds a command to the registry
+func Register(cmd *Command) {
+	for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+		if _, exists := registryLookup[name]; exists {
+			panic(fmt.Sprintf("command %q is registered twice", name))
+		}
+		registryLookup[name] = cmd
+	}
+	registry = append(registry, cmd)
+}
+
+// Lookup finds a command by name or alias
+func Lookup(name string) *Command {
+	return registryLookup[strings.ToLower(name)]
+}
+
+// Commands returns every registered command in help order
+func Commands() []*Command {
+	return registry
+}
+
+// RequiredCapability returns the capability a command invocation requires
+func RequiredCapability(command string, args []string) permissions.Capability {
+	cmd := Lookup(command)
+	if cmd == nil {
+		return permissions.None
+	}
+	return cmd.RequiredCapability(args)
+}
+
+// DispatchMessage runs a prefix command, returning false if no command has that name
+func DispatchMessage(s *discordgo.Session, m *discordgo.MessageCreate, name string, args []string) bool {
+	cmd := Lookup(name)
+	if cmd == nil {
+		return false
+	}
+
+	runCommand(newMessageContext(s, m, cmd, args))
+	return true
+}
+
+// DispatchInteraction runs a slash command
+func DispatchInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cmd := Lookup(i.ApplicationCommandData().Name)
+	if cmd == nil || !cmd.Slash {
+		respondEphemeral(s, i, "❌ "+i18n.T(interactionLanguage(i), "commands.unknown", "/"))
+		return
+	}
+
+	// Denials answer the interaction themselves, so the checks run before deferring;
+	// otherwise a public "thinking" response would never resolve
+	ctx := newInteractionContext(s, i, cmd)
+	if !permitted(ctx) {
+		return
+	}
+	if err := ctx.deferResponse(); err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger(cmd.Name).Error("Failed to acknowledge interaction", err, map[string]interface{}{
+			"guild_id":   i.GuildID,
+			"channel_id": i.ChannelID,
+		})
+		return
+	}
+
+	ctx.Command.Run(ctx)
+}
+
+// runCommand runs a command once it is permitted
+func runCommand(ctx *Context) {
+	if !permitted(ctx) {
+		return
+	}
+	ctx.Command.Run(ctx)
+}
+
+// permitted reports whether the guild's channel restrictions, the permission policy
+// and the rate limits allow an invocation, replying with the reason when they don't
+func permitted(ctx *Context) bool {
+	return allowedInChannel(ctx) && authorize(ctx) && withinRateLimit(ctx)
+}
+
+// AutocompleteChoices returns the suggestions for the focused slash command option
+func AutocompleteChoices(i *discordgo.InteractionCreate) []*discordgo.ApplicationCommandOptionChoice {
+	data := i.ApplicationCommandData()
+	cmd := Lookup(data.Name)
+	if cmd == nil {
+		return nil
+	}
+
+	declared := cmd.Args
+	options := data.Options
+	if len(options) > 0 && options[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		sub := cmd.subcommand([]string{options[0].Name})
+		if sub == nil {
+			return nil
+		}
+		declared = sub.Args
+		options = options[0].Options
+	}
+
+	for _, option := range options {
+		if !option.Focused {
+			continue
+		}
+		for _, arg := range declared {
+			if arg.Name == option.Name && arg.Autocomplete != nil {
+				return arg.Autocomplete(i, option.StringValue())
+			}
+		}
+	}
+	return nil
+}
+
+// authorize checks an invocation against the permission policy, replying with a
+// denial if the user lacks the capability
+func authorize(ctx *Context) bool {
+	capability := ctx.Command.RequiredCapability(ctx.Args)
+	if getPermissionEngine().Check(ctx.Session, ctx.GuildID, ctx.Author.ID, capability) {
+		return true
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger(ctx.Command.Name).Warn("Command denied by permission policy", map[string]interface{}{
+		"user_id":     ctx.Author.ID,
+		"guild_id":    ctx.GuildID,
+		"channel_id":  ctx.ChannelID,
+		"capability":  string(capability),
+		"interaction": ctx.IsInteraction(),
+	})
+
+	ctx.ReplyEmbedEphemeral(embedBuilder.Error(ctx.T("commands.permission_denied.title"), permissionDeniedMessage(ctx.Language, capability)))
+	return false
+}
+
+// SlashCommands returns the slash command definitions of every registered slash command
+func SlashCommands() []*discordgo.ApplicationCommand {
+	var definitions []*discordgo.ApplicationCommand
+	for _, cmd := range registry {
+		if !cmd.Slash {
+			continue
+		}
+
+		definition := &discordgo.ApplicationCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Options:     slashOptions(cmd.Args),
+		}
+		for _, sub := range cmd.Subcommands {
+			definition.Options = append(definition.Options, &discordgo.ApplicationCommandOption{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        sub.Name,
+				Description: sub.Description,
+				Options:     slashOptions(sub.Args),
+			})
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions
+}
+
+// slashOptions converts declared arguments to slash command options
+func slashOptions(args []Arg) []*discordgo.ApplicationCommandOption {
+	var options []*discordgo.ApplicationCommandOption
+	for _, arg := range args {
+		option := &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         arg.Name,
+			Description:  arg.Description,
+			Required:     arg.Required,
+			Autocomplete: arg.Autocomplete != nil,
+		}
+		switch arg.Type {
+		case ArgInteger:
+			option.Type = discordgo.ApplicationCommandOptionInteger
+		case ArgAttachment:
+			option.Type = discordgo.ApplicationCommandOptionAttachment
+		}
+		for _, choice := range arg.Choices {
+			option.Choices = append(option.Choices, &discordgo.ApplicationCommandOptionChoice{Name: choice, Value: choice})
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// usageLines returns the help lines of a command, generating one from its
+// arguments when it declares none
+func (c *Command) usageLines() []Usage {
+	if len(c.Usage) > 0 {
+		return c.Usage
+	}
+
+	syntax := c.Name
+	for _, arg := range c.Args {
+		if arg.Required {
+			syntax += fmt.Sprintf(" <%s>", arg.Name)
+		} else {
+			syntax += fmt.Sprintf(" [%s]", arg.Name)
+		}
+	}
+	return []Usage{{Syntax: syntax, Description: c.Description}}
+}
@@ -1,25 +1,23 @@
 package commands
 
 import (
-	"github.com/bwmarrin/discordgo"
-	"github.com/latoulicious/HKTM/pkg/embed"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
-func ResumeCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func ResumeCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("resume")
 	logger.Info("Resume command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
+		"channel_id": ctx.ChannelID,
 	})
 
 	// Initialize centralized embed builder
-	embedBuilder := embed.GetGlobalAudioEmbedBuilder()
+	embedBuilder := embedsFor(ctx)
 
 	// Update activity for idle monitoring
 	updateActivity(guildID)
@@ -29,11 +27,11 @@ func ResumeCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No queue found for this guild.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.no_queue"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
@@ -42,33 +40,46 @@ func ResumeCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if pipeline == nil {
 		logger.Warn("No pipeline found for resume", map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No audio pipeline found.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.resume.no_pipeline"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
 	// Check if already playing
-	if pipeline.IsPlaying() {
-		logger.Info("Audio already playing, no need to resume", map[string]interface{}{
+	if !pipeline.IsPaused() {
+		logger.Info("Audio not paused, no need to resume", map[string]interface{}{
+			"guild_id":   guildID,
+			"user_id":    ctx.Author.ID,
+			"is_playing": pipeline.IsPlaying(),
+		})
+
+		infoEmbed := embedBuilder.Info(ctx.T("commands.resume.not_paused.title"), ctx.T("commands.resume.not_paused.description"))
+		ctx.ReplyEmbed(infoEmbed)
+		return
+	}
+
+	if err := pipeline.Resume(); err != nil {
+		logger.Error("Failed to resume playback", err, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
-		
-		infoEmbed := embedBuilder.Info("▶️ Already Playing", "Audio is already playing.")
-		s.ChannelMessageSendEmbed(m.ChannelID, infoEmbed)
+
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.resume.failed"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
-	// For now, resume is not implemented in the current audio pipeline
-	// This would require implementing pause/resume functionality in the AudioPipeline
-	logger.Warn("Resume functionality not yet implemented", map[string]interface{}{
-		"guild_id": guildID,
-		"user_id":  m.Author.ID,
+	logger.Info("Playback resumed", map[string]interface{}{
+		"guild_id":   guildID,
+		"user_id":    ctx.Author.ID,
+		"resumed_by": ctx.Author.Username,
 	})
-	
-	warningEmbed := embedBuilder.Warning("▶️ Resume Not Available", "Resume functionality is not yet implemented. Use `!play` to start new playback.")
-	s.ChannelMessageSendEmbed(m.ChannelID, warningEmbed)
+
+	successEmbed := embedBuilder.Success(ctx.T("commands.resume.resumed.title"), ctx.T("commands.resume.resumed.description"))
+	ctx.ReplyEmbed(successEmbed)
+
+	refreshNowPlayingPanel(ctx.Session, guildID)
 }
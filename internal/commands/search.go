@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/audio"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+const (
+	// searchPickerPrefix identifies search result select menu interactions
+	searchPickerPrefix = "search:"
+	// searchResultLimit is how many results the picker offers
+	searchResultLimit = 5
+	// searchPickerTimeout is how long a picker accepts a selection
+	searchPickerTimeout = 60 * time.Second
+	// searchCacheTTL is how long results are reused for the same query
+	searchCacheTTL = 5 * time.Minute
+	// searchTimeout bounds a single yt-dlp search
+	searchTimeout = 30 * time.Second
+)
+
+// searchPicker is an open search result menu waiting for a selection
+type searchPicker struct {
+	userID    string
+	guildID   string
+	channelID string
+	messageID string
+	query     string
+	results   []common.SearchResult
+	language  string // Language of the picker message, kept for when it expires
+	timer     *time.Timer
+}
+
+// cachedSearch holds results for a query until they expire
+type cachedSearch struct {
+	done    chan struct{} // Closed once the search has finished
+	results []common.SearchResult
+	err     error
+	expires time.Time // Zero while the search is still running
+}
+
+var (
+	searchPickers     = make(map[string]*searchPicker)
+	searchPickerMutex sync.Mutex
+
+	searchCache      = make(map[string]*cachedSearch)
+	searchCacheMutex sync.Mutex
+)
+
+// SearchCommand handles the !search command to pick from the top YouTube results
+func SearchCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	// Initialize centralized logging for this command
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("search")
+	logger.Info("Search command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"guild_id":   guildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	// Initialize centralized embed builder
+	embedBuilder := embedsFor(ctx)
+
+	if len(ctx.Args) == 0 {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), ctx.T("commands.search.usage", commandDisplayName(ctx, "search"))))
+		return
+	}
+
+	// Update activity for idle monitoring
+	updateActivity(guildID)
+
+	query := strings.Join(ctx.Args, " ")
+	ctx.Session.ChannelTyping(ctx.ChannelID)
+	notifyIfBusy(ctx, audio.ProcessExtraction, audio.PriorityMetadata)
+
+	searchCtx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+
+	results, err := cachedYouTubeSearch(searchCtx, query, searchResultLimit)
+	if err != nil {
+		logger.Error("YouTube search failed", err, map[string]interface{}{
+			"guild_id":     guildID,
+			"user_id":      ctx.Author.ID,
+			"search_query": query,
+		})
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.search.failed.title"), ctx.T("commands.search.failed.description")))
+		return
+	}
+
+	pickerID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	components := searchPickerComponents(ctx.Language, pickerID, results, false)
+	msg, err := ctx.ReplyEmbedComponents(searchResultsEmbed(ctx.Language, query, results, ctx.Author.Username), components)
+	if err != nil {
+		logger.Error("Failed to send search results", err, map[string]interface{}{
+			"guild_id":   guildID,
+			"channel_id": ctx.ChannelID,
+		})
+		return
+	}
+
+	picker := &searchPicker{
+		userID:    ctx.Author.ID,
+		guildID:   guildID,
+		channelID: ctx.ChannelID,
+		messageID: msg.ID,
+		query:     query,
+		results:   results,
+		language:  ctx.Language,
+	}
+	picker.timer = time.AfterFunc(searchPickerTimeout, func() {
+		expireSearchPicker(ctx.Session, pickerID)
+	})
+
+	searchPickerMutex.Lock()
+	searchPickers[pickerID] = picker
+	searchPickerMutex.Unlock()
+
+	logger.Info("Search results displayed", map[string]interface{}{
+		"guild_id":     guildID,
+		"user_id":      ctx.Author.ID,
+		"search_query": query,
+		"results":      len(results),
+	})
+}
+
+// cachedYouTubeSearch returns recent results for a query, only running yt-dlp on a miss.
+// Concurrent callers share one search, and the search keeps running in the background when
+// ctx ends first so a later call for the same query can still use its results.
+func cachedYouTubeSearch(ctx context.Context, query string, limit int) ([]common.SearchResult, error) {
+	key := fmt.Sprintf("%d:%s", limit, strings.ToLower(strings.TrimSpace(query)))
+
+	searchCacheMutex.Lock()
+	// Drop anything stale while we hold the lock so the cache can't grow unbounded
+	now := time.Now()
+	for cachedKey, entry := range searchCache {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(searchCache, cachedKey)
+		}
+	}
+
+	entry, exists := searchCache[key]
+	if !exists {
+		entry = &cachedSearch{done: make(chan struct{})}
+		searchCache[key] = entry
+		go runCachedSearch(key, entry, query, limit)
+	}
+	searchCacheMutex.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.results, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runCachedSearch runs a yt-dlp search for a cache entry, keeping only successful results
+func runCachedSearch(key string, entry *cachedSearch, query string, limit int) {
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+
+	results, err := common.SearchYouTube(ctx, query, limit)
+
+	searchCacheMutex.Lock()
+	entry.results = results
+	entry.err = err
+	if err != nil {
+		delete(searchCache, key)
+	} else {
+		entry.expires = time.Now().Add(searchCacheTTL)
+	}
+	searchCacheMutex.Unlock()
+
+	close(entry.done)
+}
+
+// searchResultsEmbed lists the results with their channel and duration
+func searchResultsEmbed(lang, query string, results []common.SearchResult, requestedBy string) *discordgo.MessageEmbed {
+	lines := make([]string, len(results))
+	for i, result := range results {
+		lines[i] = fmt.Sprintf("**%d.** [%s](%s)\n%s", i+1, result.Title, result.URL, searchResultDetails(lang, result))
+	}
+
+	description := strings.Join(lines, "\n\n") + "\n\n" +
+		i18n.T(lang, "commands.search.results.pick", int(searchPickerTimeout.Seconds()))
+	resultsEmbed := embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang).Info(i18n.T(lang, "commands.search.results.title", truncateLabel(query, 200)), description)
+	resultsEmbed.Footer = &discordgo.MessageEmbedFooter{Text: i18n.T(lang, "commands.search.results.footer", requestedBy)}
+	return resultsEmbed
+}
+
+// searchResultDetails renders a result's channel and duration
+func searchResultDetails(lang string, result common.SearchResult) string {
+	channel := result.Channel
+	if channel == "" {
+		channel = i18n.T(lang, "commands.search.unknown_channel")
+	}
+	duration := i18n.T(lang, "commands.search.live")
+	if result.Duration > 0 {
+		duration = formatDuration(result.Duration)
+	}
+	return fmt.Sprintf("%s • %s", channel, duration)
+}
+
+// searchPickerComponents builds the select menu offering each result
+func searchPickerComponents(lang, pickerID string, results []common.SearchResult, disabled bool) []discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, len(results))
+	for i, result := range results {
+		options[i] = discordgo.SelectMenuOption{
+			Label:       truncateLabel(fmt.Sprintf("%d. %s", i+1, result.Title), 100),
+			Value:       strconv.Itoa(i),
+			Description: truncateLabel(searchResultDetails(lang, result), 100),
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    searchPickerPrefix + pickerID,
+					Placeholder: i18n.T(lang, "commands.search.placeholder"),
+					Options:     options,
+					Disabled:    disabled,
+				},
+			},
+		},
+	}
+}
+
+// expireSearchPicker closes a picker that was never answered
+func expireSearchPicker(s *discordgo.Session, pickerID string) {
+	searchPickerMutex.Lock()
+	picker, exists := searchPickers[pickerID]
+	delete(searchPickers, pickerID)
+	searchPickerMutex.Unlock()
+
+	if !exists {
+		return
+	}
+
+	lang := picker.language
+	expiredEmbed := embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang).Info(i18n.T(lang, "commands.search.expired.title"),
+		i18n.T(lang, "commands.search.expired.description", picker.query, GuildSettings(picker.guildID).Prefix+"search"))
+	components := searchPickerComponents(lang, pickerID, picker.results, true)
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         picker.messageID,
+		Channel:    picker.channelID,
+		Embeds:     &[]*discordgo.MessageEmbed{expiredEmbed},
+		Components: &components,
+	})
+}
+
+// IsSearchPickerInteraction reports whether a component custom ID belongs to a search picker
+func IsSearchPickerInteraction(customID string) bool {
+	return strings.HasPrefix(customID, searchPickerPrefix)
+}
+
+// HandleSearchPicker queues the result chosen from a search picker
+func HandleSearchPicker(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("search")
+	lang := interactionLanguage(i)
+
+	if i.Member == nil {
+		respondEphemeral(s, i, i18n.T(lang, "commands.search.server_only"))
+		return
+	}
+	user := i.Member.User
+
+	if allowed, denial := AuthorizeInteraction(s, i, "search", nil); !allowed {
+		respondEphemeral(s, i, denial)
+		return
+	}
+
+	data := i.MessageComponentData()
+	pickerID := strings.TrimPrefix(data.CustomID, searchPickerPrefix)
+
+	searchPickerMutex.Lock()
+	picker, exists := searchPickers[pickerID]
+	if exists && picker.userID == user.ID {
+		// Claim the picker so a double click can't queue twice
+		delete(searchPickers, pickerID)
+		picker.timer.Stop()
+	}
+	searchPickerMutex.Unlock()
+
+	if !exists {
+		respondEphemeral(s, i, i18n.T(lang, "commands.search.gone", GuildSettings(i.GuildID).Prefix+"search"))
+		return
+	}
+	if picker.userID != user.ID {
+		respondEphemeral(s, i, i18n.T(lang, "commands.search.not_yours"))
+		return
+	}
+
+	index := -1
+	if len(data.Values) > 0 {
+		index, _ = strconv.Atoi(data.Values[0])
+	}
+	if index < 0 || index >= len(picker.results) {
+		respondEphemeral(s, i, i18n.T(lang, "commands.search.unavailable"))
+		return
+	}
+	result := picker.results[index]
+
+	// Close the picker, showing what was picked
+	components := searchPickerComponents(picker.language, pickerID, picker.results, true)
+	pickedEmbed := embed.GetGlobalAudioEmbedBuilder().WithLanguage(picker.language).Success(i18n.T(picker.language, "commands.search.picked"),
+		fmt.Sprintf("[%s](%s)\n%s", result.Title, result.URL, searchResultDetails(picker.language, result)))
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{pickedEmbed},
+			Components: components,
+		},
+	})
+
+	logger.Info("Search result picked", map[string]interface{}{
+		"guild_id":     picker.guildID,
+		"user_id":      user.ID,
+		"search_query": picker.query,
+		"video_id":     result.VideoID,
+		"position":     index + 1,
+	})
+
+	enqueueSearchResult(newComponentContext(s, i, Lookup("search")), result, logger)
+}
+
+// enqueueSearchResult adds a picked result to the queue using the metadata the search returned
+func enqueueSearchResult(ctx *Context, result common.SearchResult, logger logging.Logger) {
+	guildID := ctx.GuildID
+	updateActivity(guildID)
+
+	queue := getOrCreateQueue(guildID)
+	if err := queue.AddWithYouTubeData("", result.URL, result.VideoID, result.Title, ctx.Author.Username, ctx.Author.ID, result.Duration); err != nil {
+		replyEmbedMessage(ctx, ctx.T("commands.cant_add.title"), localizeError(ctx.Language, err), 0xff0000)
+		return
+	}
+
+	position := queue.PositionOf(ctx.Author.ID)
+	replyEmbedMessage(ctx, ctx.T("commands.song_added.title"), ctx.T("commands.song_added.description", result.Title, position), 0x00ff00)
+
+	queue.LogQueueOperation("song_added", map[string]interface{}{
+		"title":        result.Title,
+		"url":          result.URL,
+		"requested_by": ctx.Author.Username,
+		"user_id":      ctx.Author.ID,
+		"channel_id":   ctx.ChannelID,
+		"position":     position,
+		"source":       "search",
+	})
+
+	if queue.CanStartPlaying() {
+		logger.Debug("Starting playback from search pick", map[string]interface{}{
+			"guild_id": guildID,
+		})
+		startNextInQueue(ctx.Session, guildID, ctx.ChannelID, ctx.Author.ID, queue)
+	}
+}
+
+// truncateLabel shortens text to Discord's component label limits
+func truncateLabel(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -3,35 +3,34 @@ package commands
 import (
 	"fmt"
 
-	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
 // ServersCommand displays information about which servers the bot is joined to
-func ServersCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func ServersCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("servers")
 	logger.Info("Servers command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
 	})
-	guilds := s.State.Guilds
+	guilds := allGuilds(ctx.Session)
 
 	logger.Info("Displaying server list", map[string]interface{}{
-		"user_id":      m.Author.ID,
-		"guild_id":     m.GuildID,
+		"user_id":      ctx.Author.ID,
+		"guild_id":     ctx.GuildID,
 		"server_count": len(guilds),
 	})
 
 	if len(guilds) == 0 {
 		logger.Warn("No servers found in bot state", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "I'm not joined to any servers.")
+		ctx.ReplyEphemeral("I'm not joined to any servers.")
 		return
 	}
 
@@ -50,11 +49,11 @@ func ServersCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	}
 
 	response += "\n\n💡 **Tip**: Use `!leave <server_id>` to leave a server."
-	_, err := s.ChannelMessageSend(m.ChannelID, response)
+	err := ctx.ReplyEphemeral(response)
 	if err != nil {
 		logger.Error("Failed to send servers list", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
 	}
 }
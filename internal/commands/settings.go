@@ -0,0 +1,335 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/settings"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// settingsHistoryLimit is how many past changes `!settings history` shows
+const settingsHistoryLimit = 10
+
+// Global settings service consulted by the message handler and the commands
+var settingsService *settings.Service
+
+// InitializeSettings sets up the guild settings service with database storage
+func InitializeSettings(db *gorm.DB) {
+	settingsService = settings.NewService(db)
+}
+
+// getSettingsService returns the settings service, falling back to defaults-only settings
+func getSettingsService() *settings.Service {
+	if settingsService == nil {
+		settingsService = settings.NewService(nil)
+	}
+	return settingsService
+}
+
+// GuildSettings returns a guild's settings
+func GuildSettings(guildID string) settings.Settings {
+	return getSettingsService().Get(guildID)
+}
+
+// announceChannel returns where a guild's music announcements go, defaulting to the
+// channel the music was requested in
+func announceChannel(guildID, requestChannelID string) string {
+	if channelID := GuildSettings(guildID).AnnounceChannelID; channelID != "" {
+		return channelID
+	}
+	return requestChannelID
+}
+
+// allowedInChannel keeps music commands to the guild's music channels, telling the
+// user where to use them instead
+func allowedInChannel(ctx *Context) bool {
+	switch ctx.Command.Category {
+	case CategoryMusic, CategoryPlayback, CategoryQueue:
+	default:
+		return true
+	}
+
+	guildSettings := GuildSettings(ctx.GuildID)
+	if guildSettings.AllowsMusicIn(ctx.ChannelID) {
+		return true
+	}
+
+	ctx.ReplyEmbedEphemeral(embedBuilder.Warning(ctx.T("commands.wrong_channel.title"),
+		ctx.T("commands.wrong_channel.description", channelMentions(guildSettings.MusicChannelIDs))))
+	return false
+}
+
+// settingsGetterAdapter gives the timeout manager the guild settings it honours
+type settingsGetterAdapter struct{}
+
+func (settingsGetterAdapter) IdleTimeout(guildID string) time.Duration {
+	return GuildSettings(guildID).IdleTimeout
+}
+
+func (settingsGetterAdapter) AnnounceChannel(guildID string) string {
+	return GuildSettings(guildID).AnnounceChannelID
+}
+
+func (settingsGetterAdapter) Language(guildID string) string {
+	return GuildSettings(guildID).Language
+}
+
+// SettingsCommand handles the !settings command to show and change the guild's settings
+func SettingsCommand(ctx *Context) {
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("settings")
+	logger.Info("Settings command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	if ctx.GuildID == "" {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.settings.server_only")))
+		return
+	}
+
+	usage := ctx.T("commands.settings.usage", commandDisplayName(ctx, "settings"))
+
+	if len(ctx.Args) == 0 || strings.ToLower(ctx.Args[0]) == "show" {
+		ctx.ReplyEmbed(settingsEmbed(ctx, GuildSettings(ctx.GuildID)))
+		return
+	}
+
+	switch strings.ToLower(ctx.Args[0]) {
+	case "set":
+		if len(ctx.Args) < 3 {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage+"\n\n"+settingKeyList(ctx.Language)))
+			return
+		}
+
+		key, err := settings.ParseKey(ctx.Args[1])
+		if err != nil {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.settings.unknown.title"), localizeError(ctx.Language, err)+"\n\n"+settingKeyList(ctx.Language)))
+			return
+		}
+
+		value, err := resolveSettingValue(ctx.Session, ctx.GuildID, key, strings.Join(ctx.Args[2:], " "))
+		if err != nil {
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.settings.invalid.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		updated, err := getSettingsService().Set(ctx.GuildID, key, value, ctx.Author.ID)
+		if err != nil {
+			logger.Warn("Failed to change setting", map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"key":      string(key),
+				"error":    err.Error(),
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.settings.invalid.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		settingsChanged(updated)
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.settings.updated.title"),
+			ctx.T("commands.settings.updated.description", key, formatSetting(ctx.Language, updated, key))))
+	case "reset":
+		var key settings.Key
+		if len(ctx.Args) > 1 && strings.ToLower(ctx.Args[1]) != "all" {
+			parsed, err := settings.ParseKey(ctx.Args[1])
+			if err != nil {
+				ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.settings.unknown.title"), localizeError(ctx.Language, err)+"\n\n"+settingKeyList(ctx.Language)))
+				return
+			}
+			key = parsed
+		}
+
+		updated, err := getSettingsService().Reset(ctx.GuildID, key, ctx.Author.ID)
+		if err != nil {
+			logger.Error("Failed to reset settings", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"key":      string(key),
+			})
+			ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+			return
+		}
+
+		settingsChanged(updated)
+		if key == "" {
+			ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.settings.reset_all.title"), ctx.T("commands.settings.reset_all.description")))
+			return
+		}
+		ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.settings.reset.title"),
+			ctx.T("commands.settings.reset.description", key, formatSetting(ctx.Language, updated, key))))
+	case "history":
+		showSettingsHistory(ctx, logger)
+	default:
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.usage_error"), usage))
+	}
+}
+
+// settingsChanged applies changed settings to the guild's running queue
+func settingsChanged(updated settings.Settings) {
+	if queue := getQueue(updated.GuildID); queue != nil {
+		applyQueueSettings(queue, updated)
+	}
+}
+
+// applyQueueSettings configures a guild's queue from its settings
+func applyQueueSettings(queue *common.MusicQueue, guildSettings settings.Settings) {
+	queue.SetMaxLength(guildSettings.MaxQueueLength)
+	queue.SetMode(guildSettings.QueueMode)
+	queue.SetUserLimits(guildSettings.MaxUserSongs, guildSettings.MaxUserDuration)
+}
+
+// showSettingsHistory lists the guild's recent setting changes
+func showSettingsHistory(ctx *Context, logger logging.Logger) {
+	audits, err := getSettingsService().History(ctx.GuildID, settingsHistoryLimit)
+	if err != nil {
+		logger.Error("Failed to load settings history", err, map[string]interface{}{
+			"guild_id": ctx.GuildID,
+		})
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.error.title"), localizeError(ctx.Language, err)+"."))
+		return
+	}
+	if len(audits) == 0 {
+		ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.settings.history.title"), ctx.T("commands.settings.history.empty")))
+		return
+	}
+
+	var lines []string
+	for _, audit := range audits {
+		lines = append(lines, ctx.T("commands.settings.history.entry",
+			audit.CreatedAt.Unix(), audit.ChangedBy, audit.Key, orNone(audit.OldValue), orNone(audit.NewValue)))
+	}
+	ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.settings.history.title"), strings.Join(lines, "\n")))
+}
+
+// settingsEmbed shows every setting of a guild
+func settingsEmbed(ctx *Context, guildSettings settings.Settings) *discordgo.MessageEmbed {
+	embed := embedBuilder.Info(ctx.T("commands.settings.title"),
+		ctx.T("commands.settings.description", commandDisplayName(ctx, "settings set")))
+	for _, key := range settings.Keys {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("`%s`", key),
+			Value:  fmt.Sprintf("%s\n_%s_", formatSetting(ctx.Language, guildSettings, key), key.Description(ctx.Language)),
+			Inline: true,
+		})
+	}
+	return embed
+}
+
+// formatSetting renders a setting for display in the language
+func formatSetting(lang string, guildSettings settings.Settings, key settings.Key) string {
+	switch key {
+	case settings.Prefix:
+		return fmt.Sprintf("`%s`", guildSettings.Prefix)
+	case settings.AnnounceChannel:
+		if guildSettings.AnnounceChannelID == "" {
+			return i18n.T(lang, "commands.settings.value.request_channel")
+		}
+		return fmt.Sprintf("<#%s>", guildSettings.AnnounceChannelID)
+	case settings.Language:
+		return fmt.Sprintf("`%s`", guildSettings.Language)
+	case settings.IdleTimeout:
+		return formatDuration(guildSettings.IdleTimeout)
+	case settings.MaxQueueLength:
+		return formatItemLimit(lang, guildSettings.MaxQueueLength)
+	case settings.MusicChannels:
+		if len(guildSettings.MusicChannelIDs) == 0 {
+			return i18n.T(lang, "commands.settings.value.every_channel")
+		}
+		return channelMentions(guildSettings.MusicChannelIDs)
+	case settings.QueueMode:
+		return fmt.Sprintf("`%s`", guildSettings.QueueMode)
+	case settings.MaxUserSongs:
+		return formatItemLimit(lang, guildSettings.MaxUserSongs)
+	case settings.MaxUserDuration:
+		return formatDurationLimit(lang, guildSettings.MaxUserDuration)
+	case settings.VoteSkipThreshold:
+		if guildSettings.VoteSkipThreshold == 0 {
+			return i18n.T(lang, "commands.settings.value.threshold_default", getVoteSkipThreshold(""))
+		}
+		return i18n.T(lang, "commands.settings.value.threshold", guildSettings.VoteSkipThreshold)
+	default:
+		return guildSettings.Value(key)
+	}
+}
+
+// resolveSettingValue turns user input into the stored form of a setting, resolving
+// channel mentions and names to IDs of text channels in the guild
+func resolveSettingValue(s *discordgo.Session, guildID string, key settings.Key, input string) (string, error) {
+	switch key {
+	case settings.AnnounceChannel, settings.MusicChannels:
+	default:
+		return input, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "none", "off", "all", "any":
+		return "", nil
+	}
+
+	var ids []string
+	for _, word := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		channelID, err := resolveTextChannelID(s, guildID, word)
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, channelID)
+	}
+	if key == settings.AnnounceChannel && len(ids) != 1 {
+		return "", i18n.Errorf("commands.settings.error.single_channel")
+	}
+	return strings.Join(ids, ","), nil
+}
+
+// resolveTextChannelID resolves a channel mention, ID or name to a text channel in the guild
+func resolveTextChannelID(s *discordgo.Session, guildID, input string) (string, error) {
+	channelID := strings.TrimSuffix(strings.TrimPrefix(input, "<#"), ">")
+	name := strings.TrimPrefix(input, "#")
+
+	channels, err := s.GuildChannels(guildID)
+	if err != nil {
+		return "", i18n.Errorf("commands.settings.error.load_channels")
+	}
+	for _, channel := range channels {
+		if channel.ID != channelID && !strings.EqualFold(channel.Name, name) {
+			continue
+		}
+		if channel.Type != discordgo.ChannelTypeGuildText && channel.Type != discordgo.ChannelTypeGuildNews {
+			return "", i18n.Errorf("commands.settings.error.not_text_channel", channel.ID)
+		}
+		return channel.ID, nil
+	}
+	return "", i18n.Errorf("commands.settings.error.no_channel", input)
+}
+
+// settingKeyList renders the available settings with descriptions in the language
+func settingKeyList(lang string) string {
+	var lines []string
+	for _, key := range settings.Keys {
+		lines = append(lines, fmt.Sprintf("• `%s` - %s", key, key.Description(lang)))
+	}
+	return i18n.T(lang, "commands.settings.available") + "\n" + strings.Join(lines, "\n")
+}
+
+// channelMentions renders channel IDs as mentions
+func channelMentions(channelIDs []string) string {
+	mentions := make([]string, len(channelIDs))
+	for i, channelID := range channelIDs {
+		mentions[i] = fmt.Sprintf("<#%s>", channelID)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// orNone shows empty audit values as "none"
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
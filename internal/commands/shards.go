@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/shard"
+)
+
+// Global shard manager for routing guilds to their gateway session
+var shardManager *shard.Manager
+
+// SetShardManager sets the global shard manager
+func SetShardManager(manager *shard.Manager) {
+	shardManager = manager
+}
+
+// sessionForGuild returns the session of the shard a guild belongs to. Voice joins and
+// state lookups only work on that shard, so work that may target another guild than
+// the event's (owner commands, timers) routes through here. Falls back to s when the
+// guild's shard isn't run by this process.
+func sessionForGuild(s *discordgo.Session, guildID string) *discordgo.Session {
+	if shardManager == nil {
+		return s
+	}
+	if session := shardManager.SessionForGuild(guildID); session != nil {
+		return session
+	}
+	return s
+}
+
+// allGuilds returns the guilds of every shard this process runs
+func allGuilds(s *discordgo.Session) []*discordgo.Guild {
+	if shardManager == nil {
+		return s.State.Guilds
+	}
+	return shardManager.Guilds()
+}
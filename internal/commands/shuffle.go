@@ -12,18 +12,18 @@ import (
 )
 
 // ShuffleCommand handles the !shuffle command to shuffle the queue
-func ShuffleCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	guildID := m.GuildID
+func ShuffleCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("shuffle")
 	logger.Info("Shuffle command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
-		"args_count": len(args),
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
 
 	// Update activity
@@ -34,9 +34,9 @@ func ShuffleCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []str
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "❌ Error", "No queue found for this server.", 0xff0000)
+		replyEmbedMessage(ctx, "❌ Error", "No queue found for this server.", 0xff0000)
 		return
 	}
 
@@ -45,10 +45,10 @@ func ShuffleCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []str
 	if queueSize < 2 {
 		logger.Info("Shuffle command called with insufficient songs", map[string]interface{}{
 			"guild_id":   guildID,
-			"user_id":    m.Author.ID,
+			"user_id":    ctx.Author.ID,
 			"queue_size": queueSize,
 		})
-		sendEmbedMessage(s, m.ChannelID, "📭 Not Enough Songs", "Need at least 2 songs to shuffle the queue.", 0x808080)
+		replyEmbedMessage(ctx, "📭 Not Enough Songs", "Need at least 2 songs to shuffle the queue.", 0x808080)
 		return
 	}
 
@@ -57,26 +57,23 @@ func ShuffleCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []str
 	if len(items) == 0 {
 		logger.Info("Shuffle command called on empty queue", map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
-		sendEmbedMessage(s, m.ChannelID, "📭 Queue Empty", "No songs in queue to shuffle.", 0x808080)
+		replyEmbedMessage(ctx, "📭 Queue Empty", "No songs in queue to shuffle.", 0x808080)
 		return
 	}
 
 	logger.Info("Shuffling queue", map[string]interface{}{
 		"guild_id":   guildID,
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"queue_size": queueSize,
 	})
 
 	// Shuffle the queue
 	shuffledItems := shuffleQueueItems(items)
 
-	// Clear the current queue and add shuffled items back
-	queue.Clear()
-	for _, item := range shuffledItems {
-		queue.Add(item.URL, item.Title, item.RequestedBy)
-	}
+	// Replace the pending items with the shuffled order
+	queue.ReplaceItems(shuffledItems)
 
 	// Create embed for shuffle confirmation
 	embed := &discordgo.MessageEmbed{
@@ -95,35 +92,36 @@ func ShuffleCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []str
 			},
 			{
 				Name:   "Shuffled By",
-				Value:  m.Author.Username,
+				Value:  ctx.Author.Username,
 				Inline: true,
 			},
 		},
 	}
 
 	// Add new top song announcement if requested or if it's a large queue
-	announceTop := len(args) > 0 && strings.ToLower(args[0]) == "announce"
+	announceTop := len(ctx.Args) > 0 && strings.ToLower(ctx.Args[0]) == "announce"
 	if announceTop || queueSize > 5 {
-		if len(shuffledItems) > 0 {
-			topSong := shuffledItems[0]
+		// Use the effective play order so fair mode reports the real next song
+		if upcoming := queue.List(); len(upcoming) > 0 {
+			topSong := upcoming[0]
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 				Name:   "🎵 New Top Song",
-				Value:  fmt.Sprintf("**%s**\nRequested by: %s", topSong.Title, topSong.RequestedBy),
+				Value:  ctx.T("audio.requested_by", topSong.Title, topSong.RequestedBy),
 				Inline: false,
 			})
 		}
 	}
 
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	err := ctx.ReplyEmbed(embed)
 	if err != nil {
 		logger.Error("Failed to send shuffle embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
+			"channel_id": ctx.ChannelID,
 			"guild_id":   guildID,
 		})
 	} else {
 		logger.Info("Queue shuffle completed successfully", map[string]interface{}{
 			"guild_id":     guildID,
-			"user_id":      m.Author.ID,
+			"user_id":      ctx.Author.ID,
 			"queue_size":   queueSize,
 			"announce_top": announceTop,
 		})
@@ -1,25 +1,38 @@
 package commands
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/internal/settings"
+	"github.com/latoulicious/HKTM/pkg/common"
 	"github.com/latoulicious/HKTM/pkg/embed"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
-func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
-	guildID := m.GuildID
+func SkipCommand(ctx *Context) {
+	guildID := ctx.GuildID
 
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("skip")
 	logger.Info("Skip command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
 
 	// Initialize centralized embed builder
-	embedBuilder := embed.GetGlobalAudioEmbedBuilder()
+	embedBuilder := embedsFor(ctx)
+
+	// Handle vote-skip configuration
+	if len(ctx.Args) > 0 && strings.ToLower(ctx.Args[0]) == "threshold" {
+		setVoteSkipThresholdCommand(ctx, ctx.Args[1:], embedBuilder, logger)
+		return
+	}
 
 	// Update activity for idle monitoring
 	updateActivity(guildID)
@@ -29,26 +42,62 @@ func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No queue found for this guild.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.no_queue"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
 	if !queue.IsPlaying() {
 		logger.Info("Nothing currently playing to skip", map[string]interface{}{
 			"guild_id":   guildID,
-			"user_id":    m.Author.ID,
+			"user_id":    ctx.Author.ID,
 			"queue_size": queue.Size(),
 		})
 		
-		infoEmbed := embedBuilder.Info("⏭️ Nothing to Skip", "Nothing is currently playing.")
-		s.ChannelMessageSendEmbed(m.ChannelID, infoEmbed)
+		infoEmbed := embedBuilder.Info(ctx.T("commands.skip.nothing.title"), ctx.T("commands.nothing_playing"))
+		ctx.ReplyEmbed(infoEmbed)
 		return
 	}
 
+	// The requester can always skip their own song and DJs skip instantly; everyone else votes
+	currentSong := queue.Current()
+	isRequester := currentSong.IsRequestedBy(ctx.Author.ID)
+	if !isRequester && !hasCapabilityGrant(ctx.Session, guildID, ctx.Author.ID, permissions.PlaybackControl) {
+		result, err := castSkipVote(ctx.Session, guildID, ctx.Author.ID, currentSong)
+		if err != nil {
+			logger.Warn("Skip vote rejected", map[string]interface{}{
+				"guild_id": guildID,
+				"user_id":  ctx.Author.ID,
+				"reason":   err.Error(),
+			})
+
+			errorEmbed := embedBuilder.Error(ctx.T("commands.skip.cannot_vote.title"), localizeError(ctx.Language, err))
+			ctx.ReplyEmbed(errorEmbed)
+			return
+		}
+
+		if !result.passed {
+			logger.Info("Skip vote recorded", map[string]interface{}{
+				"guild_id": guildID,
+				"user_id":  ctx.Author.ID,
+				"votes":    result.votes,
+				"required": result.required,
+			})
+			sendVoteSkipProgress(ctx, currentSong, result, logger)
+			return
+		}
+	}
+
+	performSkip(ctx, queue, ctx.Author.Username, embedBuilder, logger)
+}
+
+// performSkip stops the current track, announces the skip and starts the next song
+func performSkip(ctx *Context, queue *common.MusicQueue, skippedBy string, embedBuilder embed.AudioEmbedBuilder, logger logging.Logger) {
+	guildID := ctx.GuildID
+
 	// Get current song info before stopping
 	currentSong := queue.Current()
 	var songTitle, requestedBy string
@@ -59,13 +108,16 @@ func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 
 	logger.Info("Skipping current song", map[string]interface{}{
 		"guild_id":     guildID,
-		"user_id":      m.Author.ID,
-		"skipped_by":   m.Author.Username,
+		"user_id":      ctx.Author.ID,
+		"skipped_by":   skippedBy,
 		"song_title":   songTitle,
 		"requested_by": requestedBy,
 		"queue_size":   queue.Size(),
 	})
 
+	// Votes only apply to the track being skipped
+	resetSkipVotes(guildID)
+
 	// Stop current pipeline and mark as skipped
 	if pipeline := queue.GetPipeline(); pipeline != nil {
 		// Set a flag to indicate this was a skip operation
@@ -74,7 +126,7 @@ func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 		if err := pipeline.Stop(); err != nil {
 			logger.Error("Error stopping pipeline during skip", err, map[string]interface{}{
 				"guild_id":   guildID,
-				"user_id":    m.Author.ID,
+				"user_id":    ctx.Author.ID,
 				"song_title": songTitle,
 			})
 		}
@@ -83,15 +135,15 @@ func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 	// Send skip embed using centralized embed system
 	var skipEmbed *discordgo.MessageEmbed
 	if currentSong != nil {
-		skipEmbed = embedBuilder.SongSkipped(songTitle, requestedBy, m.Author.Username)
+		skipEmbed = embedBuilder.SongSkipped(songTitle, requestedBy, skippedBy)
 	} else {
-		skipEmbed = embedBuilder.Warning("⏭️ Song Skipped", "Current song has been skipped.")
+		skipEmbed = embedBuilder.Warning(ctx.T("audio.song_skipped.title"), ctx.T("commands.skip.skipped.description"))
 	}
 	
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, skipEmbed)
+	err := ctx.ReplyEmbed(skipEmbed)
 	if err != nil {
 		logger.Error("Failed to send skip embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
+			"channel_id": ctx.ChannelID,
 			"guild_id":   guildID,
 		})
 	}
@@ -101,5 +153,35 @@ func SkipCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 		"guild_id":         guildID,
 		"remaining_queue":  queue.Size(),
 	})
-	startNextInQueue(s, m, queue)
+	startNextInQueue(ctx.Session, ctx.GuildID, ctx.ChannelID, ctx.Author.ID, queue)
+}
+
+// setVoteSkipThresholdCommand shows or changes the vote-skip threshold for the guild
+func setVoteSkipThresholdCommand(ctx *Context, args []string, embedBuilder embed.AudioEmbedBuilder, logger logging.Logger) {
+	if len(args) == 0 {
+		description := ctx.T("commands.skip.threshold.description", getVoteSkipThreshold(ctx.GuildID), commandDisplayName(ctx, "skip threshold"))
+		ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.skip.threshold.title"), description))
+		return
+	}
+
+	percent, err := parsePercent(args[0])
+	if err != nil || percent < 1 || percent > 100 {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.skip.threshold.invalid.title"), ctx.T("commands.skip.threshold.invalid.description")))
+		return
+	}
+
+	updated, err := getSettingsService().Set(ctx.GuildID, settings.VoteSkipThreshold, strconv.Itoa(percent), ctx.Author.ID)
+	if err != nil {
+		ctx.ReplyEmbed(embedBuilder.Error(ctx.T("commands.skip.threshold.not_saved.title"), localizeError(ctx.Language, err)))
+		return
+	}
+	settingsChanged(updated)
+
+	logger.Info("Vote skip threshold changed", map[string]interface{}{
+		"guild_id":  ctx.GuildID,
+		"user_id":   ctx.Author.ID,
+		"threshold": percent,
+	})
+
+	ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.skip.threshold.updated.title"), ctx.T("commands.skip.threshold.updated.description", percent)))
 }
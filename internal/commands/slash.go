@@ -6,96 +6,9 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
-// RegisterSlashCommands registers all slash commands globally
+// RegisterSlashCommands registers every slash command of the command registry globally
 func RegisterSlashCommands(s *discordgo.Session) error {
-	commands := []*discordgo.ApplicationCommand{
-		{
-			Name:        "play",
-			Description: "Add a song to the queue and play it",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "url",
-					Description: "YouTube URL to play",
-					Required:    true,
-				},
-			},
-		},
-		{
-			Name:        "queue",
-			Description: "Manage the music queue",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Name:        "add",
-					Description: "Add a song to the queue",
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Type:        discordgo.ApplicationCommandOptionString,
-							Name:        "url",
-							Description: "YouTube URL to add",
-							Required:    true,
-						},
-					},
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Name:        "list",
-					Description: "Show the current queue",
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Name:        "remove",
-					Description: "Remove a song from the queue",
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Type:        discordgo.ApplicationCommandOptionInteger,
-							Name:        "index",
-							Description: "Position of the song to remove (1-based)",
-							Required:    true,
-						},
-					},
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Name:        "clear",
-					Description: "Clear the entire queue",
-				},
-			},
-		},
-		{
-			Name:        "skip",
-			Description: "Skip the current song",
-		},
-		{
-			Name:        "stop",
-			Description: "Stop playback and clear the queue",
-		},
-		{
-			Name:        "pause",
-			Description: "Pause the current playback",
-		},
-		{
-			Name:        "resume",
-			Description: "Resume paused playback",
-		},
-		{
-			Name:        "help",
-			Description: "Show help information",
-		},
-		{
-			Name:        "servers",
-			Description: "Show server information (Bot Owner Only)",
-		},
-		{
-			Name:        "nowplaying",
-			Description: "Show what's currently playing",
-		},
-		{
-			Name:        "version",
-			Description: "Show bot version information",
-		},
-	}
+	commands := SlashCommands()
 
 	log.Println("Registering global slash commands...")
 
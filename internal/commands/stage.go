@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+var (
+	// Stage channel per guild that was already told the bot is waiting to speak
+	stageSpeakNotices = make(map[string]string)
+	stageNoticeMutex  sync.Mutex
+)
+
+// updateStageForTrack shows the track as the stage topic, or tells the text channel the bot
+// still has to be invited to speak. Regular voice channels are left alone.
+func updateStageForTrack(s *discordgo.Session, textChannelID, guildID string, item *common.QueueItem) {
+	stageChannelID := common.GetBotVoiceChannelID(s, guildID)
+	if item == nil || stageChannelID == "" || !common.IsStageChannel(s, stageChannelID) {
+		return
+	}
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("stage")
+
+	switch common.StageSpeakerFor(s, stageChannelID) {
+	case common.StageModerator:
+		if err := common.SetStageTopic(s, stageChannelID, "🎵 "+item.Title); err != nil {
+			logger.Warn("Failed to update stage topic", map[string]interface{}{
+				"guild_id":   guildID,
+				"channel_id": stageChannelID,
+				"error":      err.Error(),
+			})
+		}
+	case common.StageRequestToSpeak:
+		if !isBotSuppressed(s, guildID) {
+			// Invited up; a later request in this stage deserves a new notice
+			clearStageNotice(guildID)
+			return
+		}
+
+		stageNoticeMutex.Lock()
+		notified := stageSpeakNotices[guildID] == stageChannelID
+		stageSpeakNotices[guildID] = stageChannelID
+		stageNoticeMutex.Unlock()
+
+		if !notified {
+			lang := GuildSettings(guildID).Language
+			sendEmbedMessage(s, textChannelID, i18n.T(lang, "commands.stage.waiting.title"),
+				i18n.T(lang, "commands.stage.waiting.description"), 0xffa500)
+		}
+	}
+}
+
+// clearStageNotice forgets that a guild was told the bot is waiting to speak
+func clearStageNotice(guildID string) {
+	stageNoticeMutex.Lock()
+	defer stageNoticeMutex.Unlock()
+	delete(stageSpeakNotices, guildID)
+}
+
+// isBotSuppressed reports whether the bot is in the stage audience
+func isBotSuppressed(s *discordgo.Session, guildID string) bool {
+	if s.State.User == nil {
+		return false
+	}
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return false
+	}
+	for _, vs := range guild.VoiceStates {
+		if vs.UserID == s.State.User.ID {
+			return vs.Suppress
+		}
+	}
+	return false
+}
@@ -1,27 +1,25 @@
 package commands
 
 import (
-	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/pkg/common"
-	"github.com/latoulicious/HKTM/pkg/embed"
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
 // StopCommand stops the current audio playback and clears queue
-func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
-	guildID := m.GuildID
+func StopCommand(ctx *Context) {
+	guildID := ctx.GuildID
 	
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("stop")
 	logger.Info("Stop command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
+		"user_id":    ctx.Author.ID,
 		"guild_id":   guildID,
-		"channel_id": m.ChannelID,
+		"channel_id": ctx.ChannelID,
 	})
 
 	// Initialize centralized embed builder
-	embedBuilder := embed.GetGlobalAudioEmbedBuilder()
+	embedBuilder := embedsFor(ctx)
 	
 	// Update activity for idle monitoring
 	updateActivity(guildID)
@@ -31,11 +29,11 @@ func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 	if queue == nil {
 		logger.Error("No queue found for guild", nil, map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		errorEmbed := embedBuilder.Error("❌ Error", "No queue found for this guild.")
-		s.ChannelMessageSendEmbed(m.ChannelID, errorEmbed)
+		errorEmbed := embedBuilder.Error(ctx.T("commands.error.title"), ctx.T("commands.no_queue"))
+		ctx.ReplyEmbed(errorEmbed)
 		return
 	}
 
@@ -43,11 +41,11 @@ func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 	if !queue.IsPlaying() {
 		logger.Info("No audio currently playing", map[string]interface{}{
 			"guild_id": guildID,
-			"user_id":  m.Author.ID,
+			"user_id":  ctx.Author.ID,
 		})
 		
-		infoEmbed := embedBuilder.Info("⏹️ Nothing Playing", "No audio is currently playing.")
-		s.ChannelMessageSendEmbed(m.ChannelID, infoEmbed)
+		infoEmbed := embedBuilder.Info(ctx.T("commands.stop.nothing.title"), ctx.T("commands.no_audio"))
+		ctx.ReplyEmbed(infoEmbed)
 		return
 	}
 
@@ -67,7 +65,7 @@ func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 		if err := pipeline.Stop(); err != nil {
 			logger.Error("Error stopping pipeline", err, map[string]interface{}{
 				"guild_id": guildID,
-				"user_id":  m.Author.ID,
+				"user_id":  ctx.Author.ID,
 			})
 		}
 	}
@@ -79,26 +77,29 @@ func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 
 	logger.Info("Queue cleared and playback stopped", map[string]interface{}{
 		"guild_id":           guildID,
-		"user_id":            m.Author.ID,
+		"user_id":            ctx.Author.ID,
 		"queue_size_before":  queueSizeBefore,
 		"current_song":       currentSong,
-		"stopped_by":         m.Author.Username,
+		"stopped_by":         ctx.Author.Username,
 	})
 
+	// Retire the control panel
+	closeNowPlayingPanel(ctx.Session, guildID)
+
 	// Clear presence
 	if presenceManager != nil {
-		presenceManager.ClearMusicPresence()
+		presenceManager.ClearMusicPresence(guildID)
 		logger.Debug("Music presence cleared", map[string]interface{}{
 			"guild_id": guildID,
 		})
 	}
 
 	// Send stop embed using centralized embed system
-	stopEmbed := embedBuilder.PlaybackStopped(m.Author.Username)
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, stopEmbed)
+	stopEmbed := embedBuilder.PlaybackStopped(ctx.Author.Username)
+	err := ctx.ReplyEmbed(stopEmbed)
 	if err != nil {
 		logger.Error("Failed to send stop embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
+			"channel_id": ctx.ChannelID,
 			"guild_id":   guildID,
 		})
 	}
@@ -107,5 +108,5 @@ func StopCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string
 	logger.Debug("Disconnecting from voice channel", map[string]interface{}{
 		"guild_id": guildID,
 	})
-	common.DisconnectFromVoiceChannel(s, guildID)
+	common.DisconnectFromVoiceChannel(ctx.Session, guildID)
 }
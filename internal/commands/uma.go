@@ -1,14 +1,18 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/internal/config"
 	"github.com/latoulicious/HKTM/pkg/database/models"
 	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"github.com/latoulicious/HKTM/pkg/uma"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
@@ -18,6 +22,19 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// umaAutocompleteBudget keeps database suggestions inside Discord's 3 second autocomplete window
+	umaAutocompleteBudget = 2 * time.Second
+	// umaAutocompleteLimit is the most choices Discord shows
+	umaAutocompleteLimit = 25
+	// umaPickerPrefix identifies "did you mean" select menu interactions
+	umaPickerPrefix = "uma:"
+	// umaPickerTimeout is how long a picker accepts a selection
+	umaPickerTimeout = 60 * time.Second
+	// umaAliasFieldLimit is the most characters Discord shows in an embed field
+	umaAliasFieldLimit = 1024
+)
+
 var umaClient = handler.NewClient()
 var navigationManager = navigation.GetNavigationManager()
 var gametoraClient *handler.GametoraClient
@@ -25,6 +42,7 @@ var umaDB *gorm.DB
 var umaService *uma.Service
 var characterService uma.CharacterServiceInterface
 var supportCardService uma.SupportCardServiceInterface
+var aliasService uma.AliasServiceInterface
 
 // InitializeUmaCommands initializes the UMA commands with database for caching
 func InitializeUmaCommands(db *gorm.DB) {
@@ -33,11 +51,18 @@ func InitializeUmaCommands(db *gorm.DB) {
 	// Initialize repositories
 	characterRepo := repository.NewCharacterRepository(db)
 	supportCardRepo := repository.NewSupportCardRepository(db)
+	aliasRepo := repository.NewUmaAliasRepository(db)
 
 	// Initialize service layer with database caching
-	umaService = uma.NewService(characterRepo, supportCardRepo, umaClient, gametoraClient)
+	umaService = uma.NewService(characterRepo, supportCardRepo, aliasRepo, umaClient, gametoraClient)
 	characterService = service.NewCharacterService(umaService)
 	supportCardService = service.NewSupportCardService(umaService)
+	aliasService = service.NewAliasService(umaService)
+
+	// Make the bundled nicknames searchable
+	if err := aliasService.SeedAliases(); err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("uma").Error("Failed to seed Uma aliases", err, nil)
+	}
 }
 
 // InitializeGametoraClient initializes the global gametora client with configuration
@@ -48,116 +73,118 @@ func InitializeGametoraClient(cfg interface{}) {
 }
 
 // UmaCommand handles Uma Musume related commands
-func UmaCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+func UmaCommand(ctx *Context) {
+	args := ctx.Args
+
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("uma")
 	logger.Info("Uma command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
 		"args_count": len(args),
 	})
 	if len(args) == 0 {
 		logger.Warn("Uma command called without subcommand", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Please specify a subcommand.\n\n**Usage:** `!uma char <character name>`\n**Example:** `!uma char Oguri Cap`")
+		ctx.Reply("❌ " + ctx.T("uma.usage.subcommand", commandDisplayName(ctx, "uma")))
 		return
 	}
 
 	subcommand := strings.ToLower(args[0])
 
 	logger.Info("Uma subcommand called", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"guild_id":   m.GuildID,
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
 		"subcommand": subcommand,
 	})
 
 	switch subcommand {
 	case "char", "character":
-		CharacterCommand(s, m, args[1:], logger)
+		CharacterCommand(ctx, args[1:], logger)
 	case "support":
-		SupportCommand(s, m, args[1:], logger)
+		SupportCommand(ctx, args[1:], logger)
 	case "skills":
-		SkillsCommand(s, m, args[1:], logger)
+		SkillsCommand(ctx, args[1:], logger)
 	case "sync":
-		SyncCommand(s, m, args[1:], logger)
+		SyncCommand(ctx, args[1:], logger)
 	case "refresh":
-		StableRefreshCommand(s, m, args[1:], logger)
+		StableRefreshCommand(ctx, args[1:], logger)
 	case "cache":
-		CacheStatsCommand(s, m, args[1:], logger)
+		CacheStatsCommand(ctx, args[1:], logger)
+	case "alias":
+		AliasCommand(ctx, args[1:], logger)
 	default:
 		logger.Warn("Unknown uma subcommand", map[string]interface{}{
-			"user_id":    m.Author.ID,
-			"guild_id":   m.GuildID,
+			"user_id":    ctx.Author.ID,
+			"guild_id":   ctx.GuildID,
 			"subcommand": subcommand,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Unknown subcommand.\n\n**Available subcommands:**\n• `char <name>` - Search for a character\n• `support <name>` - Search for a support card (list view)\n• `skills <name>` - Get skills for a support card (Gametora API)\n• `sync` - Sync all data from API to database\n• `refresh` - Refresh the Gametora API build ID\n• `cache` - Show cache statistics\n\n**Examples:**\n• `!uma char Oguri Cap`\n• `!uma support daring tact`\n• `!uma skills daring tact`\n• `!uma sync`\n• `!uma refresh`\n• `!uma cache`")
+		ctx.Reply("❌ " + ctx.T("uma.usage.unknown", commandDisplayName(ctx, "uma")))
 	}
 }
 
 // CharacterCommand searches for and displays character information
-func CharacterCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func CharacterCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Character search command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"guild_id":   m.GuildID,
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
 		"args_count": len(args),
 	})
 
 	// Check if user provided a character name
 	if len(args) == 0 {
 		logger.Warn("Character command called without character name", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Please provide a character name to search for.\n\n**Usage:** `!uma char <character name>`\n**Example:** `!uma char Oguri Cap`")
+		ctx.Reply("❌ " + ctx.T("uma.usage.char", commandDisplayName(ctx, "uma")))
 		return
 	}
 
 	// Join the arguments to form the search query
 	query := strings.Join(args, " ")
+	lang := ctx.Language
 
 	logger.Info("Searching for character", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 		"query":    query,
 	})
 
 	// Send a loading message
-	loadingMsg, _ := s.ChannelMessageSend(m.ChannelID, "🔍 Searching for character...")
+	ctx.Progress(i18n.T(lang, "uma.searching_character"))
 
 	// Search for character using service layer with database caching
-	result, err := characterService.SearchCharacter(query)
+	result, err := characterService.SearchCharacter(ctx.GuildID, query)
 	if err != nil {
 		logger.Error("Character search failed", err, map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 			"query":    query,
 		})
-		s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("❌ Error searching for character: %v", err))
+		ctx.Reply(i18n.T(lang, "uma.search_error.character", err))
 		return
 	}
 
-	// Delete the loading message
-	s.ChannelMessageDelete(m.ChannelID, loadingMsg.ID)
-
 	if !result.Found {
 		// Create error embed
 		embed := &discordgo.MessageEmbed{
-			Title:       "❌ Character Not Found",
-			Description: fmt.Sprintf("Could not find character: **%s**", query),
+			Title:       i18n.T(lang, "uma.character_not_found.title"),
+			Description: i18n.T(lang, "uma.character_not_found.description", query),
 			Color:       0xff0000, // Red color
 			Timestamp:   time.Now().Format(time.RFC3339),
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "Hokko Tarumae | Uma Musume Character Search",
+				Text: i18n.T(lang, "uma.footer.character_search"),
 			},
 			Fields: []*discordgo.MessageEmbedField{
 				{
-					Name:   "💡 Tips",
-					Value:  "• Try using the Japanese name\n• Check spelling and try alternative names\n• Try partial names (e.g., 'oguri' for 'Oguri Cap')",
+					Name:   i18n.T(lang, "uma.tips"),
+					Value:  i18n.T(lang, "uma.character_not_found.tips"),
 					Inline: false,
 				},
 			},
@@ -165,34 +192,45 @@ func CharacterCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []s
 
 		if result.Error != nil {
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   "🔧 Error",
+				Name:   i18n.T(lang, "uma.error"),
 				Value:  result.Error.Error(),
 				Inline: false,
 			})
 		}
 
-		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		ctx.ReplyEmbed(embed)
+		return
+	}
+
+	// Let the user pick when several characters match about equally well
+	if len(result.Candidates) > 1 {
+		showUmaPicker(ctx, &umaPicker{query: query, characters: result.Candidates}, logger)
 		return
 	}
 
 	// Fetch character images using service layer with database caching
 	imagesResult, err := characterService.GetCharacterImages(result.Character.ID)
 	if err != nil {
-		s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("❌ Error fetching character images: %v", err))
+		ctx.Reply(i18n.T(lang, "uma.search_error.images", err))
 		return
 	}
 
 	// Create success embed with image navigation
-	embed := navigationManager.CreateCharacterEmbed(result.Character, imagesResult, 0)
+	embed := navigationManager.CreateCharacterEmbed(result.Character, imagesResult, 0, lang)
 
 	// Send the initial embed
-	msg, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	msg, err := ctx.ReplyEmbedMessage(embed)
 	if err != nil {
-		s.ChannelMessageSend(m.ChannelID, "❌ Failed to send character information.")
+		ctx.Reply(i18n.T(lang, "uma.send_error.character"))
 		return
 	}
 
-	// Register navigation if there are multiple images
+	registerCharacterNavigation(ctx.Session, ctx.ChannelID, msg.ID, result.Character, imagesResult, lang)
+}
+
+// registerCharacterNavigation adds the image navigation reactions to a character message
+// when the character has more than one image
+func registerCharacterNavigation(s *discordgo.Session, channelID, messageID string, character *shared.Character, imagesResult *shared.CharacterImagesResult, lang string) {
 	totalImages := 0
 	if imagesResult.Found {
 		for _, category := range imagesResult.Images {
@@ -201,59 +239,57 @@ func CharacterCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []s
 	}
 
 	if totalImages > 1 {
-		navigationManager.RegisterNavigation(msg.ID, result.Character, imagesResult, m.ChannelID)
+		navigationManager.RegisterNavigation(messageID, character, imagesResult, channelID, lang)
 
 		// Add navigation emotes
 		reactions := []string{"⬅️", "➡️", "🔄"}
 		for _, reaction := range reactions {
-			s.MessageReactionAdd(m.ChannelID, msg.ID, reaction)
+			s.MessageReactionAdd(channelID, messageID, reaction)
 		}
 	}
 }
 
 // SupportCommand searches for and displays support card information
-func SupportCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func SupportCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Support card search command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"guild_id":   m.GuildID,
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
 		"args_count": len(args),
 	})
 	// Check if user provided a support card name
 	if len(args) == 0 {
-		s.ChannelMessageSend(m.ChannelID, "❌ Please provide a support card name to search for.\n\n**Usage:** `!uma support <support card name>`\n**Example:** `!uma support daring tact`")
+		ctx.Reply("❌ " + ctx.T("uma.usage.support", commandDisplayName(ctx, "uma")))
 		return
 	}
 
 	// Join the arguments to form the search query
 	query := strings.Join(args, " ")
+	lang := ctx.Language
 
 	// Send a loading message
-	loadingMsg, _ := s.ChannelMessageSend(m.ChannelID, "🔍 Searching for support card...")
+	ctx.Progress(i18n.T(lang, "uma.searching_support"))
 
 	// Search for support card using service layer with database caching
-	result, err := supportCardService.SearchSupportCard(query)
+	result, err := supportCardService.SearchSupportCard(ctx.GuildID, query)
 	if err != nil {
-		s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("❌ Error searching for support card: %v", err))
+		ctx.Reply(i18n.T(lang, "uma.search_error.support", err))
 		return
 	}
 
-	// Delete the loading message
-	s.ChannelMessageDelete(m.ChannelID, loadingMsg.ID)
-
 	if !result.Found {
 		// Create error embed
 		embed := &discordgo.MessageEmbed{
-			Title:       "❌ Support Card Not Found",
-			Description: fmt.Sprintf("Could not find support card: **%s**", query),
+			Title:       i18n.T(lang, "uma.support_not_found.title"),
+			Description: i18n.T(lang, "uma.support_not_found.description", query),
 			Color:       0xff0000, // Red color
 			Timestamp:   time.Now().Format(time.RFC3339),
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "Hokko Tarumae | Uma Musume Support Card Search",
+				Text: i18n.T(lang, "uma.footer.support_search"),
 			},
 			Fields: []*discordgo.MessageEmbedField{
 				{
-					Name:   "💡 Tips",
-					Value:  "• Try using the English title\n• Try using the Japanese title\n• Try using the gametora identifier\n• Check spelling and try alternative names",
+					Name:   i18n.T(lang, "uma.tips"),
+					Value:  i18n.T(lang, "uma.support_not_found.tips"),
 					Inline: false,
 				},
 			},
@@ -261,34 +297,44 @@ func SupportCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []str
 
 		if result.Error != nil {
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   "🔧 Error",
+				Name:   i18n.T(lang, "uma.error"),
 				Value:  result.Error.Error(),
 				Inline: false,
 			})
 		}
 
-		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		ctx.ReplyEmbed(embed)
 		return
 	}
 
-	// Create success embed
-	embed := createSupportCardEmbed(result.SupportCard)
-
-	// If there are multiple versions, add them to the embed
-	if len(result.SupportCards) > 1 {
-		embed = createMultiVersionSupportCardEmbed(result.SupportCards)
+	// Let the user pick when several cards match about equally well
+	if len(result.Candidates) > 1 {
+		showUmaPicker(ctx, &umaPicker{query: query, supportCards: result.Candidates}, logger)
+		return
 	}
 
 	// Send the embed
-	_, sendErr := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	sendErr := ctx.ReplyEmbed(supportResultEmbed(result, lang))
 	if sendErr != nil {
-		s.ChannelMessageSend(m.ChannelID, "❌ Failed to send support card information.")
+		ctx.Reply(i18n.T(lang, "uma.send_error.support"))
 		return
 	}
 }
 
-// createSupportCardEmbed creates an embed for a support card
-func createSupportCardEmbed(supportCard *shared.SupportCard) *discordgo.MessageEmbed {
+// supportResultEmbed shows a found support card, listing every version when there are several
+func supportResultEmbed(result *shared.SupportCardSearchResult, lang string) *discordgo.MessageEmbed {
+	// If there are multiple versions, add them to the embed
+	if len(result.SupportCards) > 1 {
+		return createMultiVersionSupportCardEmbed(result.SupportCards, lang)
+	}
+	if result.SupportCard != nil {
+		return createSupportCardEmbed(result.SupportCard, lang)
+	}
+	return createSupportCardEmbed(&result.SupportCards[0], lang)
+}
+
+// createSupportCardEmbed creates an embed for a support card in the given language
+func createSupportCardEmbed(supportCard *shared.SupportCard, lang string) *discordgo.MessageEmbed {
 	// Determine embed color based on rarity
 	var color int
 	switch supportCard.RarityString {
@@ -303,37 +349,38 @@ func createSupportCardEmbed(supportCard *shared.SupportCard) *discordgo.MessageE
 	}
 
 	// Create embed
+	title, subtitle := localizedTitles(supportCard.TitleEn, supportCard.Title, lang)
 	embed := &discordgo.MessageEmbed{
-		Title:       supportCard.TitleEn,
-		Description: supportCard.Title,
+		Title:       title,
+		Description: subtitle,
 		Color:       color,
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Data from umapyoi.net",
+			Text: i18n.T(lang, "uma.footer.umapyoi"),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "🎴 Rarity",
+				Name:   i18n.T(lang, "uma.rarity"),
 				Value:  supportCard.RarityString,
 				Inline: true,
 			},
 			{
-				Name:   "🎯 Type",
+				Name:   i18n.T(lang, "uma.type"),
 				Value:  supportCard.Type,
 				Inline: true,
 			},
 			{
-				Name:   "🆔 Card ID",
+				Name:   i18n.T(lang, "uma.card_id"),
 				Value:  fmt.Sprintf("%d", supportCard.ID),
 				Inline: true,
 			},
 			{
-				Name:   "👤 Character ID",
+				Name:   i18n.T(lang, "uma.card_character_id"),
 				Value:  fmt.Sprintf("%d", supportCard.CharaID),
 				Inline: true,
 			},
 			{
-				Name:   "🔗 Gametora",
+				Name:   i18n.T(lang, "uma.gametora"),
 				Value:  supportCard.Gametora,
 				Inline: true,
 			},
@@ -351,44 +398,42 @@ func createSupportCardEmbed(supportCard *shared.SupportCard) *discordgo.MessageE
 }
 
 // SkillsCommand retrieves skills for a support card using the Gametora API
-func SkillsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func SkillsCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Skills command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"guild_id":   m.GuildID,
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
 		"args_count": len(args),
 	})
 	// Check if user provided a support card name
 	if len(args) == 0 {
-		s.ChannelMessageSend(m.ChannelID, "❌ Please provide a support card name to get skills for.\n\n**Usage:** `!uma skills <support card name>`\n**Example:** `!uma skills daring tact`")
+		ctx.Reply("❌ " + ctx.T("uma.usage.skills", commandDisplayName(ctx, "uma")))
 		return
 	}
 
 	// Join the arguments to form the search query
 	query := strings.Join(args, " ")
+	lang := ctx.Language
 
 	// Send a loading message
-	loadingMsg, _ := s.ChannelMessageSend(m.ChannelID, "🔍 Searching for support card skills using Gametora API...")
+	ctx.Progress(i18n.T(lang, "uma.searching_skills"))
 
 	// Search for support card using Gametora API
 	result := gametoraClient.SearchSimplifiedSupportCard(query)
 
-	// Delete the loading message
-	s.ChannelMessageDelete(m.ChannelID, loadingMsg.ID)
-
 	if !result.Found {
 		// Create error embed
 		embed := &discordgo.MessageEmbed{
-			Title:       "❌ Support Card Not Found",
-			Description: fmt.Sprintf("Could not find support card: **%s**", query),
+			Title:       i18n.T(lang, "uma.support_not_found.title"),
+			Description: i18n.T(lang, "uma.support_not_found.description", query),
 			Color:       0xff0000, // Red color
 			Timestamp:   time.Now().Format(time.RFC3339),
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "Hokko Tarumae | Uma Musume Support Card Skills (Gametora API)",
+				Text: i18n.T(lang, "uma.footer.skills_search"),
 			},
 			Fields: []*discordgo.MessageEmbedField{
 				{
-					Name:   "💡 Tips",
-					Value:  "• Try using the English title\n• Try using the Japanese title\n• Try using the gametora identifier\n• Check spelling and try alternative names\n• Try partial names",
+					Name:   i18n.T(lang, "uma.tips"),
+					Value:  i18n.T(lang, "uma.skills_not_found.tips"),
 					Inline: false,
 				},
 			},
@@ -396,13 +441,13 @@ func SkillsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 
 		if result.Error != nil {
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-				Name:   "🔧 Error",
+				Name:   i18n.T(lang, "uma.error"),
 				Value:  result.Error.Error(),
 				Inline: false,
 			})
 		}
 
-		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		ctx.ReplyEmbed(embed)
 		return
 	}
 
@@ -413,22 +458,22 @@ func SkillsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 	var embed *discordgo.MessageEmbed
 	if len(result.SupportCards) > 1 {
 		// Use navigation embed for multiple versions
-		embed = supportCardNavManager.CreateSupportCardEmbed(result.SupportCard, result.SupportCards, 0)
+		embed = supportCardNavManager.CreateSupportCardEmbed(result.SupportCard, result.SupportCards, 0, lang)
 	} else {
 		// Use simple embed for single version
-		embed = createSimplifiedSkillsEmbed(result.SupportCard)
+		embed = createSimplifiedSkillsEmbed(result.SupportCard, lang)
 	}
 
 	// Send the embed
-	msg, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	msg, err := ctx.ReplyEmbedMessage(embed)
 	if err != nil {
-		s.ChannelMessageSend(m.ChannelID, "❌ Failed to send support card skills.")
+		ctx.Reply(i18n.T(lang, "uma.send_error.skills"))
 		return
 	}
 
 	// Register navigation if there are multiple versions
 	if len(result.SupportCards) > 1 {
-		supportCardNavManager.RegisterSupportCardNavigation(msg.ID, result.SupportCards, m.ChannelID, query)
+		supportCardNavManager.RegisterSupportCardNavigation(msg.ID, result.SupportCards, ctx.ChannelID, query, lang)
 
 		// Add navigation emotes based on number of versions
 		reactions := []string{"🔄"} // Always add refresh
@@ -458,13 +503,13 @@ func SkillsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []stri
 		}
 
 		for _, reaction := range reactions {
-			s.MessageReactionAdd(m.ChannelID, msg.ID, reaction)
+			ctx.Session.MessageReactionAdd(ctx.ChannelID, msg.ID, reaction)
 		}
 	}
 }
 
 // createSimplifiedSkillsEmbed creates a simplified embed showing only skills for a support card
-func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *discordgo.MessageEmbed {
+func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard, lang string) *discordgo.MessageEmbed {
 	// Determine embed color based on rarity
 	var color int
 	switch supportCard.Rarity {
@@ -481,25 +526,25 @@ func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *dis
 	// Create embed
 	embed := &discordgo.MessageEmbed{
 		Title:       supportCard.NameJp,
-		Description: fmt.Sprintf("**Character:** %s", supportCard.CharName),
+		Description: i18n.T(lang, "uma.character", supportCard.CharName),
 		Color:       color,
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Data from Gametora API | Hokko Tarumae",
+			Text: i18n.T(lang, "uma.footer.gametora_bot"),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "🎴 Rarity",
+				Name:   i18n.T(lang, "uma.rarity"),
 				Value:  fmt.Sprintf("%d", supportCard.Rarity),
 				Inline: true,
 			},
 			{
-				Name:   "🎯 Type",
+				Name:   i18n.T(lang, "uma.type"),
 				Value:  supportCard.Type,
 				Inline: true,
 			},
 			{
-				Name:   "🆔 Support ID",
+				Name:   i18n.T(lang, "uma.support_id"),
 				Value:  fmt.Sprintf("%d", supportCard.SupportID),
 				Inline: true,
 			},
@@ -509,7 +554,7 @@ func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *dis
 	// Add obtained info if available
 	if supportCard.Obtained != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "📦 Obtained",
+			Name:   i18n.T(lang, "uma.obtained"),
 			Value:  supportCard.Obtained,
 			Inline: true,
 		})
@@ -536,7 +581,7 @@ func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *dis
 		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("💡 Support Hints (%d)", len(supportCard.Hints.HintSkills)),
+			Name:   i18n.T(lang, "uma.support_hints", len(supportCard.Hints.HintSkills)),
 			Value:  hintsText.String(),
 			Inline: false,
 		})
@@ -553,7 +598,7 @@ func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *dis
 		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("🎉 Event Skills (%d)", len(supportCard.EventSkills)),
+			Name:   i18n.T(lang, "uma.event_skills", len(supportCard.EventSkills)),
 			Value:  eventsText.String(),
 			Inline: false,
 		})
@@ -563,57 +608,56 @@ func createSimplifiedSkillsEmbed(supportCard *shared.SimplifiedSupportCard) *dis
 }
 
 // StableRefreshCommand refreshes the build ID for the Gametora API
-func StableRefreshCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func StableRefreshCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Stable refresh command executed", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
+	lang := ctx.Language
+
 	// Send a loading message
-	loadingMsg, _ := s.ChannelMessageSend(m.ChannelID, "🔄 Refreshing Gametora API build ID...")
+	ctx.Progress(i18n.T(lang, "uma.refreshing"))
 
 	// Refresh the build ID
 	buildID, err := gametoraClient.GetBuildID()
 
-	// Delete the loading message
-	s.ChannelMessageDelete(m.ChannelID, loadingMsg.ID)
-
 	if err != nil {
 		embed := &discordgo.MessageEmbed{
-			Title:       "❌ Build ID Refresh Failed",
-			Description: fmt.Sprintf("Failed to refresh build ID: **%v**", err),
+			Title:       i18n.T(lang, "uma.refresh_failed.title"),
+			Description: i18n.T(lang, "uma.refresh_failed.description", err),
 			Color:       0xff0000, // Red color
 			Timestamp:   time.Now().Format(time.RFC3339),
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "Hokko Tarumae | Gametora API Build ID Refresh",
+				Text: i18n.T(lang, "uma.footer.refresh"),
 			},
 		}
-		s.ChannelMessageSendEmbed(m.ChannelID, embed)
+		ctx.ReplyEmbed(embed)
 		return
 	}
 
 	// Success embed
 	embed := &discordgo.MessageEmbed{
-		Title:       "✅ Build ID Refreshed",
-		Description: fmt.Sprintf("Successfully refreshed the build ID for the Gametora API.\n\n**Build ID:** `%s`", buildID),
+		Title:       i18n.T(lang, "uma.refreshed.title"),
+		Description: i18n.T(lang, "uma.refreshed.description", buildID),
 		Color:       0x00ff00, // Green color
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Hokko Tarumae | Gametora API Build ID Refresh",
+			Text: i18n.T(lang, "uma.footer.refresh"),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "💡 Tip",
-				Value:  "The Gametora API should now work with the latest data. Try using `!uma stats <card name>` to test.",
+				Name:   i18n.T(lang, "uma.tip"),
+				Value:  i18n.T(lang, "uma.refreshed.tip", commandDisplayName(ctx, "uma")),
 				Inline: false,
 			},
 		},
 	}
 
-	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	ctx.ReplyEmbed(embed)
 }
 
 // createMultiVersionSupportCardEmbed creates an embed showing all versions of a support card
-func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard) *discordgo.MessageEmbed {
+func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard, lang string) *discordgo.MessageEmbed {
 	// Use the highest rarity card for the main embed info
 	mainCard := supportCards[0]
 
@@ -631,22 +675,23 @@ func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard) *disc
 	}
 
 	// Create embed
+	title, subtitle := localizedTitles(mainCard.TitleEn, mainCard.Title, lang)
 	embed := &discordgo.MessageEmbed{
-		Title:       mainCard.TitleEn,
-		Description: mainCard.Title,
+		Title:       title,
+		Description: subtitle,
 		Color:       color,
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Data from umapyoi.net",
+			Text: i18n.T(lang, "uma.footer.umapyoi"),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "🎯 Type",
+				Name:   i18n.T(lang, "uma.type"),
 				Value:  mainCard.Type,
 				Inline: true,
 			},
 			{
-				Name:   "👤 Character ID",
+				Name:   i18n.T(lang, "uma.card_character_id"),
 				Value:  fmt.Sprintf("%d", mainCard.CharaID),
 				Inline: true,
 			},
@@ -675,9 +720,9 @@ func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard) *disc
 
 		versionsText.WriteString(fmt.Sprintf("%s **%s**\n", rarityEmoji, card.RarityString))
 		versionsText.WriteString(fmt.Sprintf("• ID: %d\n", card.ID))
-		versionsText.WriteString(fmt.Sprintf("• Title: %s\n", card.TitleEn))
+		versionsText.WriteString(i18n.T(lang, "uma.version.title", card.TitleEn) + "\n")
 		if card.Title != card.TitleEn {
-			versionsText.WriteString(fmt.Sprintf("• JP: %s\n", card.Title))
+			versionsText.WriteString(i18n.T(lang, "uma.version.jp", card.Title) + "\n")
 		}
 		versionsText.WriteString(fmt.Sprintf("• Gametora: %s\n", card.Gametora))
 
@@ -687,7 +732,7 @@ func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard) *disc
 	}
 
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-		Name:   fmt.Sprintf("📋 All Versions (%d)", len(supportCards)),
+		Name:   i18n.T(lang, "uma.all_versions", len(supportCards)),
 		Value:  versionsText.String(),
 		Inline: false,
 	})
@@ -695,41 +740,52 @@ func createMultiVersionSupportCardEmbed(supportCards []shared.SupportCard) *disc
 	return embed
 }
 
+// localizedTitles orders a card's English and Japanese titles for the reader,
+// Japanese first for Japanese readers
+func localizedTitles(titleEn, titleJp, lang string) (string, string) {
+	if lang == i18n.Japanese && titleJp != "" {
+		return titleJp, titleEn
+	}
+	return titleEn, titleJp
+}
+
 // SyncCommand syncs all data from API to database
-func SyncCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func SyncCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Sync command executed", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
+	lang := ctx.Language
+
 	// Send initial message
-	msg, _ := s.ChannelMessageSend(m.ChannelID, "🔄 Starting data sync from API to database...\n\nThis may take a few minutes.")
+	ctx.Progress(i18n.T(lang, "uma.sync.starting"))
 
 	// Create sync service
 	syncService := service.NewSyncService(umaService)
 
 	// Sync characters first
-	s.ChannelMessageEdit(m.ChannelID, msg.ID, "🔄 Syncing characters from API...")
+	ctx.Progress(i18n.T(lang, "uma.sync.characters"))
 	if err := syncService.SyncAllCharacters(); err != nil {
-		s.ChannelMessageEdit(m.ChannelID, msg.ID, fmt.Sprintf("❌ Failed to sync characters: %v", err))
+		ctx.Reply(i18n.T(lang, "uma.sync.characters_failed", err))
 		return
 	}
 
 	// Sync support cards
-	s.ChannelMessageEdit(m.ChannelID, msg.ID, "🔄 Syncing support cards from API...")
+	ctx.Progress(i18n.T(lang, "uma.sync.support_cards"))
 	if err := syncService.SyncAllSupportCards(); err != nil {
-		s.ChannelMessageEdit(m.ChannelID, msg.ID, fmt.Sprintf("❌ Failed to sync support cards: %v", err))
+		ctx.Reply(i18n.T(lang, "uma.sync.support_cards_failed", err))
 		return
 	}
 
 	// Success message
-	s.ChannelMessageEdit(m.ChannelID, msg.ID, "✅ Data sync completed successfully!\n\nAll characters and support cards have been cached in the database.\n\nFuture searches will be much faster! 🚀")
+	ctx.Reply(i18n.T(lang, "uma.sync.done"))
 }
 
 // CacheStatsCommand shows cache statistics
-func CacheStatsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func CacheStatsCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Cache stats command executed", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
 	// Get character count
 	var characterCount int64
@@ -738,29 +794,545 @@ func CacheStatsCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []
 	// Get support card count
 	var supportCardCount int64
 	umaDB.Model(&models.SupportCard{}).Count(&supportCardCount)
+	lang := ctx.Language
 
 	// Create embed
 	embed := &discordgo.MessageEmbed{
-		Title:       "📊 Database Cache Statistics",
-		Description: "Current data cached in the database",
+		Title:       i18n.T(lang, "uma.cache.title"),
+		Description: i18n.T(lang, "uma.cache.description"),
 		Color:       0x00ff00, // Green
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "👥 Characters",
-				Value:  fmt.Sprintf("%d characters cached", characterCount),
+				Name:   i18n.T(lang, "uma.cache.characters"),
+				Value:  i18n.T(lang, "uma.cache.characters_value", characterCount),
 				Inline: true,
 			},
 			{
-				Name:   "🎴 Support Cards",
-				Value:  fmt.Sprintf("%d support cards cached", supportCardCount),
+				Name:   i18n.T(lang, "uma.cache.support_cards"),
+				Value:  i18n.T(lang, "uma.cache.support_cards_value", supportCardCount),
 				Inline: true,
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Use !uma sync to refresh all data",
+			Text: i18n.T(lang, "uma.cache.footer", commandDisplayName(ctx, "uma")),
 		},
 	}
 
-	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	ctx.ReplyEmbed(embed)
+}
+
+// AliasCommand lists, adds and removes the nicknames character and support searches understand
+func AliasCommand(ctx *Context, args []string, logger logging.Logger) {
+	logger.Info("Alias command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
+		"args_count": len(args),
+	})
+	lang := ctx.Language
+	prefix := GuildSettings(ctx.GuildID).Prefix
+
+	action := "list"
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+
+	// Slash commands keep multi-word nicknames in one option; prefix commands separate the
+	// nickname from the name with "=", or take the first word as the nickname
+	var kindArg, alias, name string
+	if ctx.IsInteraction() {
+		kindArg, alias, name = ctx.String("type"), ctx.String("alias"), ctx.String("name")
+	} else if len(args) > 1 {
+		kindArg = args[1]
+		rest := args[2:]
+		if before, after, found := strings.Cut(strings.Join(rest, " "), "="); found {
+			alias, name = strings.TrimSpace(before), strings.TrimSpace(after)
+		} else if action == "remove" {
+			alias = strings.Join(rest, " ")
+		} else if len(rest) > 0 {
+			alias, name = rest[0], strings.Join(rest[1:], " ")
+		}
+	}
+
+	var kinds []string
+	if kindArg != "" {
+		kind, ok := parseAliasKind(kindArg)
+		if !ok {
+			ctx.Reply(i18n.T(lang, "uma.alias.usage", prefix))
+			return
+		}
+		kinds = []string{kind}
+	}
+
+	switch action {
+	case "list":
+		if kinds == nil {
+			kinds = []string{service.AliasCharacter, service.AliasSupport}
+		}
+		showUmaAliases(ctx, kinds, logger)
+	case "add":
+		if ctx.GuildID == "" {
+			ctx.Reply(i18n.T(lang, "uma.alias.server_only"))
+			return
+		}
+		if kinds == nil || alias == "" || name == "" {
+			ctx.Reply(i18n.T(lang, "uma.alias.usage", prefix))
+			return
+		}
+		addUmaAlias(ctx, kinds[0], alias, name, logger)
+	case "remove":
+		if ctx.GuildID == "" {
+			ctx.Reply(i18n.T(lang, "uma.alias.server_only"))
+			return
+		}
+		if kinds == nil || alias == "" {
+			ctx.Reply(i18n.T(lang, "uma.alias.usage", prefix))
+			return
+		}
+
+		removed, err := aliasService.RemoveAlias(ctx.GuildID, kinds[0], alias)
+		if err != nil {
+			logger.Error("Failed to remove Uma alias", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"alias":    alias,
+			})
+			ctx.Reply(i18n.T(lang, "uma.alias.error", err))
+			return
+		}
+		if !removed {
+			ctx.Reply(i18n.T(lang, "uma.alias.not_found", alias))
+			return
+		}
+		ctx.Reply(i18n.T(lang, "uma.alias.removed", alias))
+	default:
+		ctx.Reply(i18n.T(lang, "uma.alias.usage", prefix))
+	}
+}
+
+// addUmaAlias resolves what a nickname should stand for and saves it for the guild. The
+// name must find exactly one character or card so the nickname never points at a guess.
+func addUmaAlias(ctx *Context, kind, alias, name string, logger logging.Logger) {
+	lang := ctx.Language
+	var target string
+	var targetID int
+
+	if kind == service.AliasCharacter {
+		result, err := characterService.SearchCharacter(ctx.GuildID, name)
+		if err != nil {
+			ctx.Reply(i18n.T(lang, "uma.search_error.character", err))
+			return
+		}
+		if !result.Found || result.Character == nil {
+			ctx.Reply(i18n.T(lang, "uma.alias.target_not_found", name))
+			return
+		}
+		if len(result.Candidates) > 1 {
+			names := make([]string, len(result.Candidates))
+			for i, candidate := range result.Candidates {
+				names[i] = "• " + candidate.NameEn
+			}
+			ctx.Reply(i18n.T(lang, "uma.alias.ambiguous", name, strings.Join(names, "\n")))
+			return
+		}
+		target, targetID = result.Character.NameEn, result.Character.ID
+	} else {
+		result, err := supportCardService.SearchSupportCard(ctx.GuildID, name)
+		if err != nil {
+			ctx.Reply(i18n.T(lang, "uma.search_error.support", err))
+			return
+		}
+		if !result.Found || result.SupportCard == nil {
+			ctx.Reply(i18n.T(lang, "uma.alias.target_not_found", name))
+			return
+		}
+		if len(result.Candidates) > 1 {
+			titles := make([]string, len(result.Candidates))
+			for i, candidate := range result.Candidates {
+				titles[i] = "• " + candidate.TitleEn
+			}
+			ctx.Reply(i18n.T(lang, "uma.alias.ambiguous", name, strings.Join(titles, "\n")))
+			return
+		}
+		target, targetID = result.SupportCard.TitleEn, result.SupportCard.ID
+	}
+
+	if _, err := aliasService.AddAlias(ctx.GuildID, kind, alias, target, targetID, ctx.Author.ID); err != nil {
+		logger.Error("Failed to add Uma alias", err, map[string]interface{}{
+			"guild_id": ctx.GuildID,
+			"alias":    alias,
+			"target":   target,
+		})
+		ctx.Reply(i18n.T(lang, "uma.alias.error", err))
+		return
+	}
+	ctx.Reply(i18n.T(lang, "uma.alias.added", alias, target))
+}
+
+// showUmaAliases lists the nicknames of each kind grouped by what they stand for. The
+// guild's own nicknames are starred; the rest are bundled with the bot.
+func showUmaAliases(ctx *Context, kinds []string, logger logging.Logger) {
+	lang := ctx.Language
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "uma.alias.list.title"),
+		Description: i18n.T(lang, "uma.alias.list.description", GuildSettings(ctx.GuildID).Prefix),
+		Color:       0x00ff00, // Green
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(lang, "uma.alias.list.footer"),
+		},
+	}
+
+	for _, kind := range kinds {
+		aliases, err := aliasService.ListAliases(ctx.GuildID, kind)
+		if err != nil {
+			logger.Error("Failed to list Uma aliases", err, map[string]interface{}{
+				"guild_id": ctx.GuildID,
+				"kind":     kind,
+			})
+			ctx.Reply(i18n.T(lang, "uma.alias.error", err))
+			return
+		}
+
+		// Aliases come ordered by target, so each target's nicknames are adjacent
+		var lines []string
+		for i := 0; i < len(aliases); {
+			target := aliases[i].Target
+			var names []string
+			for ; i < len(aliases) && aliases[i].Target == target; i++ {
+				name := aliases[i].Alias
+				if aliases[i].GuildID != "" {
+					name += "★"
+				}
+				names = append(names, name)
+			}
+			lines = append(lines, fmt.Sprintf("**%s**: %s", target, strings.Join(names, ", ")))
+		}
+
+		value := i18n.T(lang, "uma.alias.list.empty")
+		if len(lines) > 0 {
+			value = joinWithinLimit(lines, umaAliasFieldLimit, func(hidden int) string {
+				return i18n.T(lang, "uma.alias.list.more", hidden)
+			})
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(lang, "uma.alias.list."+kind),
+			Value: value,
+		})
+	}
+
+	ctx.ReplyEmbed(embed)
+}
+
+// joinWithinLimit joins lines, dropping them from the end with a note of how many were
+// left out until the text fits in limit characters
+func joinWithinLimit(lines []string, limit int, more func(hidden int) string) string {
+	joined := strings.Join(lines, "\n")
+	for shown := len(lines) - 1; shown >= 0 && len([]rune(joined)) > limit; shown-- {
+		joined = strings.TrimPrefix(strings.Join(lines[:shown], "\n")+"\n"+more(len(lines)-shown), "\n")
+	}
+	return joined
+}
+
+// parseAliasKind reads the kind of nickname a user named
+func parseAliasKind(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case "char", "character":
+		return service.AliasCharacter, true
+	case "support", "card":
+		return service.AliasSupport, true
+	}
+	return "", false
+}
+
+// umaCharacterAutocomplete suggests cached characters for /uma char
+func umaCharacterAutocomplete(i *discordgo.InteractionCreate, value string) []*discordgo.ApplicationCommandOptionChoice {
+	if umaDB == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), umaAutocompleteBudget)
+	defer cancel()
+
+	characters, err := repository.NewCharacterRepository(umaDB.WithContext(ctx)).SuggestCharacters(strings.TrimSpace(value), umaAutocompleteLimit)
+	if err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("uma").Debug("Character autocomplete lookup failed", map[string]interface{}{
+			"guild_id": i.GuildID,
+			"error":    err.Error(),
+		})
+		return nil
+	}
+
+	lang := interactionLanguage(i)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(characters))
+	for _, character := range characters {
+		choices = append(choices, umaChoice(character.NameEn, character.NameJp, lang))
+	}
+	return choices
+}
+
+// umaSupportAutocomplete suggests cached support cards for /uma support and /uma skills
+func umaSupportAutocomplete(i *discordgo.InteractionCreate, value string) []*discordgo.ApplicationCommandOptionChoice {
+	if umaDB == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), umaAutocompleteBudget)
+	defer cancel()
+
+	supportCards, err := repository.NewSupportCardRepository(umaDB.WithContext(ctx)).SuggestSupportCards(strings.TrimSpace(value), umaAutocompleteLimit)
+	if err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("uma").Debug("Support card autocomplete lookup failed", map[string]interface{}{
+			"guild_id": i.GuildID,
+			"error":    err.Error(),
+		})
+		return nil
+	}
+
+	lang := interactionLanguage(i)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(supportCards))
+	for _, card := range supportCards {
+		choices = append(choices, umaChoice(card.TitleEn, card.Title, lang))
+	}
+	return choices
+}
+
+// umaChoice labels a suggestion with both names, the reader's language first; the
+// value is the English name, which every search accepts
+func umaChoice(nameEn, nameJp, lang string) *discordgo.ApplicationCommandOptionChoice {
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name:  truncateLabel(umaLabel(nameEn, nameJp, lang), maxChoiceLength),
+		Value: truncateLabel(nameEn, maxChoiceLength),
+	}
+}
+
+// umaLabel shows both names of a character or card, the reader's language first
+func umaLabel(nameEn, nameJp, lang string) string {
+	if nameJp == "" || nameJp == nameEn {
+		return nameEn
+	}
+	if lang == i18n.Japanese {
+		return fmt.Sprintf("%s (%s)", nameJp, nameEn)
+	}
+	return fmt.Sprintf("%s (%s)", nameEn, nameJp)
+}
+
+// umaPicker is an open "did you mean" menu waiting for a selection. It offers either
+// characters or support cards.
+type umaPicker struct {
+	userID       string
+	guildID      string
+	channelID    string
+	messageID    string
+	query        string
+	lang         string
+	characters   []shared.Character
+	supportCards []shared.SupportCard // One card per title
+	timer        *time.Timer
+}
+
+var (
+	umaPickers     = make(map[string]*umaPicker)
+	umaPickerMutex sync.Mutex
+)
+
+// showUmaPicker asks the user which of several close matches they meant
+func showUmaPicker(ctx *Context, picker *umaPicker, logger logging.Logger) {
+	picker.userID = ctx.Author.ID
+	picker.guildID = ctx.GuildID
+	picker.channelID = ctx.ChannelID
+	picker.lang = ctx.Language
+
+	pickerID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	pickerEmbed := &discordgo.MessageEmbed{
+		Title:       i18n.T(picker.lang, "uma.picker.title"),
+		Description: i18n.T(picker.lang, "uma.picker.description", truncateLabel(picker.query, 200), int(umaPickerTimeout.Seconds())),
+		Color:       0x0099ff,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	msg, err := ctx.ReplyEmbedComponents(pickerEmbed, umaPickerComponents(pickerID, picker, false))
+	if err != nil {
+		logger.Error("Failed to send Uma picker", err, map[string]interface{}{
+			"guild_id":   ctx.GuildID,
+			"channel_id": ctx.ChannelID,
+			"query":      picker.query,
+		})
+		return
+	}
+	picker.messageID = msg.ID
+	picker.timer = time.AfterFunc(umaPickerTimeout, func() {
+		expireUmaPicker(ctx.Session, pickerID)
+	})
+
+	umaPickerMutex.Lock()
+	umaPickers[pickerID] = picker
+	umaPickerMutex.Unlock()
+
+	logger.Info("Uma picker displayed", map[string]interface{}{
+		"guild_id":   ctx.GuildID,
+		"user_id":    ctx.Author.ID,
+		"query":      picker.query,
+		"characters": len(picker.characters),
+		"cards":      len(picker.supportCards),
+	})
+}
+
+// umaPickerComponents builds the select menu offering each close match
+func umaPickerComponents(pickerID string, picker *umaPicker, disabled bool) []discordgo.MessageComponent {
+	var options []discordgo.SelectMenuOption
+	placeholder := i18n.T(picker.lang, "uma.picker.placeholder.character")
+	for i, character := range picker.characters {
+		options = append(options, discordgo.SelectMenuOption{
+			Label: truncateLabel(umaLabel(character.NameEn, character.NameJp, picker.lang), 100),
+			Value: strconv.Itoa(i),
+		})
+	}
+	if len(picker.supportCards) > 0 {
+		placeholder = i18n.T(picker.lang, "uma.picker.placeholder.support")
+	}
+	for i, card := range picker.supportCards {
+		options = append(options, discordgo.SelectMenuOption{
+			Label:       truncateLabel(umaLabel(card.TitleEn, card.Title, picker.lang), 100),
+			Value:       strconv.Itoa(i),
+			Description: truncateLabel(card.Type, 100),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    umaPickerPrefix + pickerID,
+					Placeholder: placeholder,
+					Options:     options,
+					Disabled:    disabled,
+				},
+			},
+		},
+	}
+}
+
+// expireUmaPicker closes a picker that was never answered
+func expireUmaPicker(s *discordgo.Session, pickerID string) {
+	umaPickerMutex.Lock()
+	picker, exists := umaPickers[pickerID]
+	delete(umaPickers, pickerID)
+	umaPickerMutex.Unlock()
+
+	if !exists {
+		return
+	}
+
+	expiredEmbed := &discordgo.MessageEmbed{
+		Title:       i18n.T(picker.lang, "uma.picker.expired.title"),
+		Description: i18n.T(picker.lang, "uma.picker.expired.description", truncateLabel(picker.query, 200)),
+		Color:       0x808080,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	components := umaPickerComponents(pickerID, picker, true)
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         picker.messageID,
+		Channel:    picker.channelID,
+		Embeds:     &[]*discordgo.MessageEmbed{expiredEmbed},
+		Components: &components,
+	})
+}
+
+// IsUmaPickerInteraction reports whether a component custom ID belongs to an Uma picker
+func IsUmaPickerInteraction(customID string) bool {
+	return strings.HasPrefix(customID, umaPickerPrefix)
+}
+
+// HandleUmaPicker replaces a picker with the character or support card chosen from it
+func HandleUmaPicker(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("uma")
+	lang := interactionLanguage(i)
+
+	user := interactionUser(i)
+	if user == nil {
+		return
+	}
+
+	data := i.MessageComponentData()
+	pickerID := strings.TrimPrefix(data.CustomID, umaPickerPrefix)
+
+	umaPickerMutex.Lock()
+	picker, exists := umaPickers[pickerID]
+	if exists && picker.userID == user.ID {
+		// Claim the picker so a double click can't answer twice
+		delete(umaPickers, pickerID)
+		picker.timer.Stop()
+	}
+	umaPickerMutex.Unlock()
+
+	if !exists {
+		respondEphemeral(s, i, i18n.T(lang, "uma.picker.gone"))
+		return
+	}
+	if picker.userID != user.ID {
+		respondEphemeral(s, i, i18n.T(lang, "uma.picker.not_yours"))
+		return
+	}
+	lang = picker.lang
+
+	index := -1
+	if len(data.Values) > 0 {
+		index, _ = strconv.Atoi(data.Values[0])
+	}
+	if index < 0 || index >= len(picker.characters)+len(picker.supportCards) {
+		respondEphemeral(s, i, i18n.T(lang, "uma.picker.unavailable"))
+		return
+	}
+
+	if len(picker.characters) > 0 {
+		character := picker.characters[index]
+		logger.Info("Uma picker character picked", map[string]interface{}{
+			"guild_id":       picker.guildID,
+			"user_id":        user.ID,
+			"query":          picker.query,
+			"character_name": character.NameEn,
+			"position":       index + 1,
+		})
+
+		imagesResult, err := characterService.GetCharacterImages(character.ID)
+		if err != nil {
+			respondEphemeral(s, i, i18n.T(lang, "uma.search_error.images", err))
+			return
+		}
+		updateUmaPicker(s, i, navigationManager.CreateCharacterEmbed(&character, imagesResult, 0, lang))
+		registerCharacterNavigation(s, picker.channelID, picker.messageID, &character, imagesResult, lang)
+		return
+	}
+
+	// Look the card up again by its exact title to get every rarity of it
+	card := picker.supportCards[index]
+	logger.Info("Uma picker support card picked", map[string]interface{}{
+		"guild_id":   picker.guildID,
+		"user_id":    user.ID,
+		"query":      picker.query,
+		"card_title": card.TitleEn,
+		"position":   index + 1,
+	})
+
+	result, err := supportCardService.SearchSupportCard(picker.guildID, card.TitleEn)
+	if err != nil {
+		respondEphemeral(s, i, i18n.T(lang, "uma.search_error.support", err))
+		return
+	}
+	if !result.Found {
+		respondEphemeral(s, i, "❌ "+i18n.T(lang, "uma.support_not_found.description", card.TitleEn))
+		return
+	}
+	updateUmaPicker(s, i, supportResultEmbed(result, lang))
+}
+
+// updateUmaPicker replaces the picker message with the chosen result, removing the menu
+func updateUmaPicker(s *discordgo.Session, i *discordgo.InteractionCreate, resultEmbed *discordgo.MessageEmbed) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{resultEmbed},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
 }
@@ -6,85 +6,90 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/pkg/audio"
+	"github.com/latoulicious/HKTM/pkg/extractor"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
 )
 
 // UtilityCommand handles utility-related commands
-func UtilityCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+func UtilityCommand(ctx *Context) {
 	// Initialize centralized logging for this command
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateCommandLogger("utility")
 	logger.Info("Utility command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
-		"args_count": len(args),
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
 	})
-	if len(args) == 0 {
+	if len(ctx.Args) == 0 {
 		logger.Warn("Utility command called without subcommand", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Please specify a subcommand.\n\n**Usage:** `!utility <subcommand>`\n**Available subcommands:**\n• `cron` - Check cron job status (Bot Owner Only)\n• `cron-refresh` - Manually trigger build ID refresh (Bot Owner Only)\n\n**Examples:**\n• `!utility cron`\n• `!utility cron-refresh`")
+		ctx.Reply("❌ Please specify a subcommand.\n\n**Usage:** `!utility <subcommand>`\n**Available subcommands:**\n• `cron` - Check cron job status (Bot Owner Only)\n• `cron-refresh` - Manually trigger build ID refresh (Bot Owner Only)\n• `streams` - Show stream URL cache and process usage (Bot Owner Only)\n\n**Examples:**\n• `!utility cron`\n• `!utility cron-refresh`\n• `!utility streams`")
 		return
 	}
 
-	subcommand := strings.ToLower(args[0])
+	subcommand := strings.ToLower(ctx.Args[0])
 
 	logger.Info("Utility subcommand called", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"guild_id":   m.GuildID,
+		"user_id":    ctx.Author.ID,
+		"guild_id":   ctx.GuildID,
 		"subcommand": subcommand,
 	})
 
 	switch subcommand {
 	case "cron":
-		CronStatusCommand(s, m, args[1:], logger)
+		CronStatusCommand(ctx, ctx.Args[1:], logger)
 	case "cron-refresh":
-		CronRefreshCommand(s, m, args[1:], logger)
+		CronRefreshCommand(ctx, ctx.Args[1:], logger)
+	case "streams":
+		StreamCacheStatusCommand(ctx, logger)
 	default:
 		logger.Warn("Unknown utility subcommand", map[string]interface{}{
-			"user_id":    m.Author.ID,
-			"guild_id":   m.GuildID,
+			"user_id":    ctx.Author.ID,
+			"guild_id":   ctx.GuildID,
 			"subcommand": subcommand,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Unknown subcommand.\n\n**Available subcommands:**\n• `cron` - Check cron job status (Bot Owner Only)\n• `cron-refresh` - Manually trigger build ID refresh (Bot Owner Only)\n\n**Examples:**\n• `!utility cron`\n• `!utility cron-refresh`")
+		ctx.Reply("❌ Unknown subcommand.\n\n**Available subcommands:**\n• `cron` - Check cron job status (Bot Owner Only)\n• `cron-refresh` - Manually trigger build ID refresh (Bot Owner Only)\n• `streams` - Show stream URL cache and process usage (Bot Owner Only)\n\n**Examples:**\n• `!utility cron`\n• `!utility cron-refresh`\n• `!utility streams`")
 	}
 }
 
 // CronStatusCommand shows the status of cron jobs
-func CronStatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func CronStatusCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Cron status command executed", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
 
 	// Check if user is bot owner
-	if m.Author.ID != s.State.User.ID {
+	if !hasCapability(ctx.Session, ctx.GuildID, ctx.Author.ID, permissions.OwnerUtility) {
 		logger.Warn("Cron status command denied - not bot owner", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ This command is restricted to the bot owner only.")
+		ctx.Reply("❌ This command is restricted to the bot owner only.")
 		return
 	}
 
 	client := handler.GetGametoraClient()
 	if client == nil {
 		logger.Error("Gametora client not available", nil, map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Gametora client not available.")
+		ctx.Reply("❌ Gametora client not available.")
 		return
 	}
 
 	// Get build ID manager
 	buildIDManager := client.GetBuildIDManager()
 	if buildIDManager == nil {
-		s.ChannelMessageSend(m.ChannelID, "❌ Build ID manager not available.")
+		ctx.Reply("❌ Build ID manager not available.")
 		return
 	}
 
@@ -141,48 +146,48 @@ func CronStatusCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []
 		},
 	}
 
-	_, sendErr := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	sendErr := ctx.ReplyEmbed(embed)
 	if sendErr != nil {
 		logger.Error("Failed to send cron status embed", sendErr, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
 	}
 }
 
 // CronRefreshCommand manually triggers a build ID refresh
-func CronRefreshCommand(s *discordgo.Session, m *discordgo.MessageCreate, args []string, logger logging.Logger) {
+func CronRefreshCommand(ctx *Context, args []string, logger logging.Logger) {
 	logger.Info("Cron refresh command executed", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
 
 	// Check if user is bot owner
-	if m.Author.ID != s.State.User.ID {
+	if !hasCapability(ctx.Session, ctx.GuildID, ctx.Author.ID, permissions.OwnerUtility) {
 		logger.Warn("Cron refresh command denied - not bot owner", map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ This command is restricted to the bot owner only.")
+		ctx.Reply("❌ This command is restricted to the bot owner only.")
 		return
 	}
 
 	client := handler.GetGametoraClient()
 	if client == nil {
 		logger.Error("Gametora client not available for refresh", nil, map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
-		s.ChannelMessageSend(m.ChannelID, "❌ Gametora client not available.")
+		ctx.Reply("❌ Gametora client not available.")
 		return
 	}
 
-	// Send initial message
-	msg, _ := s.ChannelMessageSend(m.ChannelID, "🔄 Manually triggering build ID refresh...")
+	// Show progress; the result replaces it
+	ctx.Progress("🔄 Manually triggering build ID refresh...")
 
 	logger.Info("Starting manual build ID refresh", map[string]interface{}{
-		"user_id":  m.Author.ID,
-		"guild_id": m.GuildID,
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
 	})
 
 	// Trigger refresh
@@ -190,8 +195,8 @@ func CronRefreshCommand(s *discordgo.Session, m *discordgo.MessageCreate, args [
 
 	if err != nil {
 		logger.Error("Build ID refresh failed", err, map[string]interface{}{
-			"user_id":  m.Author.ID,
-			"guild_id": m.GuildID,
+			"user_id":  ctx.Author.ID,
+			"guild_id": ctx.GuildID,
 		})
 
 		// Update message with error
@@ -211,14 +216,14 @@ func CronRefreshCommand(s *discordgo.Session, m *discordgo.MessageCreate, args [
 				},
 			},
 		}
-		s.ChannelMessageEditEmbed(m.ChannelID, msg.ID, embed)
+		ctx.ReplyEmbed(embed)
 	} else {
 		// Get new build ID
 		newBuildID, _ := client.GetBuildID()
 
 		logger.Info("Build ID refresh completed successfully", map[string]interface{}{
-			"user_id":      m.Author.ID,
-			"guild_id":     m.GuildID,
+			"user_id":      ctx.Author.ID,
+			"guild_id":     ctx.GuildID,
 			"new_build_id": newBuildID,
 		})
 
@@ -244,6 +249,74 @@ func CronRefreshCommand(s *discordgo.Session, m *discordgo.MessageCreate, args [
 				},
 			},
 		}
-		s.ChannelMessageEditEmbed(m.ChannelID, msg.ID, embed)
+		ctx.ReplyEmbed(embed)
+	}
+}
+
+// StreamCacheStatusCommand shows how often stream URLs were reused per extraction strategy
+func StreamCacheStatusCommand(ctx *Context, logger logging.Logger) {
+	logger.Info("Stream cache status command executed", map[string]interface{}{
+		"user_id":  ctx.Author.ID,
+		"guild_id": ctx.GuildID,
+	})
+
+	// Check if user is bot owner
+	if !hasCapability(ctx.Session, ctx.GuildID, ctx.Author.ID, permissions.OwnerUtility) {
+		ctx.Reply("❌ This command is restricted to the bot owner only.")
+		return
+	}
+
+	streams := extractor.GetGlobalExtractor().Streams()
+	names, stats := streams.Stats()
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "📦 Cached URLs",
+			Value:  fmt.Sprintf("%d", streams.Size()),
+			Inline: true,
+		},
+		{
+			Name:   "⏱️ Refresh Margin",
+			Value:  streams.RefreshMargin().String(),
+			Inline: true,
+		},
+	}
+	for _, usage := range audio.GetGlobalProcessBudget().Usage() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "⚙️ " + capitalize(string(usage.Kind)) + " Processes",
+			Value:  fmt.Sprintf("%d/%d running\n%d waiting", usage.Active, usage.Limit, usage.Waiting),
+			Inline: true,
+		})
+	}
+	for _, name := range names {
+		counters := stats[name]
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "🎯 " + capitalize(name),
+			Value:  fmt.Sprintf("Hits: %d\nMisses: %d\nFailures: %d\nInvalidated: %d", counters.Hits, counters.Misses, counters.Failures, counters.Invalidations),
+			Inline: true,
+		})
+	}
+
+	description := "Stream URLs reused across guilds and replays"
+	if len(names) == 0 {
+		description = "No stream URLs have been resolved yet"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🔗 Stream URL Cache",
+		Description: description,
+		Color:       0x7289DA, // Discord blue
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Hokko Tarumae | Utility Commands",
+		},
+		Fields: fields,
+	}
+
+	if err := ctx.ReplyEmbed(embed); err != nil {
+		logger.Error("Failed to send stream cache status embed", err, map[string]interface{}{
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
+		})
 	}
 }
@@ -8,13 +8,13 @@ import (
 	"github.com/latoulicious/HKTM/pkg/logging"
 )
 
-func VersionCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
+func VersionCommand(ctx *Context) {
 	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("version")
 	logger.Info("Version command executed", map[string]interface{}{
-		"user_id":    m.Author.ID,
-		"username":   m.Author.Username,
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
+		"user_id":    ctx.Author.ID,
+		"username":   ctx.Author.Username,
+		"guild_id":   ctx.GuildID,
+		"channel_id": ctx.ChannelID,
 	})
 
 	info := version.Get()
@@ -42,19 +42,13 @@ func VersionCommand(s *discordgo.Session, m *discordgo.MessageCreate) {
 		},
 	}
 
-	// Prevent accidental mentions in responses.
-	_, err := s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
-		Embed: embed,
-		AllowedMentions: &discordgo.MessageAllowedMentions{
-			Parse: []discordgo.AllowedMentionType{}, // no @everyone/@here
-		},
-	})
-	if err != nil {
+	// The context keeps replies from mentioning anyone
+	if err := ctx.ReplyEmbed(embed); err != nil {
 		logger.Error("Failed to send version embed", err, map[string]interface{}{
-			"channel_id": m.ChannelID,
-			"guild_id":   m.GuildID,
+			"channel_id": ctx.ChannelID,
+			"guild_id":   ctx.GuildID,
 		})
-		_, _ = s.ChannelMessageSend(m.ChannelID, "❌ Error displaying version information: "+err.Error())
+		_ = ctx.Reply("❌ Error displaying version information: " + err.Error())
 	}
 }
 
@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// minEmptyChannelLeaveDelay stops brief reconnects from making the bot leave
+const minEmptyChannelLeaveDelay = 10 * time.Second
+
+// emptyChannelState tracks a guild whose voice channel has no listeners left
+type emptyChannelState struct {
+	timer      *time.Timer // Fires once the grace period is over
+	autoPaused bool        // Playback was paused because everyone left, not by a command
+}
+
+var (
+	// Guilds the bot is alone in, waiting for someone to rejoin
+	emptyChannels         = make(map[string]*emptyChannelState)
+	emptyChannelMutex     sync.Mutex
+	emptyChannelLeaveWait = 2 * time.Minute
+)
+
+// SetEmptyChannelLeaveDelay sets how many seconds the bot waits alone before leaving
+func SetEmptyChannelLeaveDelay(seconds int) {
+	delay := time.Duration(seconds) * time.Second
+	if delay < minEmptyChannelLeaveDelay {
+		return
+	}
+
+	emptyChannelMutex.Lock()
+	defer emptyChannelMutex.Unlock()
+	emptyChannelLeaveWait = delay
+}
+
+// HandleVoiceStateUpdate pauses playback when the bot's channel empties, resumes it when
+// someone rejoins and cleans up when the bot itself is disconnected
+func HandleVoiceStateUpdate(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	if s.State == nil || s.State.User == nil {
+		return
+	}
+
+	if v.UserID == s.State.User.ID {
+		handleBotVoiceStateUpdate(s, v)
+		return
+	}
+
+	// Only joins and leaves of the bot's channel matter
+	botChannelID := common.GetBotVoiceChannelID(s, v.GuildID)
+	if botChannelID == "" {
+		return
+	}
+	previousChannelID := ""
+	if v.BeforeUpdate != nil {
+		previousChannelID = v.BeforeUpdate.ChannelID
+	}
+	if v.ChannelID != botChannelID && previousChannelID != botChannelID {
+		return
+	}
+
+	checkVoiceListeners(s, v.GuildID, botChannelID)
+}
+
+// handleBotVoiceStateUpdate reacts to the bot being moved or disconnected by someone else
+func handleBotVoiceStateUpdate(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	if v.ChannelID != "" {
+		// Moved to another channel; keep the track going there, and let the listeners
+		// there decide whether to keep playing
+		if v.BeforeUpdate != nil && v.BeforeUpdate.ChannelID != "" && v.BeforeUpdate.ChannelID != v.ChannelID {
+			go recoverVoiceConnection(s, v.GuildID, v.ChannelID)
+		}
+		checkVoiceListeners(s, v.GuildID, v.ChannelID)
+		return
+	}
+
+	// Idle-leave, stop, leave and shutdown all record the intent to leave first, so
+	// a disconnect without one means a moderator disconnected the bot
+	if common.TakeLeavingVoice(v.GuildID) {
+		clearEmptyChannel(v.GuildID)
+		return
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger("voice").Info("Disconnected from voice by another user", map[string]interface{}{
+		"guild_id": v.GuildID,
+	})
+
+	channelID := panelChannelID(v.GuildID)
+	stopGuildPlayback(s, v.GuildID)
+	common.DisconnectFromVoiceChannel(s, v.GuildID)
+	if channelID != "" {
+		lang := GuildSettings(v.GuildID).Language
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.voice.disconnected.title"), i18n.T(lang, "commands.voice.disconnected.description"), 0xffa500)
+	}
+}
+
+// checkVoiceListeners starts or cancels the empty-channel grace period for the bot's channel
+func checkVoiceListeners(s *discordgo.Session, guildID, channelID string) {
+	queue := getQueue(guildID)
+	if queue == nil || !queue.IsPlaying() {
+		clearEmptyChannel(guildID)
+		return
+	}
+
+	if len(common.GetVoiceChannelListeners(s, guildID, channelID)) > 0 {
+		// Listening counts as activity for the idle timeout
+		updateActivity(guildID)
+		handleListenerReturned(s, guildID, queue)
+		return
+	}
+	handleChannelEmptied(s, guildID, queue)
+}
+
+// handleChannelEmptied pauses playback and schedules leaving once the last listener is gone
+func handleChannelEmptied(s *discordgo.Session, guildID string, queue *common.MusicQueue) {
+	emptyChannelMutex.Lock()
+	if _, waiting := emptyChannels[guildID]; waiting {
+		emptyChannelMutex.Unlock()
+		return
+	}
+
+	state := &emptyChannelState{}
+	emptyChannels[guildID] = state
+	delay := emptyChannelLeaveWait
+	state.timer = time.AfterFunc(delay, func() {
+		leaveEmptyChannel(s, guildID, state)
+	})
+	emptyChannelMutex.Unlock()
+
+	// Playback a listener paused stays theirs to resume
+	if pipeline := queue.GetPipeline(); pipeline != nil && pipeline.IsPlaying() && !pipeline.IsPaused() {
+		if err := pipeline.Pause(); err == nil {
+			emptyChannelMutex.Lock()
+			state.autoPaused = true
+			emptyChannelMutex.Unlock()
+		}
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger("voice").Info("Voice channel emptied", map[string]interface{}{
+		"guild_id":    guildID,
+		"leave_after": delay.String(),
+	})
+
+	refreshNowPlayingPanel(s, guildID)
+	if channelID := panelChannelID(guildID); channelID != "" {
+		lang := GuildSettings(guildID).Language
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.voice.paused.title"),
+			i18n.T(lang, "commands.voice.paused.description", formatWait(lang, delay)), 0xffa500)
+	}
+}
+
+// handleListenerReturned cancels the pending leave and resumes playback that was paused for it
+func handleListenerReturned(s *discordgo.Session, guildID string, queue *common.MusicQueue) {
+	emptyChannelMutex.Lock()
+	state, waiting := emptyChannels[guildID]
+	if waiting {
+		state.timer.Stop()
+		delete(emptyChannels, guildID)
+	}
+	emptyChannelMutex.Unlock()
+
+	if !waiting || !state.autoPaused {
+		return
+	}
+
+	pipeline := queue.GetPipeline()
+	if pipeline == nil || !pipeline.IsPaused() {
+		return
+	}
+	if err := pipeline.Resume(); err != nil {
+		logging.GetGlobalLoggerFactory().CreateCommandLogger("voice").Error("Failed to resume playback", err, map[string]interface{}{
+			"guild_id": guildID,
+		})
+		return
+	}
+
+	refreshNowPlayingPanel(s, guildID)
+	if channelID := panelChannelID(guildID); channelID != "" {
+		lang := GuildSettings(guildID).Language
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.voice.resumed.title"), i18n.T(lang, "commands.voice.resumed.description"), 0x00ff00)
+	}
+}
+
+// leaveEmptyChannel stops playback and leaves once the grace period passed without listeners
+func leaveEmptyChannel(s *discordgo.Session, guildID string, state *emptyChannelState) {
+	emptyChannelMutex.Lock()
+	if emptyChannels[guildID] != state {
+		// Someone rejoined in the meantime
+		emptyChannelMutex.Unlock()
+		return
+	}
+	delete(emptyChannels, guildID)
+	emptyChannelMutex.Unlock()
+
+	botChannelID := common.GetBotVoiceChannelID(s, guildID)
+	if botChannelID != "" && len(common.GetVoiceChannelListeners(s, guildID, botChannelID)) > 0 {
+		return
+	}
+
+	logging.GetGlobalLoggerFactory().CreateCommandLogger("voice").Info("Leaving empty voice channel", map[string]interface{}{
+		"guild_id": guildID,
+	})
+
+	channelID := panelChannelID(guildID)
+	stopGuildPlayback(s, guildID)
+	common.DisconnectFromVoiceChannel(s, guildID)
+	if channelID != "" {
+		lang := GuildSettings(guildID).Language
+		sendEmbedMessage(s, channelID, i18n.T(lang, "commands.voice.left.title"), i18n.T(lang, "commands.voice.left.description"), 0x808080)
+	}
+}
+
+// stopGuildPlayback stops the guild's playback and clears its queue, panel and presence
+func stopGuildPlayback(s *discordgo.Session, guildID string) {
+	clearEmptyChannel(guildID)
+	resetSkipVotes(guildID)
+	clearStageNotice(guildID)
+
+	if queue := getQueue(guildID); queue != nil {
+		// The track didn't finish, so no "finished" message once the pipeline stops
+		queue.SetSkipped(true)
+		queue.Clear()
+		queue.SetPlaying(false)
+		if pipeline := queue.GetPipeline(); pipeline != nil {
+			pipeline.Stop()
+		}
+		queue.SetVoiceConnection(nil)
+	}
+
+	closeNowPlayingPanel(s, guildID)
+	if presenceManager != nil {
+		presenceManager.ClearMusicPresence(guildID)
+	}
+	if timeoutManager != nil {
+		timeoutManager.RemoveGuild(guildID)
+	}
+}
+
+// clearEmptyChannel forgets a guild's pending leave
+func clearEmptyChannel(guildID string) {
+	emptyChannelMutex.Lock()
+	defer emptyChannelMutex.Unlock()
+
+	if state, exists := emptyChannels[guildID]; exists {
+		state.timer.Stop()
+		delete(emptyChannels, guildID)
+	}
+}
+
+// panelChannelID returns the text channel of the guild's now-playing panel, or ""
+func panelChannelID(guildID string) string {
+	panelMutex.Lock()
+	defer panelMutex.Unlock()
+
+	if panel, exists := nowPlayingPanels[guildID]; exists {
+		return panel.channelID
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// volumeStep is how much the volume buttons and `!volume up|down` change the volume
+const volumeStep = 10
+
+// VolumeCommand handles the !volume command to show or change the playback volume
+func VolumeCommand(ctx *Context) {
+	guildID := ctx.GuildID
+
+	// Initialize centralized logging for this command
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("volume")
+	logger.Info("Volume command executed", map[string]interface{}{
+		"user_id":    ctx.Author.ID,
+		"guild_id":   guildID,
+		"channel_id": ctx.ChannelID,
+		"args_count": len(ctx.Args),
+	})
+
+	// Initialize centralized embed builder
+	embedBuilder := embedsFor(ctx)
+
+	// Update activity for idle monitoring
+	updateActivity(guildID)
+
+	queue := getOrCreateQueue(guildID)
+
+	if len(ctx.Args) == 0 {
+		description := ctx.T("commands.volume.current.description", queue.GetVolume(), commandDisplayName(ctx, "volume"), audio.MaxVolume)
+		ctx.ReplyEmbed(embedBuilder.Info(ctx.T("commands.volume.current.title"), description))
+		return
+	}
+
+	target, err := parseVolume(ctx.Args[0], queue.GetVolume())
+	if err != nil {
+		errorEmbed := embedBuilder.Error(ctx.T("commands.volume.invalid.title"), ctx.T("commands.volume.invalid.description", audio.MaxVolume))
+		ctx.ReplyEmbed(errorEmbed)
+		return
+	}
+
+	volume := queue.SetVolume(target)
+
+	logger.Info("Volume changed", map[string]interface{}{
+		"guild_id": guildID,
+		"user_id":  ctx.Author.ID,
+		"volume":   volume,
+	})
+
+	ctx.ReplyEmbed(embedBuilder.Success(ctx.T("commands.volume.set.title", volumeIcon(volume)), ctx.T("commands.volume.set.description", volume)))
+
+	refreshNowPlayingPanel(ctx.Session, guildID)
+}
+
+// parseVolume accepts absolute values ("80", "80%"), relative values ("+10", "-10") and up/down
+func parseVolume(value string, current int) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "up":
+		return current + volumeStep, nil
+	case "down":
+		return current - volumeStep, nil
+	}
+
+	percent, err := parsePercent(value)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		return current + percent, nil
+	}
+	if percent < 0 || percent > audio.MaxVolume {
+		return 0, fmt.Errorf("volume out of range: %d", percent)
+	}
+	return percent, nil
+}
+
+// volumeIcon picks a speaker emoji for a volume level
+func volumeIcon(volume int) string {
+	switch {
+	case volume == 0:
+		return "🔇"
+	case volume < 50:
+		return "🔈"
+	case volume <= 100:
+		return "🔉"
+	default:
+		return "🔊"
+	}
+}
+
+// formatVolume renders a volume for embeds
+func formatVolume(volume int) string {
+	return volumeIcon(volume) + " " + strconv.Itoa(volume) + "%"
+}
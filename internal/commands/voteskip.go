@@ -0,0 +1,417 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/permissions"
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+)
+
+// voteSkipButtonPrefix identifies vote-skip button interactions
+const voteSkipButtonPrefix = "voteskip:"
+
+// skipVoteSession tracks the votes cast against the current track in a guild
+type skipVoteSession struct {
+	track     *common.QueueItem
+	voters    map[string]bool
+	channelID string // Text channel holding the progress message
+	messageID string // Progress message, edited in place as votes come in
+}
+
+// skipVoteResult summarizes the state of a vote after a change
+type skipVoteResult struct {
+	votes     int
+	required  int
+	listeners int
+	passed    bool
+}
+
+var (
+	skipVotes     = make(map[string]*skipVoteSession)
+	skipVoteMutex sync.Mutex
+
+	// Percentage of listeners required to skip, overridable per guild in the settings
+	defaultVoteSkipThreshold = 50
+)
+
+// SetVoteSkipThreshold sets the default vote-skip threshold percentage
+func SetVoteSkipThreshold(percent int) {
+	if percent < 1 || percent > 100 {
+		return
+	}
+
+	skipVoteMutex.Lock()
+	defer skipVoteMutex.Unlock()
+	defaultVoteSkipThreshold = percent
+}
+
+// getVoteSkipThreshold returns the effective vote-skip threshold for a guild
+func getVoteSkipThreshold(guildID string) int {
+	if percent := GuildSettings(guildID).VoteSkipThreshold; percent > 0 {
+		return percent
+	}
+
+	skipVoteMutex.Lock()
+	defer skipVoteMutex.Unlock()
+	return defaultVoteSkipThreshold
+}
+
+// requiredSkipVotes returns how many votes are needed for the given listener count
+func requiredSkipVotes(listeners, threshold int) int {
+	required := (listeners*threshold + 99) / 100
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+// resetSkipVotes discards any vote in progress for a guild
+func resetSkipVotes(guildID string) {
+	skipVoteMutex.Lock()
+	defer skipVoteMutex.Unlock()
+	delete(skipVotes, guildID)
+}
+
+// tallySkipVotes counts votes from users still listening; caller must hold skipVoteMutex
+func tallySkipVotes(session *skipVoteSession, listeners []string, threshold int) *skipVoteResult {
+	present := make(map[string]bool, len(listeners))
+	for _, userID := range listeners {
+		present[userID] = true
+	}
+
+	// Drop votes from anyone who is no longer in the channel
+	for userID := range session.voters {
+		if !present[userID] {
+			delete(session.voters, userID)
+		}
+	}
+
+	result := &skipVoteResult{
+		votes:     len(session.voters),
+		required:  requiredSkipVotes(len(listeners), threshold),
+		listeners: len(listeners),
+	}
+	result.passed = result.votes >= result.required
+	return result
+}
+
+// castSkipVote records a vote from a user in the bot's voice channel
+func castSkipVote(s *discordgo.Session, guildID, userID string, track *common.QueueItem) (*skipVoteResult, error) {
+	botChannelID := common.GetBotVoiceChannelID(s, guildID)
+	if botChannelID == "" {
+		return nil, errBotNotInVoice
+	}
+
+	listeners := common.GetVoiceChannelListeners(s, guildID, botChannelID)
+	threshold := getVoteSkipThreshold(guildID)
+	inChannel := false
+	for _, listenerID := range listeners {
+		if listenerID == userID {
+			inChannel = true
+			break
+		}
+	}
+	if !inChannel {
+		return nil, errVoterNotListening
+	}
+
+	skipVoteMutex.Lock()
+	defer skipVoteMutex.Unlock()
+
+	session, exists := skipVotes[guildID]
+	if !exists || session.track != track {
+		session = &skipVoteSession{
+			track:  track,
+			voters: make(map[string]bool),
+		}
+		skipVotes[guildID] = session
+	}
+
+	session.voters[userID] = true
+	result := tallySkipVotes(session, listeners, threshold)
+	if result.passed {
+		delete(skipVotes, guildID)
+	}
+	return result, nil
+}
+
+// voteSkipButtonID builds the custom ID for a track's vote button. It is keyed on when
+// the track started so a looped replay of the same item doesn't accept old buttons.
+func voteSkipButtonID(guildID string, track *common.QueueItem) string {
+	var marker int64
+	if track != nil {
+		marker = track.StartedAt.UnixNano()
+	}
+	return fmt.Sprintf("%s%s:%d", voteSkipButtonPrefix, guildID, marker)
+}
+
+// voteSkipComponents builds the action row holding the vote button
+func voteSkipComponents(lang, guildID string, track *common.QueueItem, disabled bool) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(lang, "commands.voteskip.button"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: voteSkipButtonID(guildID, track),
+					Disabled: disabled,
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "⏭️",
+					},
+				},
+			},
+		},
+	}
+}
+
+// voteSkipProgressEmbed builds the embed showing vote progress
+func voteSkipProgressEmbed(lang string, track *common.QueueItem, result *skipVoteResult) *discordgo.MessageEmbed {
+	title := i18n.T(lang, "commands.voteskip.current_song")
+	if track != nil {
+		title = fmt.Sprintf("**%s**", track.Title)
+	}
+
+	filled := 0
+	if result.required > 0 {
+		filled = result.votes * 10 / result.required
+		if filled > 10 {
+			filled = 10
+		}
+	}
+	bar := strings.Repeat("🟩", filled) + strings.Repeat("⬜", 10-filled)
+
+	description := i18n.T(lang, "commands.voteskip.progress", title, bar, result.votes, result.required, result.listeners)
+	return embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang).Info(i18n.T(lang, "commands.voteskip.title"), description)
+}
+
+// sendVoteSkipProgress posts or updates the vote progress message for a guild
+func sendVoteSkipProgress(ctx *Context, track *common.QueueItem, result *skipVoteResult, logger logging.Logger) {
+	s, channelID, guildID := ctx.Session, ctx.ChannelID, ctx.GuildID
+
+	// Everyone in the channel votes on the same message, so it uses the guild's language
+	lang := GuildSettings(guildID).Language
+	progressEmbed := voteSkipProgressEmbed(lang, track, result)
+	components := voteSkipComponents(lang, guildID, track, false)
+
+	skipVoteMutex.Lock()
+	session, exists := skipVotes[guildID]
+	var messageID string
+	if exists && session.channelID == channelID {
+		messageID = session.messageID
+	}
+	skipVoteMutex.Unlock()
+
+	// Edit the existing progress message in place when possible. A slash command still
+	// owes a reply, so it posts a fresh one instead.
+	if messageID != "" && !ctx.awaitingReply() {
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         messageID,
+			Channel:    channelID,
+			Embeds:     &[]*discordgo.MessageEmbed{progressEmbed},
+			Components: &components,
+		})
+		if err == nil {
+			return
+		}
+	}
+
+	msg, err := ctx.ReplyEmbedComponents(progressEmbed, components)
+	if err != nil {
+		logger.Error("Failed to send vote skip embed", err, map[string]interface{}{
+			"channel_id": channelID,
+			"guild_id":   guildID,
+		})
+		return
+	}
+
+	skipVoteMutex.Lock()
+	if session, exists := skipVotes[guildID]; exists && session.track == track {
+		session.channelID = channelID
+		session.messageID = msg.ID
+	}
+	skipVoteMutex.Unlock()
+}
+
+// IsVoteSkipInteraction reports whether a component custom ID belongs to vote-skip
+func IsVoteSkipInteraction(customID string) bool {
+	return strings.HasPrefix(customID, voteSkipButtonPrefix)
+}
+
+// HandleVoteSkipButton handles presses of the vote-skip button
+func HandleVoteSkipButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loggerFactory := logging.GetGlobalLoggerFactory()
+	logger := loggerFactory.CreateCommandLogger("voteskip")
+	lang := interactionLanguage(i)
+	embedBuilder := embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang)
+
+	user := interactionUser(i)
+	guildID := i.GuildID
+	voteLang := GuildSettings(guildID).Language // The vote message is shared
+
+	queue := getQueue(guildID)
+	if queue == nil || !queue.IsPlaying() {
+		respondEphemeral(s, i, i18n.T(lang, "commands.voteskip.nothing_playing"))
+		return
+	}
+
+	// Ignore presses on buttons left over from a previous track
+	track := queue.Current()
+	if i.MessageComponentData().CustomID != voteSkipButtonID(guildID, track) {
+		respondEphemeral(s, i, i18n.T(lang, "commands.voteskip.expired"))
+		return
+	}
+
+	updateActivity(guildID)
+
+	passed := track.IsRequestedBy(user.ID) ||
+		hasCapabilityGrant(s, guildID, user.ID, permissions.PlaybackControl)
+	if !passed {
+		result, err := castSkipVote(s, guildID, user.ID, track)
+		if err != nil {
+			respondEphemeral(s, i, "❌ "+localizeError(interactionLanguage(i), err)+".")
+			return
+		}
+
+		logger.Info("Skip vote recorded via button", map[string]interface{}{
+			"guild_id": guildID,
+			"user_id":  user.ID,
+			"votes":    result.votes,
+			"required": result.required,
+		})
+
+		if !result.passed {
+			components := voteSkipComponents(voteLang, guildID, track, false)
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseUpdateMessage,
+				Data: &discordgo.InteractionResponseData{
+					Embeds:     []*discordgo.MessageEmbed{voteSkipProgressEmbed(voteLang, track, result)},
+					Components: components,
+				},
+			})
+			return
+		}
+	}
+
+	// Vote passed: close the vote message and skip
+	components := voteSkipComponents(voteLang, guildID, track, true)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embedBuilder.WithLanguage(voteLang).Success(i18n.T(voteLang, "commands.voteskip.passed.title"),
+				i18n.T(voteLang, "commands.voteskip.passed.description"))},
+			Components: components,
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to update vote skip message", err, map[string]interface{}{
+			"guild_id": guildID,
+		})
+	}
+
+	performSkip(newComponentContext(s, i, Lookup("skip")), queue, user.Username, embedBuilder, logger)
+}
+
+// HandleVoteSkipVoiceStateUpdate re-tallies the vote in progress when someone leaves the
+// bot's voice channel. Fewer listeners need fewer votes, so a departure can pass the
+// vote even when the one leaving hadn't voted; the track is skipped when it does.
+func HandleVoteSkipVoiceStateUpdate(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	skipVoteMutex.Lock()
+	_, exists := skipVotes[v.GuildID]
+	skipVoteMutex.Unlock()
+	if !exists {
+		return
+	}
+
+	botChannelID := common.GetBotVoiceChannelID(s, v.GuildID)
+	if botChannelID == "" || v.ChannelID == botChannelID {
+		return
+	}
+	// Only departures from the bot's channel change the tally
+	if v.BeforeUpdate != nil && v.BeforeUpdate.ChannelID != botChannelID {
+		return
+	}
+
+	listeners := common.GetVoiceChannelListeners(s, v.GuildID, botChannelID)
+	threshold := getVoteSkipThreshold(v.GuildID)
+
+	skipVoteMutex.Lock()
+	session, exists := skipVotes[v.GuildID]
+	if !exists {
+		skipVoteMutex.Unlock()
+		return
+	}
+	result := tallySkipVotes(session, listeners, threshold)
+	track, channelID, messageID := session.track, session.channelID, session.messageID
+	if result.passed {
+		delete(skipVotes, v.GuildID)
+	}
+	skipVoteMutex.Unlock()
+
+	logger := logging.GetGlobalLoggerFactory().CreateCommandLogger("voteskip")
+	logger.Info("Re-tallied skip vote after a listener left", map[string]interface{}{
+		"guild_id": v.GuildID,
+		"user_id":  v.UserID,
+		"votes":    result.votes,
+		"required": result.required,
+		"passed":   result.passed,
+	})
+
+	if !result.passed {
+		if channelID != "" {
+			sendVoteSkipProgress(newChannelContext(s, v.GuildID, channelID, Lookup("skip")), track, result, logger)
+		}
+		return
+	}
+
+	// The vote may have been cast against a track that has since ended
+	queue := getQueue(v.GuildID)
+	if queue == nil || queue.Current() != track || channelID == "" {
+		return
+	}
+
+	lang := GuildSettings(v.GuildID).Language
+	embedBuilder := embed.GetGlobalAudioEmbedBuilder().WithLanguage(lang)
+	if messageID != "" {
+		components := voteSkipComponents(lang, v.GuildID, track, true)
+		s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:      messageID,
+			Channel: channelID,
+			Embeds: &[]*discordgo.MessageEmbed{embedBuilder.Success(i18n.T(lang, "commands.voteskip.passed.title"),
+				i18n.T(lang, "commands.voteskip.passed.description"))},
+			Components: &components,
+		})
+	}
+
+	performSkip(newChannelContext(s, v.GuildID, channelID, Lookup("skip")), queue, "vote", embedBuilder, logger)
+}
+
+// respondEphemeral sends a short reply only visible to the interacting user
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// capitalize upper-cases the first letter of a message
+func capitalize(message string) string {
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
+}
+
+// parsePercent parses values like "60" or "60%"
+func parsePercent(value string) (int, error) {
+	return strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +17,33 @@ type Config struct {
 
 	// Database configuration
 	DatabaseURL string
+
+	// Percentage of listeners required to vote-skip a track
+	VoteSkipThreshold int
+
+	// Seconds between live now-playing progress updates
+	NowPlayingLiveInterval int
+
+	// Hours parsed video metadata stays cached in the database
+	MetadataCacheTTL int
+
+	// Seconds before a stream URL expires that it gets refreshed
+	StreamURLRefreshMargin int
+
+	// Concurrent yt-dlp | ffmpeg streams and yt-dlp extractions allowed
+	MaxConcurrentStreams     int
+	MaxConcurrentExtractions int
+
+	// Seconds a request waits for a free process slot before giving up
+	ProcessQueueTimeout int
+
+	// Seconds the bot stays paused in an empty voice channel before leaving
+	EmptyChannelLeaveDelay int
+
+	// Gateway shards across all processes, and the shard IDs this process runs
+	// ("0,2" or "0-3"; empty runs every shard)
+	ShardCount int
+	ShardIDs   string
 }
 
 var (
@@ -57,11 +85,88 @@ func LoadConfig() (*Config, error) {
 		return nil, ErrDBPathNotSet
 	}
 
+	voteSkipThreshold := 50 // Default: half of the listeners
+	if threshold := os.Getenv("VOTE_SKIP_THRESHOLD"); threshold != "" {
+		if parsed, err := strconv.Atoi(threshold); err == nil && parsed >= 1 && parsed <= 100 {
+			voteSkipThreshold = parsed
+		}
+	}
+
+	nowPlayingLiveInterval := 15 // Default: well inside Discord's message edit limits
+	if interval := os.Getenv("NOW_PLAYING_LIVE_INTERVAL"); interval != "" {
+		if parsed, err := strconv.Atoi(interval); err == nil && parsed >= 5 {
+			nowPlayingLiveInterval = parsed
+		}
+	}
+
+	metadataCacheTTL := 24 // Default: titles and durations rarely change within a day
+	if ttl := os.Getenv("METADATA_CACHE_TTL"); ttl != "" {
+		if parsed, err := strconv.Atoi(ttl); err == nil && parsed >= 1 {
+			metadataCacheTTL = parsed
+		}
+	}
+
+	streamURLRefreshMargin := 60 // Default: leaves time for a slow yt-dlp run
+	if margin := os.Getenv("STREAM_URL_REFRESH_MARGIN"); margin != "" {
+		if parsed, err := strconv.Atoi(margin); err == nil && parsed >= 10 {
+			streamURLRefreshMargin = parsed
+		}
+	}
+
+	maxConcurrentStreams := 4 // Default: each stream is a yt-dlp and an ffmpeg process
+	if streams := os.Getenv("MAX_CONCURRENT_STREAMS"); streams != "" {
+		if parsed, err := strconv.Atoi(streams); err == nil && parsed >= 1 {
+			maxConcurrentStreams = parsed
+		}
+	}
+
+	maxConcurrentExtractions := 3 // Default: metadata lookups and searches are short but CPU heavy
+	if extractions := os.Getenv("MAX_CONCURRENT_EXTRACTIONS"); extractions != "" {
+		if parsed, err := strconv.Atoi(extractions); err == nil && parsed >= 1 {
+			maxConcurrentExtractions = parsed
+		}
+	}
+
+	processQueueTimeout := 60 // Default: long enough to wait out a few extractions
+	if timeout := os.Getenv("PROCESS_QUEUE_TIMEOUT"); timeout != "" {
+		if parsed, err := strconv.Atoi(timeout); err == nil && parsed >= 5 {
+			processQueueTimeout = parsed
+		}
+	}
+
+	emptyChannelLeaveDelay := 120 // Default: time for a listener to reconnect or switch devices
+	if delay := os.Getenv("EMPTY_CHANNEL_LEAVE_DELAY"); delay != "" {
+		if parsed, err := strconv.Atoi(delay); err == nil && parsed >= 10 {
+			emptyChannelLeaveDelay = parsed
+		}
+	}
+
+	shardCount := 1 // Default: a single shard serves every guild
+	if count := os.Getenv("SHARD_COUNT"); count != "" {
+		if parsed, err := strconv.Atoi(count); err == nil && parsed >= 1 {
+			shardCount = parsed
+		}
+	}
+
 	return &Config{
 		DiscordToken: discordToken,
 		OwnerID:      ownerID,
 		CronEnabled:  cronEnabled,
 		CronSchedule: cronSchedule,
 		DatabaseURL:  databaseURL,
+
+		VoteSkipThreshold:      voteSkipThreshold,
+		NowPlayingLiveInterval: nowPlayingLiveInterval,
+		MetadataCacheTTL:       metadataCacheTTL,
+		StreamURLRefreshMargin: streamURLRefreshMargin,
+
+		MaxConcurrentStreams:     maxConcurrentStreams,
+		MaxConcurrentExtractions: maxConcurrentExtractions,
+		ProcessQueueTimeout:      processQueueTimeout,
+
+		EmptyChannelLeaveDelay: emptyChannelLeaveDelay,
+
+		ShardCount: shardCount,
+		ShardIDs:   os.Getenv("SHARD_IDS"),
 	}, nil
 }
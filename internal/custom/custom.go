@@ -0,0 +1,278 @@
+// Package custom stores guild-defined commands, aliases for built-in commands and
+// canned responses, and renders their response templates.
+package custom
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// Kind is what a custom command does when invoked
+type Kind string
+
+const (
+	// KindAlias runs a built-in command line, followed by any arguments given
+	KindAlias Kind = "alias"
+	// KindText replies with a rendered text template
+	KindText Kind = "text"
+	// KindEmbed replies with a rendered template in an embed
+	KindEmbed Kind = "embed"
+)
+
+// Kinds lists every kind in display order
+var Kinds = []Kind{KindAlias, KindText, KindEmbed}
+
+const (
+	// MaxPerGuild is how many custom commands a guild may define
+	MaxPerGuild = 50
+
+	maxNameLength    = 32
+	maxTextLength    = 2000
+	maxEmbedLength   = 4096
+	embedTitleMarker = "|"
+)
+
+// validName matches a custom command name
+var validName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// ParseKind converts user input into a kind
+func ParseKind(value string) (Kind, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, kind := range Kinds {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+	return "", i18n.Errorf("custom.error.kind")
+}
+
+// Command is a guild-defined command
+type Command struct {
+	Name      string
+	Kind      Kind
+	Content   string
+	CreatedBy string
+}
+
+// EmbedParts splits an embed response into its title and body; a title is given by
+// starting the content with "Title |"
+func (c Command) EmbedParts() (string, string) {
+	title, body, found := strings.Cut(c.Content, embedTitleMarker)
+	if !found {
+		return "", c.Content
+	}
+	return strings.TrimSpace(title), strings.TrimSpace(body)
+}
+
+// ShowsMusic reports whether a response shows the track or queue, like the queue commands do
+func (c Command) ShowsMusic() bool {
+	return strings.Contains(c.Content, "{track}") || strings.Contains(c.Content, "{queue.size}")
+}
+
+// Values fill the placeholders of a response template
+type Values struct {
+	User      string // Mention of the invoking user
+	UserName  string
+	Channel   string // Mention of the channel the command was used in
+	Track     string // Title of the current track, if any
+	QueueSize int
+}
+
+// Placeholders lists the placeholders response templates may use. Each is described
+// in the message catalog under custom.placeholder.<name>.
+var Placeholders = []string{"user", "user.name", "channel", "track", "queue.size"}
+
+// Render fills in the placeholders of a response template
+func Render(template string, values Values) string {
+	track := values.Track
+	if track == "" {
+		track = "nothing"
+	}
+
+	return strings.NewReplacer(
+		"{user}", values.User,
+		"{user.name}", values.UserName,
+		"{channel}", values.Channel,
+		"{track}", track,
+		"{queue.size}", strconv.Itoa(values.QueueSize),
+	).Replace(template)
+}
+
+// Service loads custom commands from the database and caches them per guild
+type Service struct {
+	repo     *repository.CustomCommandRepository
+	commands map[string]map[string]Command // guildID -> name -> command
+	mu       sync.RWMutex
+	logger   logging.Logger
+}
+
+// NewService creates a custom command service; db may be nil, in which case guilds have
+// no custom commands and none can be added
+func NewService(db *gorm.DB) *Service {
+	var repo *repository.CustomCommandRepository
+	if db != nil {
+		repo = repository.NewCustomCommandRepository(db)
+	}
+
+	return &Service{
+		repo:     repo,
+		commands: make(map[string]map[string]Command),
+		logger:   logging.GetGlobalLoggerFactory().CreateLogger("custom-commands"),
+	}
+}
+
+// Get finds a guild's custom command by name
+func (s *Service) Get(guildID, name string) (Command, bool) {
+	command, exists := s.load(guildID)[strings.ToLower(name)]
+	return command, exists
+}
+
+// List returns a guild's custom commands sorted by name
+func (s *Service) List(guildID string) []Command {
+	var list []Command
+	for _, command := range s.load(guildID) {
+		list = append(list, command)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Add defines a custom command, replacing any existing one with the same name
+func (s *Service) Add(guildID string, command Command) error {
+	if s.repo == nil {
+		return fmt.Errorf("custom command storage is not available")
+	}
+
+	command.Name = strings.ToLower(command.Name)
+	command.Content = strings.TrimSpace(command.Content)
+	if err := validate(command); err != nil {
+		return err
+	}
+
+	existing := s.load(guildID)
+	if _, replaces := existing[command.Name]; !replaces && len(existing) >= MaxPerGuild {
+		return i18n.Errorf("custom.error.full", MaxPerGuild)
+	}
+
+	err := s.repo.SaveCustomCommand(&models.CustomCommand{
+		GuildID:   guildID,
+		Name:      command.Name,
+		Kind:      string(command.Kind),
+		Content:   command.Content,
+		CreatedBy: command.CreatedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save custom command: %w", err)
+	}
+
+	s.invalidate(guildID)
+	s.logger.Info("Custom command saved", map[string]interface{}{
+		"guild_id":   guildID,
+		"name":       command.Name,
+		"kind":       string(command.Kind),
+		"created_by": command.CreatedBy,
+	})
+	return nil
+}
+
+// Remove deletes a custom command; returns false if none had that name
+func (s *Service) Remove(guildID, name, removedBy string) (bool, error) {
+	if s.repo == nil {
+		return false, fmt.Errorf("custom command storage is not available")
+	}
+
+	name = strings.ToLower(name)
+	removed, err := s.repo.DeleteCustomCommand(guildID, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove custom command: %w", err)
+	}
+
+	s.invalidate(guildID)
+	s.logger.Info("Custom command removed", map[string]interface{}{
+		"guild_id":   guildID,
+		"name":       name,
+		"removed":    removed,
+		"removed_by": removedBy,
+	})
+	return removed > 0, nil
+}
+
+// validate checks a definition before it is stored
+func validate(command Command) error {
+	if len(command.Name) > maxNameLength || !validName.MatchString(command.Name) {
+		return i18n.Errorf("custom.error.name", maxNameLength)
+	}
+	if command.Content == "" {
+		return i18n.Errorf("custom.error.empty")
+	}
+
+	switch command.Kind {
+	case KindAlias:
+		if strings.Fields(command.Content)[0] == command.Name {
+			return i18n.Errorf("custom.error.self_alias")
+		}
+	case KindText:
+		if len([]rune(command.Content)) > maxTextLength {
+			return i18n.Errorf("custom.error.text_length", maxTextLength)
+		}
+	case KindEmbed:
+		if len([]rune(command.Content)) > maxEmbedLength {
+			return i18n.Errorf("custom.error.embed_length", maxEmbedLength)
+		}
+	default:
+		return i18n.Errorf("custom.error.unknown_kind", command.Kind)
+	}
+	return nil
+}
+
+// load returns a guild's custom commands, loading them into the cache if needed
+func (s *Service) load(guildID string) map[string]Command {
+	s.mu.RLock()
+	cached, exists := s.commands[guildID]
+	s.mu.RUnlock()
+	if exists {
+		return cached
+	}
+
+	loaded := make(map[string]Command)
+	if s.repo != nil && guildID != "" {
+		rows, err := s.repo.GetCustomCommandsByGuildID(guildID)
+		if err != nil {
+			// Don't cache failures so the next lookup retries the database
+			s.logger.Error("Failed to load custom commands", err, map[string]interface{}{
+				"guild_id": guildID,
+			})
+			return loaded
+		}
+		for _, row := range rows {
+			loaded[row.Name] = Command{
+				Name:      row.Name,
+				Kind:      Kind(row.Kind),
+				Content:   row.Content,
+				CreatedBy: row.CreatedBy,
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.commands[guildID] = loaded
+	s.mu.Unlock()
+	return loaded
+}
+
+// invalidate drops the cached commands for a guild
+func (s *Service) invalidate(guildID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.commands, guildID)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/internal/commands"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 )
 
 func MessageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
@@ -36,50 +37,16 @@ func MessageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
 		}
 	}
 
-	// Check if the message is a command
-	if m.Content != "" && strings.HasPrefix(m.Content, "!") {
+	// Check if the message is a command, using the guild's configured prefix
+	prefix := commands.GuildSettings(m.GuildID).Prefix
+	if m.Content != "" && strings.HasPrefix(m.Content, prefix) {
 		args := strings.Split(m.Content, " ")
-		command := strings.TrimPrefix(args[0], "!")
+		command := strings.TrimPrefix(args[0], prefix)
 
-		switch command {
-		case "help", "h":
-			commands.ShowHelpCommand(s, m)
-		case "play", "p":
-			commands.PlayCommand(s, m, args[1:])
-		case "pause":
-			commands.PauseCommand(s, m)
-		case "resume":
-			commands.ResumeCommand(s, m)
-		case "skip":
-			commands.SkipCommand(s, m)
-		case "stop":
-			commands.StopCommand(s, m, args[1:])
-		case "servers":
-			commands.ServersCommand(s, m)
-		case "leave":
-			commands.LeaveCommand(s, m, args[1:])
-		case "queue", "q":
-			commands.QueueCommand(s, m, args[1:])
-		case "clear":
-			commands.ClearCommand(s, m, args[1:])
-		case "shuffle":
-			commands.ShuffleCommand(s, m, args[1:])
-		case "about":
-			commands.AboutCommand(s, m)
-		case "nowplaying", "np":
-			commands.NowPlayingCommand(s, m)
-		case "gremlin":
-			commands.GremlinCommand(s, m)
-		case "uma":
-			commands.UmaCommand(s, m, args[1:])
-		case "utility":
-			commands.UtilityCommand(s, m, args[1:])
-		case "delete":
-			commands.DeleteCommand(s, m, args[1:])
-		case "version", "v":
-			commands.VersionCommand(s, m)
-		default:
-			s.ChannelMessageSend(m.ChannelID, "Unknown command. Try `!help` to see all available commands.")
+		// The registry checks the permission policy before running the command;
+		// guild custom commands and aliases are only tried when no built-in matches
+		if !commands.DispatchMessage(s, m, command, args[1:]) && !commands.DispatchCustom(s, m, command, args[1:]) {
+			s.ChannelMessageSend(m.ChannelID, i18n.T(commands.GuildSettings(m.GuildID).Language, "commands.unknown", prefix))
 		}
 	}
 }
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/commands"
+)
+
+// VoiceStateUpdateHandler handles users joining, leaving and moving between voice channels
+func VoiceStateUpdateHandler(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	// Add comprehensive nil checks
+	if s == nil || v == nil || v.VoiceState == nil {
+		return
+	}
+
+	// Remove skip votes from users who left the bot's channel
+	commands.HandleVoteSkipVoiceStateUpdate(s, v)
+
+	// Pause, resume or leave as listeners come and go
+	commands.HandleVoiceStateUpdate(s, v)
+}
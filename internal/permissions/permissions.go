@@ -0,0 +1,321 @@
+package permissions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// Capability is a named permission that commands declare and guilds map to roles
+type Capability string
+
+const (
+	// None marks commands that anyone may run
+	None Capability = ""
+
+	QueueAdd          Capability = "queue.add"
+	PlaybackControl   Capability = "playback.control"
+	QueueClear        Capability = "queue.clear"
+	ModerationDelete  Capability = "moderation.delete"
+	PermissionsManage Capability = "permissions.manage"
+	CustomManage      Capability = "custom.manage"
+	UmaAliasManage    Capability = "uma.alias"
+
+	// SettingsManage always follows the Manage Server permission; it can't be mapped to roles
+	SettingsManage Capability = "settings.manage"
+
+	// owner.* capabilities are reserved for the bot owner and cannot be mapped to roles
+	OwnerServers Capability = "owner.servers"
+	OwnerLeave   Capability = "owner.leave"
+	OwnerUtility Capability = "owner.utility"
+)
+
+// Assignable lists the capabilities guilds can map to roles
+var Assignable = []Capability{QueueAdd, PlaybackControl, QueueClear, ModerationDelete, PermissionsManage, CustomManage, UmaAliasManage}
+
+// DJCapabilities are granted together by the DJ role shortcut
+var DJCapabilities = []Capability{PlaybackControl, QueueClear}
+
+// IsOwnerOnly reports whether the capability is restricted to the bot owner
+func (c Capability) IsOwnerOnly() bool {
+	return strings.HasPrefix(string(c), "owner.")
+}
+
+// IsGuildOnly reports whether the capability moderates or configures a guild, so nobody
+// holds it outside one
+func (c Capability) IsGuildOnly() bool {
+	switch c {
+	case ModerationDelete, PermissionsManage, CustomManage, SettingsManage, UmaAliasManage:
+		return true
+	}
+	return false
+}
+
+// Description returns a short human readable summary of the capability in the given language
+func (c Capability) Description(lang string) string {
+	switch c {
+	case QueueAdd, PlaybackControl, QueueClear, ModerationDelete, PermissionsManage, CustomManage, UmaAliasManage, SettingsManage:
+		return i18n.T(lang, "permissions.capability."+string(c))
+	default:
+		if c.IsOwnerOnly() {
+			return i18n.T(lang, "permissions.capability.owner")
+		}
+		return string(c)
+	}
+}
+
+// ParseCapability converts user input into an assignable capability
+func ParseCapability(value string) (Capability, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, capability := range Assignable {
+		if string(capability) == value {
+			return capability, nil
+		}
+	}
+	return None, i18n.Errorf("permissions.error.unknown_capability", value)
+}
+
+// Engine evaluates capability checks against guild role mappings
+type Engine struct {
+	repo    *repository.GuildPermissionRepository
+	ownerID string
+	grants  map[string]map[Capability][]string // guildID -> capability -> role IDs
+	mu      sync.RWMutex
+	logger  logging.Logger
+}
+
+// NewEngine creates a new permission engine; db may be nil, in which case only defaults apply
+func NewEngine(db *gorm.DB, ownerID string) *Engine {
+	var repo *repository.GuildPermissionRepository
+	if db != nil {
+		repo = repository.NewGuildPermissionRepository(db)
+	}
+
+	return &Engine{
+		repo:    repo,
+		ownerID: ownerID,
+		grants:  make(map[string]map[Capability][]string),
+		logger:  logging.GetGlobalLoggerFactory().CreateLogger("permissions"),
+	}
+}
+
+// IsOwner reports whether the user is the bot owner
+func (e *Engine) IsOwner(userID string) bool {
+	return e.ownerID != "" && userID == e.ownerID
+}
+
+// Check reports whether a user holds a capability in a guild.
+//
+// The bot owner holds every capability and owner.* capabilities are held by nobody else.
+// Outside a guild only capabilities that don't moderate or configure one are held.
+// Guild owners and administrators hold every other capability. When a guild has mapped
+// roles to a capability, only members with one of those roles hold it; otherwise the
+// capability's default rule applies.
+func (e *Engine) Check(s *discordgo.Session, guildID, userID string, capability Capability) bool {
+	if capability == None || e.IsOwner(userID) {
+		return true
+	}
+	if capability.IsOwnerOnly() {
+		return false
+	}
+	if guildID == "" {
+		return !capability.IsGuildOnly()
+	}
+
+	member, perms, isGuildOwner := memberPermissions(s, guildID, userID)
+	if isGuildOwner || perms&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	if roles := e.rolesFor(guildID, capability); len(roles) > 0 {
+		return hasAnyRole(member, roles)
+	}
+
+	return defaultAllows(capability, perms)
+}
+
+// HasGrant reports whether a user holds a capability through elevated access rather
+// than a default rule: bot owner, guild owner, administrator or a mapped role.
+// Used to let DJs bypass checks meant for regular listeners.
+func (e *Engine) HasGrant(s *discordgo.Session, guildID, userID string, capability Capability) bool {
+	if e.IsOwner(userID) {
+		return true
+	}
+	if guildID == "" || capability.IsOwnerOnly() {
+		return false
+	}
+
+	member, perms, isGuildOwner := memberPermissions(s, guildID, userID)
+	if isGuildOwner || perms&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	return hasAnyRole(member, e.rolesFor(guildID, capability))
+}
+
+// IsGuildAdmin reports whether the user is the guild owner or has the Administrator permission
+func (e *Engine) IsGuildAdmin(s *discordgo.Session, guildID, userID string) bool {
+	_, perms, isGuildOwner := memberPermissions(s, guildID, userID)
+	return isGuildOwner || perms&discordgo.PermissionAdministrator != 0
+}
+
+// Grant maps a capability to a role in a guild
+func (e *Engine) Grant(guildID string, capability Capability, roleID, grantedBy string) error {
+	if e.repo == nil {
+		return fmt.Errorf("permission storage is not available")
+	}
+
+	err := e.repo.GrantPermission(&models.GuildPermission{
+		GuildID:    guildID,
+		Capability: string(capability),
+		RoleID:     roleID,
+		GrantedBy:  grantedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save permission: %w", err)
+	}
+
+	e.invalidate(guildID)
+	e.logger.Info("Capability granted to role", map[string]interface{}{
+		"guild_id":   guildID,
+		"capability": string(capability),
+		"role_id":    roleID,
+		"granted_by": grantedBy,
+	})
+	return nil
+}
+
+// Revoke removes a capability mapping from a role; returns false if none existed
+func (e *Engine) Revoke(guildID string, capability Capability, roleID string) (bool, error) {
+	if e.repo == nil {
+		return false, fmt.Errorf("permission storage is not available")
+	}
+
+	removed, err := e.repo.RevokePermission(guildID, string(capability), roleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove permission: %w", err)
+	}
+
+	e.invalidate(guildID)
+	e.logger.Info("Capability revoked from role", map[string]interface{}{
+		"guild_id":   guildID,
+		"capability": string(capability),
+		"role_id":    roleID,
+		"removed":    removed,
+	})
+	return removed > 0, nil
+}
+
+// Grants returns the capability to role mappings for a guild
+func (e *Engine) Grants(guildID string) map[Capability][]string {
+	e.mu.RLock()
+	cached, exists := e.grants[guildID]
+	e.mu.RUnlock()
+	if exists {
+		return cached
+	}
+
+	loaded := make(map[Capability][]string)
+	if e.repo != nil {
+		rows, err := e.repo.GetPermissionsByGuildID(guildID)
+		if err != nil {
+			// Don't cache failures so the next check retries the database
+			e.logger.Error("Failed to load guild permissions", err, map[string]interface{}{
+				"guild_id": guildID,
+			})
+			return loaded
+		}
+		for _, row := range rows {
+			capability := Capability(row.Capability)
+			loaded[capability] = append(loaded[capability], row.RoleID)
+		}
+	}
+
+	e.mu.Lock()
+	e.grants[guildID] = loaded
+	e.mu.Unlock()
+	return loaded
+}
+
+// rolesFor returns the roles mapped to a capability in a guild
+func (e *Engine) rolesFor(guildID string, capability Capability) []string {
+	return e.Grants(guildID)[capability]
+}
+
+// invalidate drops the cached mappings for a guild
+func (e *Engine) invalidate(guildID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.grants, guildID)
+}
+
+// defaultAllows applies the built-in rule for capabilities without role mappings
+func defaultAllows(capability Capability, perms int64) bool {
+	switch capability {
+	case QueueAdd, PlaybackControl, QueueClear:
+		return true
+	case ModerationDelete, UmaAliasManage:
+		return perms&discordgo.PermissionManageMessages != 0
+	case PermissionsManage, CustomManage, SettingsManage:
+		return perms&discordgo.PermissionManageServer != 0
+	default:
+		return false
+	}
+}
+
+// memberPermissions resolves a member and their guild-level permission bits
+func memberPermissions(s *discordgo.Session, guildID, userID string) (*discordgo.Member, int64, bool) {
+	if s == nil || guildID == "" {
+		return nil, 0, false
+	}
+
+	member, err := s.State.Member(guildID, userID)
+	if err != nil {
+		member, err = s.GuildMember(guildID, userID)
+		if err != nil {
+			return nil, 0, false
+		}
+	}
+
+	isGuildOwner := false
+	if guild, err := s.State.Guild(guildID); err == nil {
+		isGuildOwner = guild.OwnerID == userID
+	}
+
+	// @everyone shares the guild's ID
+	var perms int64
+	if everyone, err := s.State.Role(guildID, guildID); err == nil {
+		perms |= everyone.Permissions
+	}
+	for _, roleID := range member.Roles {
+		role, err := s.State.Role(guildID, roleID)
+		if err != nil {
+			continue
+		}
+		perms |= role.Permissions
+	}
+
+	return member, perms, isGuildOwner
+}
+
+// hasAnyRole reports whether the member has at least one of the given roles
+func hasAnyRole(member *discordgo.Member, roles []string) bool {
+	if member == nil {
+		return false
+	}
+	for _, memberRole := range member.Roles {
+		for _, role := range roles {
+			if memberRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -7,46 +7,52 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/shard"
 )
 
-var (
-	currentPresence string
-	presenceMutex   sync.RWMutex
-)
-
-// PresenceManager manages the bot's presence
+// PresenceManager manages the bot's presence on every shard this process runs.
+// Each shard shows its own presence, so music playing in one guild only shows up
+// on that guild's shard.
 type PresenceManager struct {
-	session *discordgo.Session
+	shards *shard.Manager
+
+	mutex    sync.RWMutex
+	presence map[int]string    // Current presence type by shard ID
+	playing  map[string]string // Song shown for each guild playing music
 }
 
 // NewPresenceManager creates a new presence manager
-func NewPresenceManager(session *discordgo.Session) *PresenceManager {
+func NewPresenceManager(shards *shard.Manager) *PresenceManager {
 	return &PresenceManager{
-		session: session,
+		shards:   shards,
+		presence: make(map[int]string),
+		playing:  make(map[string]string),
 	}
 }
 
-// UpdateDefaultPresence updates the bot's presence with server statistics
+// UpdateDefaultPresence updates every shard's presence with its server statistics
 func (pm *PresenceManager) UpdateDefaultPresence() {
-	// Get all guilds (servers) the bot is in
-	guilds := pm.session.State.Guilds
-	if len(guilds) == 0 {
-		return
+	for _, session := range pm.shards.Sessions() {
+		pm.updateShardDefaultPresence(session)
 	}
+}
 
-	// Count total channels across all servers
+// updateShardDefaultPresence shows the servers and channels a single shard serves
+func (pm *PresenceManager) updateShardDefaultPresence(session *discordgo.Session) {
+	// Get the guilds (servers) this shard is in
+	session.State.RLock()
+	guildCount := len(session.State.Guilds)
 	totalChannels := 0
-	for _, guild := range guilds {
+	for _, guild := range session.State.Guilds {
 		if guild != nil {
-			// Count text channels
-			channels, err := pm.session.GuildChannels(guild.ID)
-			if err != nil {
-				log.Printf("Error getting channels for guild %s: %v", guild.ID, err)
-				continue
-			}
-			totalChannels += len(channels)
+			totalChannels += len(guild.Channels)
 		}
 	}
+	session.State.RUnlock()
+
+	if guildCount == 0 {
+		return
+	}
 
 	// Create presence data
 	presence := &discordgo.UpdateStatusData{
@@ -55,25 +61,32 @@ func (pm *PresenceManager) UpdateDefaultPresence() {
 			{
 				Name:  "Over " + strconv.Itoa(totalChannels) + " channels",
 				Type:  discordgo.ActivityTypeWatching,
-				State: "in " + strconv.Itoa(len(guilds)) + " servers",
+				State: "in " + strconv.Itoa(guildCount) + " servers",
 			},
 		},
 	}
 
-	// Update the bot's presence
-	err := pm.session.UpdateStatusComplex(*presence)
+	// Update the shard's presence
+	err := session.UpdateStatusComplex(*presence)
 	if err != nil {
-		log.Printf("Failed to update bot presence: %v", err)
+		log.Printf("Failed to update bot presence on shard %d: %v", session.ShardID, err)
 	}
 
-	presenceMutex.Lock()
-	currentPresence = "default"
-	presenceMutex.Unlock()
+	pm.setPresence(session.ShardID, "default")
 }
 
-// UpdateMusicPresence updates the bot's presence to show currently playing music
-func (pm *PresenceManager) UpdateMusicPresence(songTitle string) {
-	log.Printf("UpdateMusicPresence called with song: %s", songTitle)
+// UpdateMusicPresence shows the song playing in a guild on that guild's shard
+func (pm *PresenceManager) UpdateMusicPresence(guildID, songTitle string) {
+	session := pm.shards.SessionForGuild(guildID)
+	if session == nil {
+		return
+	}
+
+	log.Printf("UpdateMusicPresence called on shard %d with song: %s", session.ShardID, songTitle)
+
+	pm.mutex.Lock()
+	pm.playing[guildID] = songTitle
+	pm.mutex.Unlock()
 
 	presence := &discordgo.UpdateStatusData{
 		Status: "online",
@@ -85,28 +98,54 @@ func (pm *PresenceManager) UpdateMusicPresence(songTitle string) {
 		},
 	}
 
-	err := pm.session.UpdateStatusComplex(*presence)
+	err := session.UpdateStatusComplex(*presence)
 	if err != nil {
 		log.Printf("Failed to update music presence: %v", err)
 	} else {
 		log.Printf("Successfully updated music presence to: %s", songTitle)
 	}
 
-	presenceMutex.Lock()
-	currentPresence = "music"
-	presenceMutex.Unlock()
+	pm.setPresence(session.ShardID, "music")
+}
+
+// ClearMusicPresence stops showing a guild's music. A shard serves many guilds, so it
+// only returns to the default presence once none of them is playing; otherwise it
+// shows what another guild on the shard is playing.
+func (pm *PresenceManager) ClearMusicPresence(guildID string) {
+	session := pm.shards.SessionForGuild(guildID)
+
+	pm.mutex.Lock()
+	delete(pm.playing, guildID)
+	otherGuildID, otherSong := "", ""
+	for playingGuildID, songTitle := range pm.playing {
+		if session != nil && pm.shards.SessionForGuild(playingGuildID) == session {
+			otherGuildID, otherSong = playingGuildID, songTitle
+			break
+		}
+	}
+	pm.mutex.Unlock()
+
+	if session == nil {
+		return
+	}
+	if otherGuildID != "" {
+		pm.UpdateMusicPresence(otherGuildID, otherSong)
+		return
+	}
+	pm.updateShardDefaultPresence(session)
 }
 
-// ClearMusicPresence clears the music presence and returns to default
-func (pm *PresenceManager) ClearMusicPresence() {
-	pm.UpdateDefaultPresence()
+// GetCurrentPresence returns the current presence type of a shard
+func (pm *PresenceManager) GetCurrentPresence(shardID int) string {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+	return pm.presence[shardID]
 }
 
-// GetCurrentPresence returns the current presence type
-func (pm *PresenceManager) GetCurrentPresence() string {
-	presenceMutex.RLock()
-	defer presenceMutex.RUnlock()
-	return currentPresence
+func (pm *PresenceManager) setPresence(shardID int, presence string) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	pm.presence[shardID] = presence
 }
 
 // StartPeriodicUpdates starts a goroutine that updates the default presence periodically
@@ -116,9 +155,11 @@ func (pm *PresenceManager) StartPeriodicUpdates() {
 		defer ticker.Stop()
 
 		for range ticker.C {
-			// Only update if we're not showing music
-			if pm.GetCurrentPresence() != "music" {
-				pm.UpdateDefaultPresence()
+			for _, session := range pm.shards.Sessions() {
+				// Only update shards that aren't showing music
+				if pm.GetCurrentPresence(session.ShardID) != "music" {
+					pm.updateShardDefaultPresence(session)
+				}
 			}
 		}
 	}()
@@ -0,0 +1,305 @@
+// Package ratelimit throttles how often users may run commands. Every user gets a
+// token bucket per guild and command, sized by the command's limit or the guild's
+// override of it.
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+const (
+	maxUses = 100
+	maxPer  = 24 * time.Hour
+
+	// Buckets untouched for longer than their window are full again, so they are
+	// dropped this often to keep memory flat
+	sweepInterval = 10 * time.Minute
+)
+
+// Limit allows Uses runs per Per, refilling gradually. The zero Limit is unlimited.
+type Limit struct {
+	Uses int
+	Per  time.Duration
+}
+
+// Unlimited reports whether the limit lets every run through
+func (l Limit) Unlimited() bool {
+	return l.Uses <= 0 || l.Per <= 0
+}
+
+// String renders the limit for display, e.g. "3 per 30s"
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d per %s", l.Uses, formatPer(l.Per))
+}
+
+// Localized renders the limit for display in the language
+func (l Limit) Localized(lang string) string {
+	if l.Unlimited() {
+		return i18n.T(lang, "ratelimit.unlimited")
+	}
+	return i18n.T(lang, "ratelimit.limit", l.Uses, formatPer(l.Per))
+}
+
+// ParseLimit converts user input such as 3/30s, 1/10m or off into a limit
+func ParseLimit(value string) (Limit, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "off" || value == "none" || value == "unlimited" {
+		return Limit{}, nil
+	}
+
+	usesPart, perPart, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, i18n.Errorf("ratelimit.error.format")
+	}
+
+	uses, err := strconv.Atoi(strings.TrimSpace(usesPart))
+	if err != nil || uses < 1 || uses > maxUses {
+		return Limit{}, i18n.Errorf("ratelimit.error.uses", maxUses)
+	}
+
+	per, err := time.ParseDuration(strings.TrimSpace(perPart))
+	if err != nil {
+		return Limit{}, i18n.Errorf("ratelimit.error.duration", perPart)
+	}
+	if per < time.Second || per > maxPer {
+		return Limit{}, i18n.Errorf("ratelimit.error.window", formatPer(maxPer))
+	}
+	return Limit{Uses: uses, Per: per.Round(time.Second)}, nil
+}
+
+// formatPer renders a window in its largest whole unit
+func formatPer(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
+
+// Result is the outcome of a rate limit check
+type Result struct {
+	Allowed bool
+
+	// RetryAfter is how long until the next run is allowed
+	RetryAfter time.Duration
+
+	// Notify is set on the first denial of a cooldown, so users are told once
+	// rather than on every spammed attempt
+	Notify bool
+}
+
+// bucket is one user's token bucket for a command in a guild
+type bucket struct {
+	tokens float64
+	last   time.Time
+	per    time.Duration
+	warned bool
+}
+
+// Limiter tracks token buckets and the guilds' limit overrides
+type Limiter struct {
+	repo      *repository.GuildRateLimitRepository
+	buckets   map[string]*bucket
+	overrides map[string]map[string]Limit // guildID -> bucket -> limit
+	hits      map[string]int64            // bucket -> denied runs
+	lastSweep time.Time
+	mu        sync.Mutex
+	logger    logging.Logger
+}
+
+// NewLimiter creates a limiter; db may be nil, in which case only the commands'
+// default limits apply and overrides can't be saved
+func NewLimiter(db *gorm.DB) *Limiter {
+	var repo *repository.GuildRateLimitRepository
+	if db != nil {
+		repo = repository.NewGuildRateLimitRepository(db)
+	}
+
+	return &Limiter{
+		repo:      repo,
+		buckets:   make(map[string]*bucket),
+		overrides: make(map[string]map[string]Limit),
+		hits:      make(map[string]int64),
+		lastSweep: time.Now(),
+		logger:    logging.GetGlobalLoggerFactory().CreateLogger("ratelimit"),
+	}
+}
+
+// Allow takes a token from the user's bucket for a command, applying the guild's
+// override of the default limit if it has one
+func (l *Limiter) Allow(guildID, userID, name string, defaultLimit Limit) Result {
+	limit := l.Effective(guildID, name, defaultLimit)
+	if limit.Unlimited() {
+		return Result{Allowed: true}
+	}
+
+	now := time.Now()
+	capacity := float64(limit.Uses)
+	rate := capacity / limit.Per.Seconds() // Tokens regained per second
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	key := guildID + ":" + userID + ":" + name
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.per = limit.Per
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.warned = false
+		return Result{Allowed: true}
+	}
+
+	l.hits[name]++
+	result := Result{
+		RetryAfter: time.Duration((1 - b.tokens) / rate * float64(time.Second)),
+		Notify:     !b.warned,
+	}
+	b.warned = true
+	return result
+}
+
+// sweep drops buckets that have refilled completely. Callers hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > b.per {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Hits returns how many runs each command's limit has denied since startup
+func (l *Limiter) Hits() map[string]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hits := make(map[string]int64, len(l.hits))
+	for name, count := range l.hits {
+		hits[name] = count
+	}
+	return hits
+}
+
+// Effective returns the limit that applies to a command in a guild
+func (l *Limiter) Effective(guildID, name string, defaultLimit Limit) Limit {
+	if override, exists := l.Overrides(guildID)[name]; exists {
+		return override
+	}
+	return defaultLimit
+}
+
+// Overrides returns a guild's limit overrides by command
+func (l *Limiter) Overrides(guildID string) map[string]Limit {
+	l.mu.Lock()
+	cached, exists := l.overrides[guildID]
+	l.mu.Unlock()
+	if exists {
+		return cached
+	}
+
+	loaded := make(map[string]Limit)
+	if l.repo != nil && guildID != "" {
+		rows, err := l.repo.GetRateLimitsByGuildID(guildID)
+		if err != nil {
+			// Don't cache failures so the next check retries the database
+			l.logger.Error("Failed to load guild rate limits", err, map[string]interface{}{
+				"guild_id": guildID,
+			})
+			return loaded
+		}
+		for _, row := range rows {
+			loaded[row.Bucket] = Limit{Uses: row.Uses, Per: time.Duration(row.PerSeconds) * time.Second}
+		}
+	}
+
+	l.mu.Lock()
+	l.overrides[guildID] = loaded
+	l.mu.Unlock()
+	return loaded
+}
+
+// SetOverride replaces a command's limit in a guild; an unlimited limit lifts it
+func (l *Limiter) SetOverride(guildID, name string, limit Limit, updatedBy string) error {
+	if l.repo == nil {
+		return fmt.Errorf("rate limit storage is not available")
+	}
+
+	err := l.repo.SaveRateLimit(&models.GuildRateLimit{
+		GuildID:    guildID,
+		Bucket:     name,
+		Uses:       limit.Uses,
+		PerSeconds: int(limit.Per / time.Second),
+		UpdatedBy:  updatedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save rate limit: %w", err)
+	}
+
+	l.invalidate(guildID)
+	l.logger.Info("Rate limit override set", map[string]interface{}{
+		"guild_id":   guildID,
+		"command":    name,
+		"limit":      limit.String(),
+		"updated_by": updatedBy,
+	})
+	return nil
+}
+
+// RemoveOverride restores a command's default limit in a guild; returns false if
+// there was no override
+func (l *Limiter) RemoveOverride(guildID, name, removedBy string) (bool, error) {
+	if l.repo == nil {
+		return false, fmt.Errorf("rate limit storage is not available")
+	}
+
+	removed, err := l.repo.DeleteRateLimit(guildID, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove rate limit: %w", err)
+	}
+
+	l.invalidate(guildID)
+	l.logger.Info("Rate limit override removed", map[string]interface{}{
+		"guild_id":   guildID,
+		"command":    name,
+		"removed":    removed,
+		"removed_by": removedBy,
+	})
+	return removed > 0, nil
+}
+
+// invalidate drops the cached overrides for a guild
+func (l *Limiter) invalidate(guildID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.overrides, guildID)
+}
@@ -0,0 +1,436 @@
+// Package settings stores per-guild configuration and keeps it cached for the
+// message handler and the commands.
+package settings
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// Key names a guild setting
+type Key string
+
+const (
+	Prefix            Key = "prefix"
+	AnnounceChannel   Key = "announce_channel"
+	Language          Key = "language"
+	IdleTimeout       Key = "idle_timeout"
+	MaxQueueLength    Key = "max_queue_length"
+	MusicChannels     Key = "music_channels"
+	QueueMode         Key = "queue_mode"
+	MaxUserSongs      Key = "max_user_songs"
+	MaxUserDuration   Key = "max_user_duration"
+	VoteSkipThreshold Key = "vote_skip_threshold"
+)
+
+// Keys lists every setting in display order
+var Keys = []Key{Prefix, AnnounceChannel, Language, IdleTimeout, MaxQueueLength, MusicChannels, QueueMode, MaxUserSongs, MaxUserDuration, VoteSkipThreshold}
+
+// Languages the bot can be set to, one per message catalog
+var Languages = i18n.Languages
+
+const (
+	DefaultPrefix      = "!"
+	DefaultLanguage    = i18n.Default
+	DefaultIdleTimeout = 5 * time.Minute
+
+	maxPrefixLength   = 5
+	minIdleTimeout    = time.Minute
+	maxIdleTimeout    = 2 * time.Hour
+	maxQueueLengthCap = 1000
+	maxUserDuration   = 24 * time.Hour
+	maxMusicChannels  = 25
+
+	// Music channel IDs are stored as one comma-separated column
+	channelSeparator = ","
+)
+
+// snowflake matches a Discord ID
+var snowflake = regexp.MustCompile(`^\d{17,20}$`)
+
+// Description returns a short human readable summary of the setting in the language
+func (k Key) Description(lang string) string {
+	switch k {
+	case Language:
+		return i18n.T(lang, "settings.key.language", strings.Join(Languages, ", "))
+	case Prefix, AnnounceChannel, IdleTimeout, MaxQueueLength, MusicChannels, QueueMode, MaxUserSongs, MaxUserDuration, VoteSkipThreshold:
+		return i18n.T(lang, "settings.key."+string(k))
+	default:
+		return string(k)
+	}
+}
+
+// ParseKey converts user input into a setting key
+func ParseKey(value string) (Key, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, key := range Keys {
+		if string(key) == value || strings.ReplaceAll(string(key), "_", "") == value {
+			return key, nil
+		}
+	}
+	return "", i18n.Errorf("settings.error.unknown", value)
+}
+
+// Settings is a guild's effective configuration
+type Settings struct {
+	GuildID           string
+	Prefix            string
+	AnnounceChannelID string // Empty announces where the music was requested
+	Language          string
+	IdleTimeout       time.Duration
+	MaxQueueLength    int      // 0 = unlimited
+	MusicChannelIDs   []string // Empty allows music commands everywhere
+	QueueMode         common.QueueMode
+	MaxUserSongs      int           // 0 = unlimited
+	MaxUserDuration   time.Duration // 0 = unlimited
+	VoteSkipThreshold int           // Percentage of listeners; 0 uses the bot's default
+}
+
+// Defaults returns the settings of a guild that never changed any
+func Defaults(guildID string) Settings {
+	return Settings{
+		GuildID:     guildID,
+		Prefix:      DefaultPrefix,
+		Language:    DefaultLanguage,
+		IdleTimeout: DefaultIdleTimeout,
+		QueueMode:   common.QueueModeFIFO,
+	}
+}
+
+// AllowsMusicIn reports whether music commands may be used in a text channel
+func (s Settings) AllowsMusicIn(channelID string) bool {
+	if len(s.MusicChannelIDs) == 0 {
+		return true
+	}
+	for _, id := range s.MusicChannelIDs {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns a setting in its stored form, as recorded in the audit trail
+func (s Settings) Value(key Key) string {
+	switch key {
+	case Prefix:
+		return s.Prefix
+	case AnnounceChannel:
+		return s.AnnounceChannelID
+	case Language:
+		return s.Language
+	case IdleTimeout:
+		return s.IdleTimeout.String()
+	case MaxQueueLength:
+		return strconv.Itoa(s.MaxQueueLength)
+	case MusicChannels:
+		return strings.Join(s.MusicChannelIDs, channelSeparator)
+	case QueueMode:
+		return string(s.QueueMode)
+	case MaxUserSongs:
+		return strconv.Itoa(s.MaxUserSongs)
+	case MaxUserDuration:
+		return s.MaxUserDuration.String()
+	case VoteSkipThreshold:
+		return strconv.Itoa(s.VoteSkipThreshold)
+	default:
+		return ""
+	}
+}
+
+// apply validates a value and sets it. Channels must already be resolved to IDs.
+func (s *Settings) apply(key Key, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case Prefix:
+		if value == "" || len([]rune(value)) > maxPrefixLength || strings.IndexFunc(value, unicode.IsSpace) >= 0 || strings.Contains(value, "`") {
+			return i18n.Errorf("settings.error.prefix", maxPrefixLength)
+		}
+		s.Prefix = value
+	case AnnounceChannel:
+		if value != "" && !snowflake.MatchString(value) {
+			return i18n.Errorf("settings.error.not_channel", value)
+		}
+		s.AnnounceChannelID = value
+	case Language:
+		value = strings.ToLower(value)
+		for _, language := range Languages {
+			if language == value {
+				s.Language = value
+				return nil
+			}
+		}
+		return i18n.Errorf("settings.error.language", strings.Join(Languages, ", "))
+	case IdleTimeout:
+		timeout, err := parseIdleTimeout(value)
+		if err != nil {
+			return err
+		}
+		s.IdleTimeout = timeout
+	case MaxQueueLength:
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 0 || length > maxQueueLengthCap {
+			return i18n.Errorf("settings.error.max_queue_length", maxQueueLengthCap)
+		}
+		s.MaxQueueLength = length
+	case MusicChannels:
+		var ids []string
+		for _, id := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			if !snowflake.MatchString(id) {
+				return i18n.Errorf("settings.error.not_channel", id)
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) > maxMusicChannels {
+			return i18n.Errorf("settings.error.music_channels", maxMusicChannels)
+		}
+		s.MusicChannelIDs = ids
+	case QueueMode:
+		mode, err := common.ParseQueueMode(value)
+		if err != nil {
+			return err
+		}
+		s.QueueMode = mode
+	case MaxUserSongs:
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 || count > maxQueueLengthCap {
+			return i18n.Errorf("settings.error.max_user_songs", maxQueueLengthCap)
+		}
+		s.MaxUserSongs = count
+	case MaxUserDuration:
+		duration, err := parseUserDuration(value)
+		if err != nil {
+			return err
+		}
+		s.MaxUserDuration = duration
+	case VoteSkipThreshold:
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || percent < 0 || percent > 100 {
+			return i18n.Errorf("settings.error.vote_skip_threshold")
+		}
+		s.VoteSkipThreshold = percent
+	default:
+		return i18n.Errorf("settings.error.unknown", key)
+	}
+	return nil
+}
+
+// parseIdleTimeout accepts a duration such as 10m or 1h30m, or a plain number of minutes
+func parseIdleTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		minutes, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, i18n.Errorf("settings.error.idle_timeout_format")
+		}
+		timeout = time.Duration(minutes) * time.Minute
+	}
+	if timeout < minIdleTimeout || timeout > maxIdleTimeout {
+		return 0, i18n.Errorf("settings.error.idle_timeout_range", minIdleTimeout, maxIdleTimeout)
+	}
+	return timeout.Round(time.Second), nil
+}
+
+// parseUserDuration accepts a duration such as 45m or 1h30m, or 0 to remove the cap
+func parseUserDuration(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 || duration > maxUserDuration {
+		return 0, i18n.Errorf("settings.error.max_user_duration", maxUserDuration)
+	}
+	return duration.Round(time.Second), nil
+}
+
+// Service loads guild settings from the database and caches them
+type Service struct {
+	repo   *repository.GuildSettingsRepository
+	cache  map[string]Settings
+	mu     sync.RWMutex
+	logger logging.Logger
+}
+
+// NewService creates a settings service; db may be nil, in which case every guild uses
+// the defaults and changes can't be saved
+func NewService(db *gorm.DB) *Service {
+	var repo *repository.GuildSettingsRepository
+	if db != nil {
+		repo = repository.NewGuildSettingsRepository(db)
+	}
+
+	return &Service{
+		repo:   repo,
+		cache:  make(map[string]Settings),
+		logger: logging.GetGlobalLoggerFactory().CreateLogger("settings"),
+	}
+}
+
+// Get returns a guild's settings
+func (s *Service) Get(guildID string) Settings {
+	if guildID == "" {
+		return Defaults(guildID)
+	}
+
+	s.mu.RLock()
+	cached, exists := s.cache[guildID]
+	s.mu.RUnlock()
+	if exists {
+		return cached
+	}
+
+	loaded := Defaults(guildID)
+	if s.repo != nil {
+		row, err := s.repo.GetSettingsByGuildID(guildID)
+		if err != nil {
+			// Don't cache failures so the next lookup retries the database
+			s.logger.Error("Failed to load guild settings", err, map[string]interface{}{
+				"guild_id": guildID,
+			})
+			return loaded
+		}
+		if row != nil {
+			loaded = fromModel(row)
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[guildID] = loaded
+	s.mu.Unlock()
+	return loaded
+}
+
+// Set changes one setting, returning the updated settings
+func (s *Service) Set(guildID string, key Key, value, changedBy string) (Settings, error) {
+	current := s.Get(guildID)
+	updated := current
+	updated.MusicChannelIDs = append([]string(nil), current.MusicChannelIDs...)
+	if err := updated.apply(key, value); err != nil {
+		return current, err
+	}
+	return updated, s.save(current, updated, []Key{key}, changedBy)
+}
+
+// Reset restores one setting, or every setting when key is empty, to its default
+func (s *Service) Reset(guildID string, key Key, changedBy string) (Settings, error) {
+	current := s.Get(guildID)
+	defaults := Defaults(guildID)
+	if key == "" {
+		return defaults, s.save(current, defaults, Keys, changedBy)
+	}
+
+	updated := current
+	if err := updated.apply(key, defaults.Value(key)); err != nil {
+		return current, err
+	}
+	return updated, s.save(current, updated, []Key{key}, changedBy)
+}
+
+// History returns the guild's most recent setting changes, newest first
+func (s *Service) History(guildID string, limit int) ([]models.GuildSettingsAudit, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("settings storage is not available")
+	}
+	return s.repo.GetAuditByGuildID(guildID, limit)
+}
+
+// save stores the updated settings and an audit entry for every key that changed
+func (s *Service) save(current, updated Settings, keys []Key, changedBy string) error {
+	if s.repo == nil {
+		return fmt.Errorf("settings storage is not available")
+	}
+
+	var audits []models.GuildSettingsAudit
+	for _, key := range keys {
+		oldValue, newValue := current.Value(key), updated.Value(key)
+		if oldValue == newValue {
+			continue
+		}
+		audits = append(audits, models.GuildSettingsAudit{
+			GuildID:   updated.GuildID,
+			Key:       string(key),
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			ChangedBy: changedBy,
+		})
+	}
+	if len(audits) == 0 {
+		return nil
+	}
+
+	if err := s.repo.SaveSettings(toModel(updated, changedBy), audits); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cache[updated.GuildID] = updated
+	s.mu.Unlock()
+
+	for _, audit := range audits {
+		s.logger.Info("Guild setting changed", map[string]interface{}{
+			"guild_id":   audit.GuildID,
+			"key":        audit.Key,
+			"old_value":  audit.OldValue,
+			"new_value":  audit.NewValue,
+			"changed_by": changedBy,
+		})
+	}
+	return nil
+}
+
+// fromModel converts a stored row to settings, falling back to defaults for unset values
+func fromModel(row *models.GuildSettings) Settings {
+	settings := Defaults(row.GuildID)
+	if row.Prefix != "" {
+		settings.Prefix = row.Prefix
+	}
+	if row.Language != "" {
+		settings.Language = row.Language
+	}
+	if row.IdleTimeoutSeconds > 0 {
+		settings.IdleTimeout = time.Duration(row.IdleTimeoutSeconds) * time.Second
+	}
+	settings.AnnounceChannelID = row.AnnounceChannelID
+	settings.MaxQueueLength = row.MaxQueueLength
+	if row.MusicChannelIDs != "" {
+		settings.MusicChannelIDs = strings.Split(row.MusicChannelIDs, channelSeparator)
+	}
+	if mode, err := common.ParseQueueMode(row.QueueMode); err == nil {
+		settings.QueueMode = mode
+	}
+	settings.MaxUserSongs = row.MaxUserSongs
+	settings.MaxUserDuration = time.Duration(row.MaxUserDurationSeconds) * time.Second
+	settings.VoteSkipThreshold = row.VoteSkipThreshold
+	return settings
+}
+
+// toModel converts settings to a row for storage
+func toModel(settings Settings, updatedBy string) *models.GuildSettings {
+	return &models.GuildSettings{
+		GuildID:                settings.GuildID,
+		Prefix:                 settings.Prefix,
+		AnnounceChannelID:      settings.AnnounceChannelID,
+		Language:               settings.Language,
+		IdleTimeoutSeconds:     int(settings.IdleTimeout / time.Second),
+		MaxQueueLength:         settings.MaxQueueLength,
+		MusicChannelIDs:        strings.Join(settings.MusicChannelIDs, channelSeparator),
+		QueueMode:              string(settings.QueueMode),
+		MaxUserSongs:           settings.MaxUserSongs,
+		MaxUserDurationSeconds: int(settings.MaxUserDuration / time.Second),
+		VoteSkipThreshold:      settings.VoteSkipThreshold,
+		UpdatedBy:              updatedBy,
+	}
+}
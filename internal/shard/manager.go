@@ -0,0 +1,204 @@
+// Package shard runs the Discord gateway connections this process owns and routes
+// guilds to the shard that receives their events.
+package shard
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// identifyInterval spaces out shard logins; Discord allows one identify per 5 seconds
+const identifyInterval = 5 * time.Second
+
+// Status is a snapshot of one shard's gateway connection
+type Status struct {
+	ID        int    `json:"id"`
+	Connected bool   `json:"connected"`
+	Guilds    int    `json:"guilds"`
+	Latency   string `json:"latency"`
+}
+
+// Manager holds one discordgo session per shard owned by this process
+type Manager struct {
+	count    int
+	ids      []int // Sorted shard IDs owned here
+	sessions map[int]*discordgo.Session
+}
+
+// NewManager creates a session for each of the given shard IDs out of count shards.
+// No IDs means this process owns every shard.
+func NewManager(token string, count int, ids []int) (*Manager, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("shard count must be at least 1, got %d", count)
+	}
+	if len(ids) == 0 {
+		for id := 0; id < count; id++ {
+			ids = append(ids, id)
+		}
+	}
+
+	manager := &Manager{
+		count:    count,
+		sessions: make(map[int]*discordgo.Session),
+	}
+	for _, id := range ids {
+		if id < 0 || id >= count {
+			return nil, fmt.Errorf("shard ID %d is outside 0-%d", id, count-1)
+		}
+		if _, exists := manager.sessions[id]; exists {
+			continue
+		}
+
+		session, err := discordgo.New(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create session for shard %d: %w", id, err)
+		}
+		session.ShardID = id
+		session.ShardCount = count
+
+		manager.sessions[id] = session
+		manager.ids = append(manager.ids, id)
+	}
+	sort.Ints(manager.ids)
+
+	return manager, nil
+}
+
+// AddHandler registers an event handler on every shard
+func (m *Manager) AddHandler(handler interface{}) {
+	for _, session := range m.Sessions() {
+		session.AddHandler(handler)
+	}
+}
+
+// Open connects the shards one after another, closing the ones already open if any fails
+func (m *Manager) Open() error {
+	for i, id := range m.ids {
+		if i > 0 {
+			time.Sleep(identifyInterval)
+		}
+
+		log.Printf("Opening shard %d/%d", id, m.count)
+		if err := m.sessions[id].Open(); err != nil {
+			m.Close()
+			return fmt.Errorf("failed to open shard %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Close disconnects every shard
+func (m *Manager) Close() {
+	for _, id := range m.ids {
+		if err := m.sessions[id].Close(); err != nil {
+			log.Printf("Error closing shard %d: %v", id, err)
+		}
+	}
+}
+
+// Count returns the total number of shards across all processes
+func (m *Manager) Count() int {
+	return m.count
+}
+
+// Sessions returns the sessions of the shards owned here, ordered by shard ID
+func (m *Manager) Sessions() []*discordgo.Session {
+	sessions := make([]*discordgo.Session, 0, len(m.ids))
+	for _, id := range m.ids {
+		sessions = append(sessions, m.sessions[id])
+	}
+	return sessions
+}
+
+// SessionForGuild returns the session of the shard that receives a guild's events,
+// or nil when another process owns that shard
+func (m *Manager) SessionForGuild(guildID string) *discordgo.Session {
+	return m.sessions[ForGuild(guildID, m.count)]
+}
+
+// Guilds returns the guilds of every shard owned here
+func (m *Manager) Guilds() []*discordgo.Guild {
+	var guilds []*discordgo.Guild
+	for _, session := range m.Sessions() {
+		session.State.RLock()
+		guilds = append(guilds, session.State.Guilds...)
+		session.State.RUnlock()
+	}
+	return guilds
+}
+
+// Status returns a snapshot of every shard owned here
+func (m *Manager) Status() []Status {
+	statuses := make([]Status, 0, len(m.ids))
+	for _, id := range m.ids {
+		session := m.sessions[id]
+
+		session.RLock()
+		connected := session.DataReady
+		session.RUnlock()
+
+		session.State.RLock()
+		guilds := len(session.State.Guilds)
+		session.State.RUnlock()
+
+		statuses = append(statuses, Status{
+			ID:        id,
+			Connected: connected,
+			Guilds:    guilds,
+			Latency:   session.HeartbeatLatency().Round(time.Millisecond).String(),
+		})
+	}
+	return statuses
+}
+
+// ForGuild returns the shard a guild belongs to, as Discord assigns it
+func ForGuild(guildID string, count int) int {
+	if count <= 1 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return int((id >> 22) % uint64(count))
+}
+
+// ParseIDs parses a shard ID list such as "0,1,4" or "0-3"; empty means every shard
+func ParseIDs(value string, count int) ([]int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard ID %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || end < start {
+				return nil, fmt.Errorf("invalid shard range %q", part)
+			}
+		}
+
+		for id := start; id <= end; id++ {
+			if id < 0 || id >= count {
+				return nil, fmt.Errorf("shard ID %d is outside 0-%d", id, count-1)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,278 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProcessKind is a class of external process the budget caps separately
+type ProcessKind string
+
+const (
+	// ProcessStream is a long-running yt-dlp | ffmpeg pair feeding a voice connection
+	ProcessStream ProcessKind = "stream"
+	// ProcessExtraction is a short yt-dlp run for metadata, stream URLs or searches
+	ProcessExtraction ProcessKind = "extraction"
+)
+
+// ProcessPriority orders requests waiting for a slot; higher goes first
+type ProcessPriority int
+
+const (
+	// PriorityMetadata is for lookups nobody is listening to yet: searches, queueing, autocomplete
+	PriorityMetadata ProcessPriority = iota
+	// PriorityPlayback is for work a listener is waiting on: starting or refreshing a stream
+	PriorityPlayback
+)
+
+const (
+	// DefaultMaxStreams caps concurrent yt-dlp | ffmpeg pairs
+	DefaultMaxStreams = 4
+	// DefaultMaxExtractions caps concurrent yt-dlp metadata runs
+	DefaultMaxExtractions = 3
+	// DefaultBudgetTimeout is how long a request waits for a slot before giving up
+	DefaultBudgetTimeout = 60 * time.Second
+)
+
+// ErrBudgetTimeout is returned when no slot frees up within the wait timeout
+var ErrBudgetTimeout = errors.New("timed out waiting for a free process slot")
+
+// BudgetUsage is a snapshot of one process kind's slots
+type BudgetUsage struct {
+	Kind    ProcessKind `json:"kind"`
+	Active  int         `json:"active"`
+	Limit   int         `json:"limit"`
+	Waiting int         `json:"waiting"`
+}
+
+// ProcessBudget caps how many yt-dlp and ffmpeg processes run at once. Requests over
+// the cap wait in line, playback ahead of metadata, until a slot frees up or they time out.
+type ProcessBudget struct {
+	mu      sync.Mutex
+	pools   map[ProcessKind]*processPool
+	timeout time.Duration
+	seq     uint64
+}
+
+// processPool tracks the slots of one process kind
+type processPool struct {
+	limit   int
+	active  int
+	waiters []*budgetWaiter // Sorted by priority, then arrival
+}
+
+// budgetWaiter is a request waiting for a slot
+type budgetWaiter struct {
+	priority ProcessPriority
+	seq      uint64
+	ready    chan struct{} // Closed once the slot is granted
+}
+
+// NewProcessBudget creates a budget; non-positive values fall back to the defaults
+func NewProcessBudget(maxStreams, maxExtractions int, timeout time.Duration) *ProcessBudget {
+	if maxStreams <= 0 {
+		maxStreams = DefaultMaxStreams
+	}
+	if maxExtractions <= 0 {
+		maxExtractions = DefaultMaxExtractions
+	}
+	if timeout <= 0 {
+		timeout = DefaultBudgetTimeout
+	}
+
+	return &ProcessBudget{
+		pools: map[ProcessKind]*processPool{
+			ProcessStream:     {limit: maxStreams},
+			ProcessExtraction: {limit: maxExtractions},
+		},
+		timeout: timeout,
+	}
+}
+
+// Acquire waits for a slot of the given kind and returns a function that frees it.
+// onQueued, if set, is called with the 1-based place in line when the request has to wait.
+func (b *ProcessBudget) Acquire(ctx context.Context, kind ProcessKind, priority ProcessPriority, onQueued func(position int)) (func(), error) {
+	b.mu.Lock()
+	pool := b.pool(kind)
+
+	// Take a free slot straight away unless someone of the same or higher priority is waiting
+	if pool.active < pool.limit && (len(pool.waiters) == 0 || pool.waiters[0].priority < priority) {
+		pool.active++
+		b.mu.Unlock()
+		return b.releaser(kind), nil
+	}
+
+	b.seq++
+	waiter := &budgetWaiter{priority: priority, seq: b.seq, ready: make(chan struct{})}
+	pool.waiters = append(pool.waiters, waiter)
+	sort.SliceStable(pool.waiters, func(i, j int) bool {
+		if pool.waiters[i].priority != pool.waiters[j].priority {
+			return pool.waiters[i].priority > pool.waiters[j].priority
+		}
+		return pool.waiters[i].seq < pool.waiters[j].seq
+	})
+	position := pool.position(waiter)
+	b.mu.Unlock()
+
+	if onQueued != nil {
+		onQueued(position)
+	}
+
+	timer := time.NewTimer(b.timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-waiter.ready:
+		return b.releaser(kind), nil
+	case <-timer.C:
+		err = ErrBudgetTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The slot may have been granted while we were giving up; hand it on
+	select {
+	case <-waiter.ready:
+		pool.active--
+		b.grant(pool)
+	default:
+		pool.remove(waiter)
+	}
+	return nil, err
+}
+
+// AdmitExtraction waits for an extraction slot; it lets the budget act as the extractor's admission control
+func (b *ProcessBudget) AdmitExtraction(ctx context.Context, playback bool) (func(), error) {
+	priority := PriorityMetadata
+	if playback {
+		priority = PriorityPlayback
+	}
+	return b.Acquire(ctx, ProcessExtraction, priority, nil)
+}
+
+// Position returns where a new request of the given kind and priority would stand in line,
+// or 0 when it would get a slot straight away
+func (b *ProcessBudget) Position(kind ProcessKind, priority ProcessPriority) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pool := b.pool(kind)
+	ahead := 0
+	for _, waiter := range pool.waiters {
+		if waiter.priority >= priority {
+			ahead++
+		}
+	}
+	if ahead == 0 && pool.active < pool.limit {
+		return 0
+	}
+	return ahead + 1
+}
+
+// Usage returns a snapshot of every process kind's slots
+func (b *ProcessBudget) Usage() []BudgetUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usage := make([]BudgetUsage, 0, len(b.pools))
+	for _, kind := range []ProcessKind{ProcessStream, ProcessExtraction} {
+		pool := b.pool(kind)
+		usage = append(usage, BudgetUsage{
+			Kind:    kind,
+			Active:  pool.active,
+			Limit:   pool.limit,
+			Waiting: len(pool.waiters),
+		})
+	}
+	return usage
+}
+
+// releaser returns a function that frees a slot exactly once
+func (b *ProcessBudget) releaser(kind ProcessKind) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			pool := b.pool(kind)
+			pool.active--
+			b.grant(pool)
+		})
+	}
+}
+
+// grant hands free slots to the first waiters in line; the caller must hold the lock
+func (b *ProcessBudget) grant(pool *processPool) {
+	for pool.active < pool.limit && len(pool.waiters) > 0 {
+		waiter := pool.waiters[0]
+		pool.waiters = pool.waiters[1:]
+		pool.active++
+		close(waiter.ready)
+	}
+}
+
+// pool returns the pool for a kind, treating unknown kinds as extractions
+func (b *ProcessBudget) pool(kind ProcessKind) *processPool {
+	if pool, exists := b.pools[kind]; exists {
+		return pool
+	}
+	return b.pools[ProcessExtraction]
+}
+
+// position returns a waiter's 1-based place in line
+func (p *processPool) position(target *budgetWaiter) int {
+	for i, waiter := range p.waiters {
+		if waiter == target {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// remove drops a waiter that gave up
+func (p *processPool) remove(target *budgetWaiter) {
+	for i, waiter := range p.waiters {
+		if waiter == target {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+var (
+	globalProcessBudget *ProcessBudget
+	globalBudgetMutex   sync.RWMutex
+)
+
+// GetGlobalProcessBudget returns the shared ProcessBudget, creating one with default limits if none was set
+func GetGlobalProcessBudget() *ProcessBudget {
+	globalBudgetMutex.RLock()
+	budget := globalProcessBudget
+	globalBudgetMutex.RUnlock()
+
+	if budget != nil {
+		return budget
+	}
+
+	globalBudgetMutex.Lock()
+	defer globalBudgetMutex.Unlock()
+	if globalProcessBudget == nil {
+		globalProcessBudget = NewProcessBudget(DefaultMaxStreams, DefaultMaxExtractions, DefaultBudgetTimeout)
+	}
+	return globalProcessBudget
+}
+
+// SetGlobalProcessBudget sets the shared ProcessBudget instance
+func SetGlobalProcessBudget(budget *ProcessBudget) {
+	globalBudgetMutex.Lock()
+	defer globalBudgetMutex.Unlock()
+	globalProcessBudget = budget
+}
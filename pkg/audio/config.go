@@ -67,6 +67,45 @@ type PipelineStatus struct {
 	LastError  string    `json:"last_error"`
 }
 
+// Clip limits playback to part of a stream. A zero Start plays from the beginning
+// and a zero End plays to the end of the stream.
+type Clip struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// IsZero reports whether the clip covers the whole stream
+func (c Clip) IsZero() bool {
+	return c.Start <= 0 && c.End <= 0
+}
+
+// Length returns how much of a stream of the given total length the clip plays,
+// or 0 when it can't be known (an open-ended clip of a stream with unknown length)
+func (c Clip) Length(total time.Duration) time.Duration {
+	end := c.End
+	if end <= 0 || (total > 0 && end > total) {
+		end = total
+	}
+	if end <= c.Start {
+		return 0
+	}
+	return end - c.Start
+}
+
+// Validate checks the clip against the stream's total length, which may be 0 when unknown
+func (c Clip) Validate(total time.Duration) error {
+	if c.Start < 0 || c.End < 0 {
+		return fmt.Errorf("offsets can't be negative")
+	}
+	if c.End > 0 && c.End <= c.Start {
+		return fmt.Errorf("the end must come after the start")
+	}
+	if total > 0 && c.Start >= total {
+		return fmt.Errorf("the start is past the end of the video (%s)", total.Round(time.Second))
+	}
+	return nil
+}
+
 // MetricsStats contains aggregated metrics data
 type MetricsStats struct {
 	TotalPlaybackTime  time.Duration `json:"total_playback_time"`
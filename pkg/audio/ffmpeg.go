@@ -2,6 +2,7 @@ package audio
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -9,12 +10,15 @@ import (
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/latoulicious/HKTM/pkg/extractor"
 )
 
 // FFmpegProcessor implements the StreamProcessor interface for FFmpeg operations
 type FFmpegProcessor struct {
 	config         *FFmpegConfig
 	ytdlpConfig    *YtDlpConfig
+	extractor      *extractor.Extractor // Resolves stream URLs with the configured yt-dlp binary
 	cmd            *exec.Cmd
 	ytdlpCmd       *exec.Cmd // yt-dlp process for piping
 	outputPipe     io.ReadCloser
@@ -22,8 +26,9 @@ type FFmpegProcessor struct {
 	ytdlpErrorPipe io.ReadCloser // yt-dlp stderr pipe
 	isRunning      bool
 	currentURL     string
-	retryCount     int // Current retry attempt
-	maxRetries     int // Maximum retry attempts (3 as per requirements)
+	clip           Clip // Part of the stream to play, applied on every (re)start
+	retryCount     int  // Current retry attempt
+	maxRetries     int  // Maximum retry attempts (3 as per requirements)
 	mu             sync.RWMutex
 	logger         AudioLogger
 	pipelineLogger AudioLogger   // Pipeline-specific logger with context
@@ -48,6 +53,7 @@ func NewFFmpegProcessor(config *FFmpegConfig, ytdlpConfig *YtDlpConfig, logger A
 	return &FFmpegProcessor{
 		config:         config,
 		ytdlpConfig:    ytdlpConfig,
+		extractor:      extractor.GetGlobalExtractor().WithBinaryPath(ytdlpConfig.BinaryPath),
 		logger:         logger,
 		pipelineLogger: pipelineLogger,
 		maxStderrLines: 50, // Keep last 50 stderr lines for debugging
@@ -57,6 +63,11 @@ func NewFFmpegProcessor(config *FFmpegConfig, ytdlpConfig *YtDlpConfig, logger A
 
 // StartStream starts the yt-dlp | FFmpeg pipeline for the given URL
 func (fp *FFmpegProcessor) StartStream(url string) (io.ReadCloser, error) {
+	return fp.StartClip(url, Clip{})
+}
+
+// StartClip starts the yt-dlp | FFmpeg pipeline for part of the given URL
+func (fp *FFmpegProcessor) StartClip(url string, clip Clip) (io.ReadCloser, error) {
 	fp.mu.Lock()
 	defer fp.mu.Unlock()
 
@@ -72,6 +83,7 @@ func (fp *FFmpegProcessor) StartStream(url string) (io.ReadCloser, error) {
 
 	fp.currentURL = url
 	fp.originalURL = url // Store original URL for refresh
+	fp.clip = clip
 	fp.retryCount = 0
 
 	// Get fresh streaming URL and track expiry (Requirement 8.1)
@@ -99,6 +111,10 @@ func (fp *FFmpegProcessor) startStreamWithRetry(url string, urlLogger AudioLogge
 
 			// Check if failure might be due to URL expiry and try refresh (Requirement 8.2, 6.1)
 			if fp.DetectStreamFailure(lastErr) && fp.originalURL != "" {
+				// A rejected URL must not be handed out again, to us or another guild
+				if isForbiddenError(lastErr.Error()) {
+					fp.invalidateStreamURL(fp.originalURL, fp.streamURL, fp.urlExpiry, urlLogger)
+				}
 				contextFields["trying_url_refresh"] = true
 				urlLogger.Info("Attempting URL refresh for retry", contextFields)
 
@@ -148,12 +164,18 @@ func (fp *FFmpegProcessor) startStreamWithRetry(url string, urlLogger AudioLogge
 
 // startPipeline starts the yt-dlp | ffmpeg pipeline
 func (fp *FFmpegProcessor) startPipeline(url string, urlLogger AudioLogger) (io.ReadCloser, error) {
+	// A pipe can't be seeked, so FFmpeg reads a clip starting past the beginning from the
+	// stream URL itself rather than downloading and decoding everything before the start
+	if fp.clip.Start > 0 {
+		return fp.startSeekingPipeline(url, urlLogger)
+	}
+
 	// Build yt-dlp command: yt-dlp -o - [url]
 	ytdlpArgs := fp.buildYtdlpArgs(url)
 	fp.ytdlpCmd = exec.Command(fp.ytdlpConfig.BinaryPath, ytdlpArgs...)
 
 	// Build FFmpeg command: ffmpeg -i pipe:0 [options] pipe:1
-	ffmpegArgs := fp.buildFFmpegPipeArgs()
+	ffmpegArgs := fp.buildFFmpegArgs(url)
 	fp.cmd = exec.Command(fp.config.BinaryPath, ffmpegArgs...)
 
 	// Set up process groups for proper cleanup
@@ -237,6 +259,52 @@ func (fp *FFmpegProcessor) startPipeline(url string, urlLogger AudioLogger) (io.
 	return ffmpegStdout, nil
 }
 
+// startSeekingPipeline starts FFmpeg on the stream URL at the clip's start offset
+func (fp *FFmpegProcessor) startSeekingPipeline(url string, urlLogger AudioLogger) (io.ReadCloser, error) {
+	// Build FFmpeg command: ffmpeg -ss <start> -i [url] [options] pipe:1
+	ffmpegArgs := fp.buildFFmpegArgs(url)
+	fp.cmd = exec.Command(fp.config.BinaryPath, ffmpegArgs...)
+	fp.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	ffmpegStdout, err := fp.cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ffmpeg stdout pipe: %w", err)
+	}
+	fp.outputPipe = ffmpegStdout
+
+	ffmpegStderr, err := fp.cmd.StderrPipe()
+	if err != nil {
+		ffmpegStdout.Close()
+		return nil, fmt.Errorf("failed to create ffmpeg stderr pipe: %w", err)
+	}
+	fp.errorPipe = ffmpegStderr
+
+	contextFields := CreateContextFieldsWithComponent("", "", url, "ffmpeg")
+	contextFields["ffmpeg_command"] = fp.config.BinaryPath + " " + strings.Join(ffmpegArgs, " ")
+	contextFields["clip_start"] = fp.clip.Start.String()
+	urlLogger.Info("Starting ffmpeg at the clip start", contextFields)
+
+	if err := fp.cmd.Start(); err != nil {
+		ffmpegStdout.Close()
+		ffmpegStderr.Close()
+		return nil, fmt.Errorf("failed to start ffmpeg process: %w", err)
+	}
+
+	fp.isRunning = true
+	fp.processExited = make(chan struct{})
+	fp.stderrBuffer = make([]string, 0, fp.maxStderrLines)
+
+	go fp.monitorStderr()
+	go fp.monitorProcess()
+
+	urlLogger.Info("ffmpeg started at the clip start", contextFields)
+
+	// Give the pipeline a moment to initialize
+	time.Sleep(100 * time.Millisecond)
+
+	return ffmpegStdout, nil
+}
+
 // buildYtdlpArgs constructs the yt-dlp command arguments for piping
 func (fp *FFmpegProcessor) buildYtdlpArgs(url string) []string {
 	args := []string{
@@ -255,11 +323,30 @@ func (fp *FFmpegProcessor) buildYtdlpArgs(url string) []string {
 	return args
 }
 
-// buildFFmpegPipeArgs constructs FFmpeg arguments for reading from pipe
-func (fp *FFmpegProcessor) buildFFmpegPipeArgs() []string {
-	args := []string{
+// buildFFmpegArgs constructs FFmpeg arguments for reading from the yt-dlp pipe, or from
+// the stream URL when the clip starts past the beginning
+func (fp *FFmpegProcessor) buildFFmpegArgs(streamURL string) []string {
+	var args []string
+	if fp.clip.Start > 0 {
+		// -ss before the input seeks with range requests instead of decoding up to the start
+		args = append(args,
+			"-reconnect", "1",
+			"-reconnect_streamed", "1",
+			"-reconnect_delay_max", "5",
+			"-ss", formatFFmpegTime(fp.clip.Start),
+			"-i", streamURL,
+		)
+	} else {
 		// Input from pipe (yt-dlp output)
-		"-i", "pipe:0",
+		args = append(args, "-i", "pipe:0")
+	}
+
+	// Trim to the requested clip
+	if fp.clip.End > 0 {
+		args = append(args, "-t", formatFFmpegTime(fp.clip.End-fp.clip.Start))
+	}
+
+	args = append(args,
 		// Output format options
 		"-f", fp.config.AudioFormat,
 		"-ar", fmt.Sprintf("%d", fp.config.SampleRate),
@@ -270,7 +357,7 @@ func (fp *FFmpegProcessor) buildFFmpegPipeArgs() []string {
 		// Reduce output noise
 		"-hide_banner",
 		"-loglevel", "error",
-	}
+	)
 
 	// Add custom arguments from configuration (but avoid duplicates)
 	for _, customArg := range fp.config.CustomArgs {
@@ -288,6 +375,11 @@ func (fp *FFmpegProcessor) buildFFmpegPipeArgs() []string {
 	return args
 }
 
+// formatFFmpegTime renders an offset in seconds with millisecond precision
+func formatFFmpegTime(offset time.Duration) string {
+	return fmt.Sprintf("%.3f", offset.Seconds())
+}
+
 // Stop stops the current FFmpeg process
 func (fp *FFmpegProcessor) Stop() error {
 	fp.mu.Lock()
@@ -433,6 +525,11 @@ func (fp *FFmpegProcessor) monitorYtdlpStderr() {
 		// Also log to console immediately
 		fmt.Printf("[yt-dlp] %s\n", line)
 
+		// The first 403 means the stream URL is no longer accepted
+		if isForbiddenError(line) {
+			fp.invalidateCurrentStreamURL(fp.pipelineLogger)
+		}
+
 		// Check for yt-dlp specific errors
 		if fp.isYtdlpError(line) {
 			errorFields := CreateContextFieldsWithComponent("", "", fp.currentURL, "ytdlp")
@@ -482,6 +579,11 @@ func (fp *FFmpegProcessor) monitorStderr() {
 		// Also log to console immediately to avoid database delays
 		fmt.Printf("[FFmpeg] %s\n", line)
 
+		// Seeking pipelines hand the stream URL to FFmpeg directly, so a 403 shows up here
+		if isForbiddenError(line) {
+			fp.invalidateCurrentStreamURL(fp.pipelineLogger)
+		}
+
 		// Check for specific error patterns
 		if fp.isErrorLine(line) {
 			errorFields := CreateContextFieldsWithComponent("", "", fp.currentURL, "ffmpeg")
@@ -525,26 +627,24 @@ func (fp *FFmpegProcessor) monitorProcess() {
 		}
 	}()
 
-	// Monitor both processes concurrently
-	ffmpegDone := make(chan error, 1)
-	ytdlpDone := make(chan error, 1)
+	// Monitor both processes concurrently; a clip read straight from the stream URL has
+	// no yt-dlp process, and its nil channel never fires
+	var ffmpegDone, ytdlpDone chan error
 
 	// Monitor FFmpeg process
 	if fp.cmd != nil {
+		ffmpegDone = make(chan error, 1)
 		go func() {
 			ffmpegDone <- fp.cmd.Wait()
 		}()
-	} else {
-		close(ffmpegDone)
 	}
 
 	// Monitor yt-dlp process
 	if fp.ytdlpCmd != nil {
+		ytdlpDone = make(chan error, 1)
 		go func() {
 			ytdlpDone <- fp.ytdlpCmd.Wait()
 		}()
-	} else {
-		close(ytdlpDone)
 	}
 
 	// Wait for either process to exit
@@ -853,36 +953,27 @@ func (fp *FFmpegProcessor) refreshStreamURL(originalURL string, logger AudioLogg
 	contextFields := CreateContextFieldsWithComponent("", "", originalURL, "url_refresh")
 	logger.Info("Getting fresh streaming URL", contextFields)
 
-	// Use yt-dlp to extract stream URL with metadata
-	cmd := exec.Command(fp.ytdlpConfig.BinaryPath,
-		"--get-url",
-		"--quiet",
-		"--no-warnings",
-		"--format", "bestaudio",
-		originalURL)
+	// Reuse a stream URL resolved for another guild or an earlier play while it is still valid
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
 
-	output, err := cmd.Output()
+	stream, _, err := fp.extractor.ResolveStream(ctx, originalURL)
 	if err != nil {
 		contextFields["error"] = err.Error()
 		logger.Error("yt-dlp URL extraction failed", err, contextFields)
 		return fmt.Errorf("yt-dlp extraction failed: %w", err)
 	}
 
-	streamURL := strings.TrimSpace(string(output))
-	if streamURL == "" {
-		logger.Error("yt-dlp returned empty URL", fmt.Errorf("empty URL"), contextFields)
-		return fmt.Errorf("yt-dlp returned empty URL")
-	}
-
-	// YouTube URLs typically expire after 5-6 minutes
-	// We'll be conservative and assume 5 minutes
-	fp.streamURL = streamURL
-	fp.urlStartTime = time.Now()
-	fp.urlExpiry = fp.urlStartTime.Add(5 * time.Minute)
+	// The expiry comes from the URL's expire parameter, so refreshes can be scheduled precisely
+	fp.streamURL = stream.URL
+	fp.urlStartTime = stream.ResolvedAt
+	fp.urlExpiry = stream.ExpiresAt
 
-	contextFields["stream_url"] = streamURL
+	contextFields["stream_url"] = stream.URL
+	contextFields["format_id"] = stream.FormatID
+	contextFields["strategy"] = stream.Strategy
 	contextFields["expiry_time"] = fp.urlExpiry.Format(time.RFC3339)
-	contextFields["estimated_ttl"] = "5m"
+	contextFields["ttl"] = time.Until(fp.urlExpiry).Round(time.Second).String()
 	logger.Info("Fresh streaming URL obtained", contextFields)
 
 	// Start proactive refresh timer (Requirement 8.2)
@@ -899,8 +990,8 @@ func (fp *FFmpegProcessor) startURLRefreshTimer(originalURL string, logger Audio
 		fp.refreshTimer = nil
 	}
 
-	// Refresh 1 minute before expiry (or immediately if already close)
-	refreshTime := fp.urlExpiry.Add(-1 * time.Minute)
+	// Refresh the configured margin before expiry (or immediately if already close)
+	refreshTime := fp.urlExpiry.Add(-fp.extractor.Streams().RefreshMargin())
 	delay := time.Until(refreshTime)
 
 	contextFields := CreateContextFieldsWithComponent("", "", originalURL, "url_refresh_timer")
@@ -967,6 +1058,35 @@ func (fp *FFmpegProcessor) handleURLRefresh(originalURL string, logger AudioLogg
 	logger.Error("URL refresh failed after all attempts", refreshErr, contextFields)
 }
 
+// invalidateCurrentStreamURL drops the current stream URL from the shared cache. It reads
+// the URL under the lock, so callers must not hold it.
+func (fp *FFmpegProcessor) invalidateCurrentStreamURL(logger AudioLogger) {
+	fp.mu.RLock()
+	originalURL, streamURL, expiry := fp.originalURL, fp.streamURL, fp.urlExpiry
+	fp.mu.RUnlock()
+
+	fp.invalidateStreamURL(originalURL, streamURL, expiry, logger)
+}
+
+// invalidateStreamURL drops a stream URL resolved for originalURL from the shared cache
+func (fp *FFmpegProcessor) invalidateStreamURL(originalURL, streamURL string, expiry time.Time, logger AudioLogger) {
+	if originalURL == "" || streamURL == "" {
+		return
+	}
+
+	if fp.extractor.Streams().Invalidate(extractor.StreamKey(originalURL), streamURL) {
+		contextFields := CreateContextFieldsWithComponent("", "", originalURL, "url_refresh")
+		contextFields["expiry_time"] = expiry.Format(time.RFC3339)
+		logger.Warn("Stream URL rejected, removed from cache", contextFields)
+	}
+}
+
+// isForbiddenError reports whether an error or stderr line is an HTTP 403
+func isForbiddenError(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "403 forbidden") || strings.Contains(message, "http error 403") || strings.Contains(message, "server returned 403")
+}
+
 // DetectStreamFailure detects if current stream has failed due to URL expiry
 func (fp *FFmpegProcessor) DetectStreamFailure(err error) bool {
 	if err == nil {
@@ -1006,6 +1126,9 @@ func (fp *FFmpegProcessor) HandleStreamFailureWithRefresh(originalURL string) er
 
 	logger.Info("Attempting recovery with fresh URL", contextFields)
 
+	// The current URL failed, so make sure the refresh doesn't reuse it
+	fp.invalidateCurrentStreamURL(logger)
+
 	// Try to get fresh URL
 	if err := fp.refreshStreamURL(originalURL, logger); err != nil {
 		contextFields["refresh_error"] = err.Error()
@@ -12,10 +12,21 @@ import (
 type AudioPipeline interface {
 	// Core playback operations
 	PlayURL(url string, voiceConn *discordgo.VoiceConnection) error
+	PlayClip(url string, clip Clip, voiceConn *discordgo.VoiceConnection) error
 	Stop() error
 	IsPlaying() bool
 	GetStatus() PipelineStatus
 
+	// Playback controls
+	Pause() error
+	Resume() error
+	IsPaused() bool
+	SetVolume(percent int)
+	GetVolume() int
+	GetPosition() time.Duration
+	Seek(position time.Duration) error
+	SetVoiceConnection(voiceConn *discordgo.VoiceConnection)
+
 	// Lifecycle management
 	Initialize() error
 	Shutdown() error
@@ -25,6 +36,7 @@ type AudioPipeline interface {
 // StreamProcessor handles the FFmpeg process and audio stream generation
 type StreamProcessor interface {
 	StartStream(url string) (io.ReadCloser, error)
+	StartClip(url string, clip Clip) (io.ReadCloser, error)
 	Stop() error
 	IsRunning() bool
 	IsProcessAlive() bool
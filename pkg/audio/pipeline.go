@@ -2,9 +2,11 @@ package audio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -53,6 +55,7 @@ type AudioPipelineController struct {
 	// State management only
 	state        PipelineState
 	currentURL   string
+	currentClip  Clip // Part of the stream being played, kept for retries
 	voiceConn    *discordgo.VoiceConnection
 	startTime    time.Time
 	errorCount   int
@@ -63,8 +66,45 @@ type AudioPipelineController struct {
 	cancelFunc   context.CancelFunc
 	initialized  bool
 	shutdownOnce sync.Once
+
+	// Playback controls
+	volume     int           // Percentage applied to PCM samples, 100 = unchanged
+	resumeChan chan struct{} // Closed when a paused stream should continue
+
+	// Playback position, counted from frames actually sent to Discord
+	framesSent atomic.Int64
+
+	// Seek the streaming loop still has to carry out, guarded by mu
+	pendingSeek *seekRequest
+
+	// Releases the process budget slot held while streaming, guarded by mu
+	streamSlot func()
+}
+
+// seekRequest asks the streaming loop to restart the stream at another position
+type seekRequest struct {
+	clip     Clip          // Part of the stream to play from the new position
+	position time.Duration // New position, measured from the start of the current clip
 }
 
+const (
+	// voiceReconnectTimeout is how long playback waits for a dropped voice connection
+	voiceReconnectTimeout = 60 * time.Second
+	// minVoiceReconnectBackoff and maxVoiceReconnectBackoff bound the wait between checks
+	minVoiceReconnectBackoff = 250 * time.Millisecond
+	maxVoiceReconnectBackoff = 5 * time.Second
+)
+
+// errPlaybackStopped is returned by waits that were cut short by stopping playback
+var errPlaybackStopped = errors.New("playback stopped")
+
+const (
+	// DefaultVolume leaves samples unchanged
+	DefaultVolume = 100
+	// MaxVolume caps amplification to keep clipping reasonable
+	MaxVolume = 200
+)
+
 // NewAudioPipelineController creates a new AudioPipelineController with injected dependencies
 func NewAudioPipelineController(
 	streamProcessor StreamProcessor,
@@ -88,6 +128,7 @@ func NewAudioPipelineController(
 		ctx:             ctx,
 		cancelFunc:      cancel,
 		initialized:     false,
+		volume:          DefaultVolume,
 	}
 }
 
@@ -143,7 +184,7 @@ func (c *AudioPipelineController) Shutdown() error {
 		c.logger.Info("Shutting down audio pipeline", CreateContextFieldsWithComponent("", "", "", "shutdown"))
 
 		// Step 1: Stop any active playback
-		if c.state == StatePlaying || c.state == StateStarting {
+		if c.state == StatePlaying || c.state == StateStarting || c.state == StatePaused {
 			c.logger.Debug("Stopping active playback during shutdown", CreateContextFieldsWithComponent("", "", "", "shutdown"))
 			if err := c.stopPlaybackInternal(); err != nil {
 				c.logger.Error("Error stopping playback during shutdown", err, CreateContextFieldsWithComponent("", "", "", "shutdown"))
@@ -192,6 +233,7 @@ func (c *AudioPipelineController) Shutdown() error {
 		// Step 4: Reset state
 		c.state = StateStopped
 		c.currentURL = ""
+		c.releaseStreamSlot()
 		c.startTime = time.Time{}
 		c.voiceConn = nil
 		c.errorCount = 0
@@ -219,6 +261,13 @@ func (c *AudioPipelineController) IsInitialized() bool {
 // PlayURL starts playback of the given URL using the voice connection
 // Implements the AudioPipeline interface
 func (c *AudioPipelineController) PlayURL(url string, voiceConn *discordgo.VoiceConnection) error {
+	return c.PlayClip(url, Clip{}, voiceConn)
+}
+
+// PlayClip starts playback of part of the given URL using the voice connection.
+// The position reported by GetPosition counts from the start of the clip.
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) PlayClip(url string, clip Clip, voiceConn *discordgo.VoiceConnection) error {
 	// Check if initialized
 	if !c.IsInitialized() {
 		return fmt.Errorf("pipeline not initialized - call Initialize() first")
@@ -229,14 +278,18 @@ func (c *AudioPipelineController) PlayURL(url string, voiceConn *discordgo.Voice
 		c.logger.Error("URL validation failed", err, CreateContextFields("", "", url))
 		return fmt.Errorf("invalid URL: %w", err)
 	}
+	if err := clip.Validate(0); err != nil {
+		return fmt.Errorf("invalid clip: %w", err)
+	}
 
 	// Check if already playing
 	c.mu.Lock()
-	if c.state == StatePlaying || c.state == StateStarting {
+	if c.state == StatePlaying || c.state == StateStarting || c.state == StatePaused {
 		currentURL := c.currentURL
 		c.mu.Unlock()
 		return fmt.Errorf("pipeline is already playing: %s", currentURL)
 	}
+	c.currentClip = clip
 	c.mu.Unlock()
 
 	// Delegate to state manager
@@ -291,8 +344,15 @@ func (c *AudioPipelineController) stopPlaybackInternal() error {
 	// Update state
 	c.state = StateStopped
 	c.currentURL = ""
+	c.currentClip = Clip{}
+	c.pendingSeek = nil
+	c.releaseStreamSlot()
 	c.startTime = time.Time{}
 	c.voiceConn = nil
+	if c.resumeChan != nil {
+		close(c.resumeChan)
+		c.resumeChan = nil
+	}
 
 	// Create new context for next playback
 	c.ctx, c.cancelFunc = context.WithCancel(context.Background())
@@ -309,12 +369,187 @@ func (c *AudioPipelineController) stopPlaybackInternal() error {
 	return nil
 }
 
-// IsPlaying returns true if the pipeline is currently playing audio
+// IsPlaying returns true if the pipeline has an active track; a paused track still counts
+// as playing so callers waiting for the track to end keep waiting
 // Implements the AudioPipeline interface
 func (c *AudioPipelineController) IsPlaying() bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.state == StatePlaying
+	return c.state == StatePlaying || c.state == StatePaused
+}
+
+// Pause stops sending audio frames without ending the track
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) Pause() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == StatePaused {
+		return nil
+	}
+	if c.state != StatePlaying {
+		return fmt.Errorf("nothing is playing")
+	}
+
+	c.state = StatePaused
+	c.resumeChan = make(chan struct{})
+	if c.voiceConn != nil {
+		c.voiceConn.Speaking(false)
+	}
+
+	c.logger.Info("Playback paused", CreateContextFields("", "", c.currentURL))
+	return nil
+}
+
+// Resume continues a paused track
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) Resume() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == StatePlaying {
+		return nil
+	}
+	if c.state != StatePaused {
+		return fmt.Errorf("nothing is paused")
+	}
+
+	c.state = StatePlaying
+	if c.resumeChan != nil {
+		close(c.resumeChan)
+		c.resumeChan = nil
+	}
+	if c.voiceConn != nil {
+		c.voiceConn.Speaking(true)
+	}
+
+	c.logger.Info("Playback resumed", CreateContextFields("", "", c.currentURL))
+	return nil
+}
+
+// IsPaused returns true if the current track is paused
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) IsPaused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state == StatePaused
+}
+
+// SetVolume sets the playback volume as a percentage, clamped to 0-MaxVolume
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) SetVolume(percent int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.volume = ClampVolume(percent)
+}
+
+// GetVolume returns the playback volume percentage
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) GetVolume() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.volume
+}
+
+// GetPosition returns how much of the current track has been sent to Discord.
+// It is derived from the number of frames sent, so it stands still while paused.
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) GetPosition() time.Duration {
+	return time.Duration(c.framesSent.Load()) * c.audioEncoder.GetFrameDuration()
+}
+
+// Seek moves playback to a position measured from the start of the current clip.
+// The old stream is stopped and the streaming loop restarts it at the new position,
+// so the voice session and pause state are kept.
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) Seek(position time.Duration) error {
+	if position < 0 {
+		position = 0
+	}
+
+	c.mu.Lock()
+	if c.state != StatePlaying && c.state != StatePaused {
+		c.mu.Unlock()
+		return fmt.Errorf("nothing is playing")
+	}
+
+	clip := Clip{Start: c.currentClip.Start + position, End: c.currentClip.End}
+	if clip.End > 0 && clip.Start >= clip.End {
+		c.mu.Unlock()
+		return fmt.Errorf("position is past the end of the track")
+	}
+	c.pendingSeek = &seekRequest{clip: clip, position: position}
+	url := c.currentURL
+	c.mu.Unlock()
+
+	// Report the new position straight away, even while paused
+	c.framesSent.Store(int64(position / c.audioEncoder.GetFrameDuration()))
+
+	contextFields := CreateContextFieldsWithComponent("", "", url, "seek")
+	contextFields["position"] = position.String()
+	c.logger.Info("Seeking", contextFields)
+
+	// Stopping the processor ends the loop's current read, which then picks up the seek
+	return c.streamProcessor.Stop()
+}
+
+// takePendingSeek returns and clears the seek the streaming loop should carry out, if any
+func (c *AudioPipelineController) takePendingSeek() *seekRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	request := c.pendingSeek
+	c.pendingSeek = nil
+	return request
+}
+
+// hasPendingSeek reports whether a seek is waiting for the streaming loop
+func (c *AudioPipelineController) hasPendingSeek() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pendingSeek != nil
+}
+
+// restartForSeek starts the stream at the requested position. Seeks that arrive while
+// restarting are applied before returning, so only the latest position is played.
+func (c *AudioPipelineController) restartForSeek(url string) (io.ReadCloser, error) {
+	var stream io.ReadCloser
+	for request := c.takePendingSeek(); request != nil; request = c.takePendingSeek() {
+		if stream != nil {
+			stream.Close()
+		}
+
+		next, err := c.streamProcessor.StartClip(url, request.clip)
+		if err != nil {
+			return nil, err
+		}
+		stream = next
+		c.framesSent.Store(int64(request.position / c.audioEncoder.GetFrameDuration()))
+	}
+	return stream, nil
+}
+
+// waitWhilePaused blocks while the pipeline is paused; returns false if playback was stopped
+func (c *AudioPipelineController) waitWhilePaused() bool {
+	for {
+		c.mu.RLock()
+		paused := c.state == StatePaused
+		resume := c.resumeChan
+		stop := c.stopChan
+		ctx := c.ctx
+		c.mu.RUnlock()
+
+		if !paused {
+			return true
+		}
+
+		select {
+		case <-resume:
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
 }
 
 // GetStatus returns the current status of the pipeline
@@ -341,9 +576,10 @@ func (c *AudioPipelineController) GetStatus() PipelineStatus {
 // This method coordinates all components in the correct order:
 // 1. Initialize state and logging context
 // 2. Initialize audio encoder
-// 3. Start stream processor
-// 4. Record metrics
-// 5. Start audio streaming loop
+// 3. Acquire a stream slot from the process budget
+// 4. Start stream processor
+// 5. Record metrics
+// 6. Start audio streaming loop
 func (c *AudioPipelineController) executePlayback(url string, voiceConn *discordgo.VoiceConnection) error {
 	// Set up initial state and context
 	c.mu.Lock()
@@ -353,11 +589,20 @@ func (c *AudioPipelineController) executePlayback(url string, voiceConn *discord
 	c.startTime = time.Now()
 	c.lastError = nil
 	c.errorCount = 0 // Reset error count for new playback
+	c.pendingSeek = nil
 	guildID := voiceConn.GuildID
+	clip := c.currentClip
 	c.mu.Unlock()
 
+	// Each attempt streams from the start of the clip, so the position starts over
+	c.framesSent.Store(0)
+
 	// Create enriched logging context for this playback session
 	contextFields := CreateContextFieldsWithComponent(guildID, "", url, "pipeline")
+	if !clip.IsZero() {
+		contextFields["clip_start"] = clip.Start.String()
+		contextFields["clip_end"] = clip.End.String()
+	}
 	c.logger.Info("Starting playback execution", contextFields)
 
 	// Record startup time measurement
@@ -382,35 +627,82 @@ func (c *AudioPipelineController) executePlayback(url string, voiceConn *discord
 		return c.handlePlaybackError(err, "encoder_prepare")
 	}
 
-	// Step 3: Start the stream processor
+	// Step 3: Wait for a stream slot in the process budget; retries keep the one they hold
+	if err := c.acquireStreamSlot(contextFields); err != nil {
+		c.logger.Error("No stream slot available", err, contextFields)
+		c.Stop()
+		return fmt.Errorf("too many streams running: %w", err)
+	}
+
+	// Step 4: Start the stream processor
 	c.logger.Debug("Starting stream processor", contextFields)
-	stream, err := c.streamProcessor.StartStream(url)
+	stream, err := c.streamProcessor.StartClip(url, clip)
 	if err != nil {
 		c.logger.Error("Stream processor start failed", err, contextFields)
 		return c.handlePlaybackError(err, "stream_start")
 	}
 
-	// Step 4: Record successful startup metrics
+	// Step 5: Record successful startup metrics
 	startupDuration := time.Since(startTime)
 	c.metrics.RecordStartupTime(startupDuration)
 
 	contextFields["startup_duration"] = FormatDuration(startupDuration)
 	c.logger.Info("Pipeline components initialized successfully", contextFields)
 
-	// Step 5: Update state to playing
+	// Step 6: Update state to playing
 	c.mu.Lock()
 	c.state = StatePlaying
 	c.mu.Unlock()
 
 	c.logger.Info("Playback started successfully, beginning audio stream", contextFields)
 
-	// Step 6: Start streaming audio in a separate goroutine
+	// Step 7: Start streaming audio in a separate goroutine
 	// This is non-blocking so the method can return immediately
 	go c.streamAudio(stream)
 
 	return nil
 }
 
+// acquireStreamSlot waits for a stream slot unless this playback already holds one.
+// Stopping playback while waiting gives up the wait.
+func (c *AudioPipelineController) acquireStreamSlot(contextFields map[string]interface{}) error {
+	c.mu.RLock()
+	held := c.streamSlot != nil
+	ctx := c.ctx
+	c.mu.RUnlock()
+
+	if held {
+		return nil
+	}
+
+	release, err := GetGlobalProcessBudget().Acquire(ctx, ProcessStream, PriorityPlayback, func(position int) {
+		contextFields["stream_queue_position"] = position
+		c.logger.Info("Waiting for a free stream slot", contextFields)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Playback was stopped as the slot came free
+	if ctx.Err() != nil {
+		release()
+		return ctx.Err()
+	}
+	c.streamSlot = release
+	return nil
+}
+
+// releaseStreamSlot frees the stream slot, if held; the caller must hold mu
+func (c *AudioPipelineController) releaseStreamSlot() {
+	if c.streamSlot != nil {
+		c.streamSlot()
+		c.streamSlot = nil
+	}
+}
+
 // streamAudio handles the audio streaming loop
 // This method manages the continuous audio streaming process:
 // 1. Set up streaming buffers and context
@@ -419,20 +711,23 @@ func (c *AudioPipelineController) executePlayback(url string, voiceConn *discord
 // 4. Send Opus frames to Discord voice connection
 // 5. Handle streaming errors and cleanup
 func (c *AudioPipelineController) streamAudio(stream io.ReadCloser) {
-	defer func() {
-		stream.Close()
-		c.logger.Debug("Audio streaming loop ended", CreateContextFields("", "", c.currentURL))
-	}()
-
-	// Get current context for logging
+	// Get current context for logging, and the stop signals of this playback; stopping
+	// swaps in fresh ones for the next playback, so they are copied under the lock
 	c.mu.RLock()
 	url := c.currentURL
 	guildID := ""
 	if c.voiceConn != nil {
 		guildID = c.voiceConn.GuildID
 	}
+	stop := c.stopChan
+	ctx := c.ctx
 	c.mu.RUnlock()
 
+	defer func() {
+		stream.Close()
+		c.logger.Debug("Audio streaming loop ended", CreateContextFields("", "", url))
+	}()
+
 	contextFields := CreateContextFieldsWithComponent(guildID, "", url, "stream")
 	c.logger.Debug("Starting audio streaming loop", contextFields)
 
@@ -460,16 +755,35 @@ func (c *AudioPipelineController) streamAudio(stream io.ReadCloser) {
 	// Main streaming loop
 	for {
 		select {
-		case <-c.stopChan:
+		case <-stop:
 			c.logger.Debug("Stop signal received, ending stream", contextFields)
 			return
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			c.logger.Debug("Context cancelled, ending stream", contextFields)
 			return
 		default:
+			// Hold the stream while paused; FFmpeg blocks on the full pipe until we resume
+			if !c.waitWhilePaused() {
+				c.logger.Debug("Stopped while paused, ending stream", contextFields)
+				return
+			}
+
 			// Read PCM data from stream processor
 			n, err := stream.Read(byteBuffer)
 			if err != nil {
+				// A seek stopped the old stream; carry on from the new position
+				if c.hasPendingSeek() {
+					next, seekErr := c.restartForSeek(url)
+					if seekErr != nil {
+						c.logger.Error("Failed to restart stream for seek", seekErr, contextFields)
+						c.handlePlaybackError(seekErr, "seek")
+						return
+					}
+					stream.Close()
+					stream = next
+					continue
+				}
+
 				if err == io.EOF {
 					// Normal stream completion
 					streamDuration := time.Since(streamStartTime)
@@ -519,6 +833,11 @@ func (c *AudioPipelineController) streamAudio(stream io.ReadCloser) {
 				pcmBuffer[i] = int16(byteBuffer[i*2]) | int16(byteBuffer[i*2+1])<<8
 			}
 
+			// Apply volume before encoding
+			if volume := c.GetVolume(); volume != DefaultVolume {
+				ApplyVolume(pcmBuffer[:samplesRead], volume)
+			}
+
 			// Validate frame size before encoding
 			if err := c.audioEncoder.ValidateFrameSize(pcmBuffer[:samplesRead]); err != nil {
 				c.logger.Warn("Invalid frame size, adjusting", CreateContextFieldsWithComponent(guildID, "", url, "frame_validation"))
@@ -538,42 +857,132 @@ func (c *AudioPipelineController) streamAudio(stream io.ReadCloser) {
 			}
 
 			// Send encoded audio to Discord voice connection
-			if c.voiceConn != nil && c.voiceConn.OpusSend != nil {
-				select {
-				case c.voiceConn.OpusSend <- opusData:
-					// Successfully sent frame
-					framesProcessed++
-
-					// Log progress much less frequently (every 500 frames = ~10 seconds)
-					if framesProcessed%500 == 0 {
-						progressFields := CreateContextFieldsWithComponent(guildID, "", url, "stream_progress")
-						progressFields["frames_processed"] = framesProcessed
-						progressFields["bytes_processed"] = bytesProcessed
-						progressFields["elapsed_time"] = FormatDuration(time.Since(streamStartTime))
-						c.logger.Info("Streaming progress", progressFields)
+			voiceConn := c.getVoiceConnection()
+			if !isVoiceReady(voiceConn) {
+				// The voice websocket dropped or the bot was moved; wait for it to come back
+				if err := c.waitForVoiceConnection(contextFields); err != nil {
+					if errors.Is(err, errPlaybackStopped) {
+						return
 					}
-
-				case <-c.stopChan:
-					c.logger.Debug("Stop signal received while sending frame", contextFields)
-					return
-				case <-c.ctx.Done():
-					c.logger.Debug("Context cancelled while sending frame", contextFields)
+					c.logger.Error("Voice connection unavailable", err, CreateContextFieldsWithComponent(guildID, "", url, "voice_connection"))
+					c.handlePlaybackError(fmt.Errorf("voice connection lost: %w", err), "voice_connection")
 					return
-				default:
-					// Discord send channel is full - this indicates potential issues
-					// Skip this frame but log the occurrence
-					c.logger.Warn("Discord send channel full, skipping frame", CreateContextFieldsWithComponent(guildID, "", url, "discord_send"))
 				}
-			} else {
-				// Voice connection is not available
-				c.logger.Error("Voice connection unavailable", nil, CreateContextFieldsWithComponent(guildID, "", url, "voice_connection"))
-				c.handlePlaybackError(fmt.Errorf("voice connection lost"), "voice_connection")
+
+				// The buffered audio is stale and the source may have timed out, so restart
+				// the stream where the listeners stopped hearing it
+				if err := c.Seek(c.GetPosition()); err != nil {
+					c.logger.Warn("Failed to restart stream after voice reconnect", contextFields)
+				}
+				continue
+			}
+
+			select {
+			case voiceConn.OpusSend <- opusData:
+				// Successfully sent frame
+				framesProcessed++
+				c.framesSent.Add(1)
+
+				// Log progress much less frequently (every 500 frames = ~10 seconds)
+				if framesProcessed%500 == 0 {
+					progressFields := CreateContextFieldsWithComponent(guildID, "", url, "stream_progress")
+					progressFields["frames_processed"] = framesProcessed
+					progressFields["bytes_processed"] = bytesProcessed
+					progressFields["elapsed_time"] = FormatDuration(time.Since(streamStartTime))
+					c.logger.Info("Streaming progress", progressFields)
+				}
+
+			case <-stop:
+				c.logger.Debug("Stop signal received while sending frame", contextFields)
+				return
+			case <-ctx.Done():
+				c.logger.Debug("Context cancelled while sending frame", contextFields)
 				return
+			default:
+				// Discord send channel is full - this indicates potential issues
+				// Skip this frame but log the occurrence
+				c.logger.Warn("Discord send channel full, skipping frame", CreateContextFieldsWithComponent(guildID, "", url, "discord_send"))
 			}
 		}
 	}
 }
 
+// SetVoiceConnection rebinds playback to another voice connection, e.g. after the bot
+// rejoined or was moved. The streaming loop picks it up on its next frame.
+// Implements the AudioPipeline interface
+func (c *AudioPipelineController) SetVoiceConnection(voiceConn *discordgo.VoiceConnection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.voiceConn == voiceConn {
+		return
+	}
+	c.voiceConn = voiceConn
+
+	if voiceConn != nil {
+		c.logger.Info("Voice connection rebound", CreateContextFieldsWithComponent(voiceConn.GuildID, "", c.currentURL, "voice_connection"))
+	}
+}
+
+// getVoiceConnection returns the voice connection frames are sent to
+func (c *AudioPipelineController) getVoiceConnection() *discordgo.VoiceConnection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.voiceConn
+}
+
+// isVoiceReady reports whether a voice connection can take audio right now
+func isVoiceReady(voiceConn *discordgo.VoiceConnection) bool {
+	if voiceConn == nil {
+		return false
+	}
+
+	voiceConn.RLock()
+	defer voiceConn.RUnlock()
+	return voiceConn.Ready && voiceConn.OpusSend != nil
+}
+
+// waitForVoiceConnection waits, backing off between checks, until the voice connection is
+// ready again or was rebound to a ready one. It gives up after voiceReconnectTimeout.
+func (c *AudioPipelineController) waitForVoiceConnection(contextFields map[string]interface{}) error {
+	c.logger.Warn("Voice connection not ready, waiting for it to reconnect", contextFields)
+
+	c.mu.RLock()
+	stop := c.stopChan
+	ctx := c.ctx
+	c.mu.RUnlock()
+
+	deadline := time.Now().Add(voiceReconnectTimeout)
+	backoff := minVoiceReconnectBackoff
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-time.After(backoff):
+		case <-stop:
+			return errPlaybackStopped
+		case <-ctx.Done():
+			return errPlaybackStopped
+		}
+
+		// Stopping swaps in a fresh stop channel, so check the state as well
+		if !c.IsPlaying() {
+			return errPlaybackStopped
+		}
+		if isVoiceReady(c.getVoiceConnection()) {
+			contextFields["voice_reconnect_attempts"] = attempt
+			c.logger.Info("Voice connection ready again, resuming playback", contextFields)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not reconnected within %s", voiceReconnectTimeout)
+		}
+
+		backoff *= 2
+		if backoff > maxVoiceReconnectBackoff {
+			backoff = maxVoiceReconnectBackoff
+		}
+	}
+}
+
 // handlePlaybackError handles errors during playback with retry logic
 // This method implements comprehensive error recovery:
 // 1. Update pipeline state and record error details
@@ -593,6 +1002,8 @@ func (c *AudioPipelineController) handlePlaybackError(err error, context string)
 	if c.voiceConn != nil {
 		guildID = c.voiceConn.GuildID
 	}
+	stop := c.stopChan
+	ctx := c.ctx
 	c.state = StateError
 	c.mu.Unlock()
 
@@ -649,10 +1060,10 @@ func (c *AudioPipelineController) handlePlaybackError(err error, context string)
 		case <-retryTimer.C:
 			// Delay completed, proceed with retry
 			c.logger.Debug("Retry delay completed, attempting recovery", retryContextFields)
-		case <-c.stopChan:
+		case <-stop:
 			c.logger.Info("Stop requested during retry delay", retryContextFields)
 			return fmt.Errorf("stop requested during retry delay for error: %w", err)
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			c.logger.Info("Context cancelled during retry delay", retryContextFields)
 			return fmt.Errorf("context cancelled during retry delay for error: %w", err)
 		}
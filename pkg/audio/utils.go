@@ -271,3 +271,27 @@ func ValidateAllBinaryDependencies(ffmpegPath, ytDlpPath string) error {
 
 	return nil
 }
+
+// ClampVolume limits a volume percentage to the supported 0-MaxVolume range
+func ClampVolume(percent int) int {
+	if percent < 0 {
+		return 0
+	}
+	if percent > MaxVolume {
+		return MaxVolume
+	}
+	return percent
+}
+
+// ApplyVolume scales PCM samples in place by a volume percentage, clipping at the int16 range
+func ApplyVolume(samples []int16, percent int) {
+	for i, sample := range samples {
+		scaled := int32(sample) * int32(percent) / 100
+		if scaled > 32767 {
+			scaled = 32767
+		} else if scaled < -32768 {
+			scaled = -32768
+		}
+		samples[i] = int16(scaled)
+	}
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+)
+
+// ParseTimestamp parses a video offset written as seconds ("90"), YouTube's unit
+// form ("1m30s", "1h2m3s") or clock form ("1:30", "1:02:03")
+func ParseTimestamp(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, fmt.Errorf("empty timestamp")
+	}
+
+	if strings.Contains(value, ":") {
+		return parseClockTimestamp(value)
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("invalid timestamp: %s", value)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	// time.ParseDuration accepts the same units YouTube uses for t=1h2m3s
+	offset, err := time.ParseDuration(value)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	return offset, nil
+}
+
+// parseClockTimestamp parses "m:ss" or "h:mm:ss"
+func parseClockTimestamp(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp: %s", value)
+	}
+
+	var total int
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || (i > 0 && (len(part) != 2 || number >= 60)) {
+			return 0, fmt.Errorf("invalid timestamp: %s", value)
+		}
+		total = total*60 + number
+	}
+	return time.Duration(total) * time.Second, nil
+}
+
+// FormatTimestamp renders an offset in clock form, e.g. "1:20" or "1:02:03"
+func FormatTimestamp(offset time.Duration) string {
+	seconds := int(offset.Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// FormatClip renders a clip as "1:20-3:45", with "end" for open-ended clips
+func FormatClip(clip audio.Clip) string {
+	end := "end"
+	if clip.End > 0 {
+		end = FormatTimestamp(clip.End)
+	}
+	return FormatTimestamp(clip.Start) + "-" + end
+}
+
+// ParseClipRange parses an explicit "start-end" range such as "1:20-3:45".
+// Either side may be left out to play from the beginning or to the end.
+func ParseClipRange(value string) (audio.Clip, error) {
+	start, end, found := strings.Cut(strings.TrimSpace(value), "-")
+	if !found || (start == "" && end == "") {
+		return audio.Clip{}, fmt.Errorf("invalid range %q (expected start-end, e.g. 1:20-3:45)", value)
+	}
+
+	var clip audio.Clip
+	var err error
+	if start != "" {
+		if clip.Start, err = ParseTimestamp(start); err != nil {
+			return audio.Clip{}, err
+		}
+	}
+	if end != "" {
+		if clip.End, err = ParseTimestamp(end); err != nil {
+			return audio.Clip{}, err
+		}
+	}
+
+	if err := clip.Validate(0); err != nil {
+		return audio.Clip{}, err
+	}
+	return clip, nil
+}
+
+// ClipFromURL reads the offsets a YouTube link carries: `t` or `start` (in the query or
+// the #t= fragment) and `end`. Unparseable values are ignored, as YouTube does.
+func ClipFromURL(rawURL string) audio.Clip {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return audio.Clip{}
+	}
+
+	query := parsed.Query()
+	fragment, _ := url.ParseQuery(parsed.Fragment)
+
+	var clip audio.Clip
+	for _, value := range []string{query.Get("t"), query.Get("start"), fragment.Get("t")} {
+		if value == "" {
+			continue
+		}
+		if offset, err := ParseTimestamp(value); err == nil {
+			clip.Start = offset
+			break
+		}
+	}
+	if value := query.Get("end"); value != "" {
+		if offset, err := ParseTimestamp(value); err == nil && offset > clip.Start {
+			clip.End = offset
+		}
+	}
+	return clip
+}
+
+// ClipForRequest works out which part of a video a request asked for. An explicit
+// range argument wins over the offsets carried by the URL.
+func ClipForRequest(rawURL, rangeArg string) (audio.Clip, error) {
+	if rangeArg != "" {
+		return ParseClipRange(rangeArg)
+	}
+	return ClipFromURL(rawURL), nil
+}
@@ -0,0 +1,286 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+)
+
+// PlaylistFormat is a file format the queue can be exported to
+type PlaylistFormat string
+
+const (
+	PlaylistFormatJSON PlaylistFormat = "json"
+	PlaylistFormatM3U  PlaylistFormat = "m3u"
+)
+
+// ParsePlaylistFormat converts user input into a playlist format
+func ParsePlaylistFormat(value string) (PlaylistFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "json":
+		return PlaylistFormatJSON, nil
+	case "m3u", "m3u8":
+		return PlaylistFormatM3U, nil
+	default:
+		return "", fmt.Errorf("unknown export format: %s (use `json` or `m3u`)", value)
+	}
+}
+
+// Extension returns the file extension for the format
+func (f PlaylistFormat) Extension() string {
+	if f == PlaylistFormatM3U {
+		return "m3u"
+	}
+	return "json"
+}
+
+// ContentType returns the MIME type for the format
+func (f PlaylistFormat) ContentType() string {
+	if f == PlaylistFormatM3U {
+		return "audio/x-mpegurl"
+	}
+	return "application/json"
+}
+
+// PlaylistEntry is a single exported queue item
+type PlaylistEntry struct {
+	Position    int    `json:"position,omitempty"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	Duration    int    `json:"duration_seconds,omitempty"` // Clipped length when offsets are set
+	Start       int    `json:"start_seconds,omitempty"`
+	End         int    `json:"end_seconds,omitempty"`
+}
+
+// PlaylistNowPlaying is the exported now-playing item with its playback position
+type PlaylistNowPlaying struct {
+	PlaylistEntry
+	Elapsed int `json:"elapsed_seconds"`
+}
+
+// PlaylistExport is a snapshot of a guild's queue
+type PlaylistExport struct {
+	GuildID    string              `json:"guild_id"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Mode       QueueMode           `json:"mode"`
+	NowPlaying *PlaylistNowPlaying `json:"now_playing,omitempty"`
+	Queue      []PlaylistEntry     `json:"queue"`
+}
+
+// ImportEntry is a single importable line: a URL or a search query
+type ImportEntry struct {
+	Line     int // 1-based line number in the file, or item number for JSON
+	Value    string
+	Title    string
+	Duration time.Duration
+	Clip     audio.Clip // Offsets carried by a JSON export
+}
+
+// Snapshot captures the queue's now-playing item and pending items in play order
+func (mq *MusicQueue) Snapshot() *PlaylistExport {
+	export := &PlaylistExport{
+		GuildID:    mq.guildID,
+		ExportedAt: time.Now().UTC(),
+		Mode:       mq.GetMode(),
+		Queue:      []PlaylistEntry{},
+	}
+
+	if current := mq.Current(); current != nil {
+		export.NowPlaying = &PlaylistNowPlaying{
+			PlaylistEntry: playlistEntryFor(current, 0),
+			Elapsed:       int(mq.Elapsed().Seconds()),
+		}
+	}
+
+	for i, item := range mq.List() {
+		export.Queue = append(export.Queue, playlistEntryFor(item, i+1))
+	}
+	return export
+}
+
+// playlistEntryFor converts a queue item, preferring the original page URL over stream URLs
+func playlistEntryFor(item *QueueItem, position int) PlaylistEntry {
+	url := item.OriginalURL
+	if url == "" {
+		url = item.URL
+	}
+	return PlaylistEntry{
+		Position:    position,
+		Title:       item.Title,
+		URL:         url,
+		RequestedBy: item.RequestedBy,
+		Duration:    int(item.Duration.Seconds()),
+		Start:       int(item.StartOffset.Seconds()),
+		End:         int(item.EndOffset.Seconds()),
+	}
+}
+
+// Encode renders the snapshot in the given format
+func (p *PlaylistExport) Encode(format PlaylistFormat) ([]byte, error) {
+	if format == PlaylistFormatM3U {
+		return p.encodeM3U(), nil
+	}
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// encodeM3U renders an extended M3U playlist; the now-playing item comes first
+// with a start offset so players resume where the bot was
+func (p *PlaylistExport) encodeM3U() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	buf.WriteString(fmt.Sprintf("#PLAYLIST:HKTM queue %s\n", p.GuildID))
+
+	if p.NowPlaying != nil {
+		writeM3UEntry(&buf, p.NowPlaying.PlaylistEntry, p.NowPlaying.Elapsed)
+	}
+
+	for _, entry := range p.Queue {
+		writeM3UEntry(&buf, entry, 0)
+	}
+	return buf.Bytes()
+}
+
+// writeM3UEntry writes one item, with VLC start/stop options for clips and the
+// now-playing position
+func writeM3UEntry(buf *bytes.Buffer, entry PlaylistEntry, elapsed int) {
+	buf.WriteString(fmt.Sprintf("#EXTINF:%d,%s\n", m3uDuration(entry.Duration), m3uTitle(entry.Title)))
+	if start := entry.Start + elapsed; start > 0 {
+		buf.WriteString(fmt.Sprintf("#EXTVLCOPT:start-time=%d\n", start))
+	}
+	if entry.End > 0 {
+		buf.WriteString(fmt.Sprintf("#EXTVLCOPT:stop-time=%d\n", entry.End))
+	}
+	buf.WriteString(entry.URL + "\n")
+}
+
+// m3uDuration uses -1 for unknown lengths as the format expects
+func m3uDuration(seconds int) int {
+	if seconds <= 0 {
+		return -1
+	}
+	return seconds
+}
+
+// m3uTitle keeps titles on a single line
+func m3uTitle(title string) string {
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// ParsePlaylistImport reads a JSON export, an extended M3U playlist or a plain text
+// file with one URL or search query per line
+func ParsePlaylistImport(data []byte) ([]ImportEntry, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+
+	if trimmed[0] == '{' {
+		return parseJSONImport(trimmed)
+	}
+	return parseLineImport(trimmed)
+}
+
+// parseJSONImport reads a queue export produced by Encode
+func parseJSONImport(data []byte) ([]ImportEntry, error) {
+	var export PlaylistExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid JSON export: %w", err)
+	}
+
+	var entries []ImportEntry
+	add := func(entry PlaylistEntry) {
+		value := entry.URL
+		if value == "" {
+			// Fall back to searching by title when the URL is missing
+			value = entry.Title
+		}
+		entries = append(entries, ImportEntry{
+			Line:     len(entries) + 1,
+			Value:    strings.TrimSpace(value),
+			Title:    entry.Title,
+			Duration: time.Duration(entry.Duration) * time.Second,
+			Clip: audio.Clip{
+				Start: time.Duration(entry.Start) * time.Second,
+				End:   time.Duration(entry.End) * time.Second,
+			},
+		})
+	}
+
+	if export.NowPlaying != nil {
+		add(export.NowPlaying.PlaylistEntry)
+	}
+	for _, entry := range export.Queue {
+		add(entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the export contains no songs")
+	}
+	return entries, nil
+}
+
+// parseLineImport reads M3U or plain text, carrying #EXTINF metadata onto the next entry
+func parseLineImport(data []byte) ([]ImportEntry, error) {
+	var entries []ImportEntry
+	var pendingTitle string
+	var pendingDuration time.Duration
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			if info, ok := strings.CutPrefix(line, "#EXTINF:"); ok {
+				pendingTitle, pendingDuration = parseExtInf(info)
+			}
+			continue
+		}
+
+		entries = append(entries, ImportEntry{
+			Line:     lineNumber,
+			Value:    line,
+			Title:    pendingTitle,
+			Duration: pendingDuration,
+		})
+		pendingTitle, pendingDuration = "", 0
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the file contains no URLs or search queries")
+	}
+	return entries, nil
+}
+
+// parseExtInf splits "#EXTINF:<seconds>,<title>" metadata
+func parseExtInf(info string) (string, time.Duration) {
+	seconds, title, found := strings.Cut(info, ",")
+	if !found {
+		return "", 0
+	}
+
+	// Attributes such as tvg-id may follow the duration
+	if fields := strings.Fields(seconds); len(fields) > 0 {
+		seconds = fields[0]
+	}
+
+	var duration time.Duration
+	if value, err := strconv.Atoi(seconds); err == nil && value > 0 {
+		duration = time.Duration(value) * time.Second
+	}
+	return strings.TrimSpace(title), duration
+}
@@ -3,12 +3,15 @@ package common
 import (
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/latoulicious/HKTM/pkg/audio"
 	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/extractor"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"gorm.io/gorm"
 )
@@ -19,10 +22,105 @@ type QueueItem struct {
 	OriginalURL string // Original YouTube URL (if applicable)
 	VideoID     string // YouTube video ID (if applicable)
 	Title       string
-	RequestedBy string
+	RequestedBy string // Username shown in embeds
+	RequesterID string // Discord user ID of the requester; usernames aren't unique
 	AddedAt     time.Time
 	StartedAt   time.Time
-	Duration    time.Duration
+	Duration    time.Duration       // Playable length, i.e. the clipped length when offsets are set
+	StartOffset time.Duration       // Where playback starts in the video (0 = beginning)
+	EndOffset   time.Duration       // Where playback stops in the video (0 = end)
+	Chapters    []extractor.Chapter // YouTube chapters, loaded when the item starts playing
+}
+
+// IsRequestedBy reports whether a user queued the item
+func (item *QueueItem) IsRequestedBy(userID string) bool {
+	return item != nil && item.RequesterID != "" && item.RequesterID == userID
+}
+
+// requester identifies whoever queued the item for limits and fair ordering, by user ID
+// when it's known
+func (item *QueueItem) requester() string {
+	if item.RequesterID != "" {
+		return item.RequesterID
+	}
+	return item.RequestedBy
+}
+
+// Clip returns the part of the video the item plays
+func (item *QueueItem) Clip() audio.Clip {
+	return audio.Clip{Start: item.StartOffset, End: item.EndOffset}
+}
+
+// ChapterAt returns the index of the chapter playing at a position measured from the
+// start of the item, or -1 when the item has no chapter there
+func (item *QueueItem) ChapterAt(position time.Duration) int {
+	offset := item.StartOffset + position
+	for i, chapter := range item.Chapters {
+		if offset >= chapter.Start && (offset < chapter.End || i == len(item.Chapters)-1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// QueueMode controls the order in which queued items are played
+type QueueMode string
+
+const (
+	// QueueModeFIFO plays items in the order they were added
+	QueueModeFIFO QueueMode = "fifo"
+	// QueueModeFair interleaves items round-robin by requester
+	QueueModeFair QueueMode = "fair"
+)
+
+// ParseQueueMode converts user input into a QueueMode
+func ParseQueueMode(value string) (QueueMode, error) {
+	switch QueueMode(strings.ToLower(strings.TrimSpace(value))) {
+	case QueueModeFIFO, "normal":
+		return QueueModeFIFO, nil
+	case QueueModeFair, "roundrobin", "round-robin":
+		return QueueModeFair, nil
+	default:
+		return "", i18n.Errorf("queue.mode.unknown", value)
+	}
+}
+
+// LoopMode controls whether finished items are played again
+type LoopMode string
+
+const (
+	// LoopOff plays each item once
+	LoopOff LoopMode = "off"
+	// LoopTrack replays the current item until it is skipped
+	LoopTrack LoopMode = "track"
+	// LoopQueue moves finished items to the back of the queue
+	LoopQueue LoopMode = "queue"
+)
+
+// ParseLoopMode converts user input into a LoopMode
+func ParseLoopMode(value string) (LoopMode, error) {
+	switch LoopMode(strings.ToLower(strings.TrimSpace(value))) {
+	case LoopOff, "none", "disable":
+		return LoopOff, nil
+	case LoopTrack, "song", "one":
+		return LoopTrack, nil
+	case LoopQueue, "all":
+		return LoopQueue, nil
+	default:
+		return "", fmt.Errorf("unknown loop mode: %s (expected off, track or queue)", value)
+	}
+}
+
+// Next returns the mode that follows in the off -> track -> queue cycle
+func (m LoopMode) Next() LoopMode {
+	switch m {
+	case LoopOff:
+		return LoopTrack
+	case LoopTrack:
+		return LoopQueue
+	default:
+		return LoopOff
+	}
 }
 
 // MusicQueue manages the queue for a specific guild
@@ -32,6 +130,15 @@ type MusicQueue struct {
 	current      *QueueItem
 	isPlaying    bool
 	wasSkipped   bool // Flag to track if current song was skipped
+	mode         QueueMode
+	rotation     []string // Requesters in fair-mode turn order, kept between calls to Next
+	lastServed   string   // Requester of the item Next took last; fair mode continues after them
+	maxUserItems int           // Per-requester item cap (0 = unlimited)
+	maxUserTime  time.Duration // Per-requester total duration cap (0 = unlimited)
+	maxLength    int           // Guild-wide item cap (0 = unlimited)
+	loop         LoopMode
+	replay       *QueueItem // Item to play again before the queue when looping a track
+	volume       int        // Playback volume percentage, carried across pipelines
 	mu           sync.RWMutex
 	voiceConn    *discordgo.VoiceConnection
 	pipeline     audio.AudioPipeline // Updated to use new AudioPipeline interface
@@ -49,6 +156,9 @@ func NewMusicQueue(guildID string) *MusicQueue {
 	return &MusicQueue{
 		guildID:      guildID,
 		items:        make([]*QueueItem, 0),
+		mode:         QueueModeFIFO,
+		loop:         LoopOff,
+		volume:       audio.DefaultVolume,
 		logger:       logger,
 		embedBuilder: embed.GetGlobalAudioEmbedBuilder(),
 	}
@@ -63,14 +173,18 @@ func NewMusicQueueWithDB(guildID string, db *gorm.DB) *MusicQueue {
 	return &MusicQueue{
 		guildID:      guildID,
 		items:        make([]*QueueItem, 0),
+		mode:         QueueModeFIFO,
+		loop:         LoopOff,
+		volume:       audio.DefaultVolume,
 		logger:       logger,
 		db:           db,
 		embedBuilder: embed.GetGlobalAudioEmbedBuilder(),
 	}
 }
 
-// Add adds a new item to the queue
-func (mq *MusicQueue) Add(url, title, requestedBy string) {
+// Add adds a new item to the queue. duration is the item's length, 0 when unknown.
+// Returns an error if the requester has reached their per-user limits
+func (mq *MusicQueue) Add(url, title, requestedBy, requesterID string, duration time.Duration) error {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
@@ -78,7 +192,12 @@ func (mq *MusicQueue) Add(url, title, requestedBy string) {
 		URL:         url,
 		Title:       title,
 		RequestedBy: requestedBy,
+		RequesterID: requesterID,
 		AddedAt:     time.Now(),
+		Duration:    duration,
+	}
+	if err := mq.checkLimits(item); err != nil {
+		return err
 	}
 
 	mq.items = append(mq.items, item)
@@ -89,16 +208,33 @@ func (mq *MusicQueue) Add(url, title, requestedBy string) {
 			"title":        title,
 			"url":          url,
 			"requested_by": requestedBy,
+			"duration":     duration.String(),
 			"queue_size":   len(mq.items),
 		})
 	} else {
 		// Fallback to standard logging if centralized logger not available
 		log.Printf("Added '%s' to queue for guild %s", title, mq.guildID)
 	}
+	return nil
 }
 
 // AddWithYouTubeData adds a new item to the queue with YouTube-specific data
-func (mq *MusicQueue) AddWithYouTubeData(url, originalURL, videoID, title, requestedBy string, duration time.Duration) {
+// Returns an error if the requester has reached their per-user limits
+func (mq *MusicQueue) AddWithYouTubeData(url, originalURL, videoID, title, requestedBy, requesterID string, duration time.Duration) error {
+	return mq.AddClipWithYouTubeData(url, originalURL, videoID, title, requestedBy, requesterID, duration, audio.Clip{})
+}
+
+// AddClipWithYouTubeData adds part of a YouTube video to the queue. duration is the full
+// length of the video; the item's Duration is set to the clipped length.
+// Returns an error if the clip is invalid or the requester has reached their per-user limits
+func (mq *MusicQueue) AddClipWithYouTubeData(url, originalURL, videoID, title, requestedBy, requesterID string, duration time.Duration, clip audio.Clip) error {
+	if err := clip.Validate(duration); err != nil {
+		return err
+	}
+	if !clip.IsZero() {
+		duration = clip.Length(duration)
+	}
+
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
@@ -108,8 +244,14 @@ func (mq *MusicQueue) AddWithYouTubeData(url, originalURL, videoID, title, reque
 		VideoID:     videoID,
 		Title:       title,
 		RequestedBy: requestedBy,
+		RequesterID: requesterID,
 		AddedAt:     time.Now(),
 		Duration:    duration,
+		StartOffset: clip.Start,
+		EndOffset:   clip.End,
+	}
+	if err := mq.checkLimits(item); err != nil {
+		return err
 	}
 
 	mq.items = append(mq.items, item)
@@ -123,12 +265,204 @@ func (mq *MusicQueue) AddWithYouTubeData(url, originalURL, videoID, title, reque
 			"video_id":     videoID,
 			"requested_by": requestedBy,
 			"duration":     duration.String(),
+			"start_offset": clip.Start.String(),
+			"end_offset":   clip.End.String(),
 			"queue_size":   len(mq.items),
 		})
 	} else {
 		// Fallback to standard logging if centralized logger not available
 		log.Printf("Added '%s' (Duration: %v) to queue for guild %s", title, duration, mq.guildID)
 	}
+	return nil
+}
+
+// LimitError is returned when adding an item would break a queue limit. Key and Args
+// render it in the reader's language with i18n.T.
+type LimitError struct {
+	Key  string
+	Args []interface{}
+}
+
+// Error returns the English message
+func (e *LimitError) Error() string {
+	return i18n.T(i18n.English, e.Key, e.Args...)
+}
+
+// checkLimits verifies that the queue has room for an item and that its requester
+// stays within their per-user caps. Caller must hold the lock.
+func (mq *MusicQueue) checkLimits(item *QueueItem) error {
+	if mq.maxLength > 0 && len(mq.items) >= mq.maxLength {
+		return &LimitError{Key: "queue.limit.full", Args: []interface{}{mq.maxLength}}
+	}
+	return mq.checkUserLimits(item.requester(), item.Duration)
+}
+
+// checkUserLimits verifies that adding an item of the given duration keeps the
+// requester within the configured per-user caps. Items of unknown length, such as live
+// streams, are refused while a duration cap is set since they can't be counted against
+// it. Caller must hold the lock.
+func (mq *MusicQueue) checkUserLimits(requester string, duration time.Duration) error {
+	if mq.maxUserItems <= 0 && mq.maxUserTime <= 0 {
+		return nil
+	}
+
+	count := 0
+	var total time.Duration
+	for _, item := range mq.items {
+		if item.requester() == requester {
+			count++
+			total += item.Duration
+		}
+	}
+
+	if mq.maxUserItems > 0 && count >= mq.maxUserItems {
+		return &LimitError{Key: "queue.limit.user_items", Args: []interface{}{count, mq.maxUserItems}}
+	}
+
+	if mq.maxUserTime > 0 && duration <= 0 {
+		return &LimitError{Key: "queue.limit.unknown_length", Args: []interface{}{mq.maxUserTime}}
+	}
+
+	if mq.maxUserTime > 0 && total+duration > mq.maxUserTime {
+		return &LimitError{Key: "queue.limit.user_duration", Args: []interface{}{(total + duration).Round(time.Second), mq.maxUserTime}}
+	}
+
+	return nil
+}
+
+// SetMode sets the queue ordering mode
+func (mq *MusicQueue) SetMode(mode QueueMode) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	if mq.mode == mode {
+		return
+	}
+	mq.mode = mode
+
+	if mq.logger != nil {
+		mq.logger.Info("Queue mode changed", map[string]interface{}{
+			"mode": string(mode),
+		})
+	}
+}
+
+// GetMode returns the queue ordering mode
+func (mq *MusicQueue) GetMode() QueueMode {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+	return mq.mode
+}
+
+// SetUserLimits sets the per-requester caps; zero disables a cap
+func (mq *MusicQueue) SetUserLimits(maxItems int, maxDuration time.Duration) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	if mq.maxUserItems == maxItems && mq.maxUserTime == maxDuration {
+		return
+	}
+	mq.maxUserItems = maxItems
+	mq.maxUserTime = maxDuration
+
+	if mq.logger != nil {
+		mq.logger.Info("Queue user limits changed", map[string]interface{}{
+			"max_items":    maxItems,
+			"max_duration": maxDuration.String(),
+		})
+	}
+}
+
+// SetMaxLength sets the guild-wide cap on queued items; zero disables it.
+// Items already queued are kept.
+func (mq *MusicQueue) SetMaxLength(maxLength int) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	mq.maxLength = maxLength
+}
+
+// GetUserLimits returns the per-requester caps
+func (mq *MusicQueue) GetUserLimits() (int, time.Duration) {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+	return mq.maxUserItems, mq.maxUserTime
+}
+
+// orderedItems returns the queued items in effective play order. Caller must hold the lock.
+//
+// In fair mode items are grouped by requester and taken one per requester per round,
+// in turn order (see turnOrder).
+func (mq *MusicQueue) orderedItems() []*QueueItem {
+	if mq.mode != QueueModeFair || len(mq.items) < 2 {
+		result := make([]*QueueItem, len(mq.items))
+		copy(result, mq.items)
+		return result
+	}
+
+	var queued []string
+	groups := make(map[string][]*QueueItem)
+	for _, item := range mq.items {
+		if _, exists := groups[item.requester()]; !exists {
+			queued = append(queued, item.requester())
+		}
+		groups[item.requester()] = append(groups[item.requester()], item)
+	}
+	requesters := mq.turnOrder(queued)
+
+	result := make([]*QueueItem, 0, len(mq.items))
+	for round := 0; len(result) < len(mq.items); round++ {
+		for _, requester := range requesters {
+			if round < len(groups[requester]) {
+				result = append(result, groups[requester][round])
+			}
+		}
+	}
+	return result
+}
+
+// turnOrder orders the requesters with queued items for fair mode: the rotation picks up
+// after the last requester served, and requesters new to it take their turn just before
+// that requester comes round again. queued lists the requesters in order of their
+// earliest queued item. Caller must hold the lock.
+func (mq *MusicQueue) turnOrder(queued []string) []string {
+	hasItems := make(map[string]bool, len(queued))
+	for _, requester := range queued {
+		hasItems[requester] = true
+	}
+
+	last := -1
+	inRotation := make(map[string]bool, len(mq.rotation))
+	for i, requester := range mq.rotation {
+		inRotation[requester] = true
+		if requester == mq.lastServed {
+			last = i
+		}
+	}
+
+	order := make([]string, 0, len(queued))
+	for i := range mq.rotation {
+		requester := mq.rotation[(last+1+i)%len(mq.rotation)]
+		if hasItems[requester] && requester != mq.lastServed {
+			order = append(order, requester)
+		}
+	}
+	for _, requester := range queued {
+		if !inRotation[requester] && requester != mq.lastServed {
+			order = append(order, requester)
+		}
+	}
+	if hasItems[mq.lastServed] {
+		order = append(order, mq.lastServed)
+	}
+	return order
+}
+
+// removeItem removes the given item from the underlying slice. Caller must hold the lock.
+func (mq *MusicQueue) removeItem(target *QueueItem) {
+	for i, item := range mq.items {
+		if item == target {
+			mq.items = append(mq.items[:i], mq.items[i+1:]...)
+			return
+		}
+	}
 }
 
 // Next gets the next item from the queue
@@ -136,12 +470,35 @@ func (mq *MusicQueue) Next() *QueueItem {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
+	// A looped track plays again before anything else
+	if mq.replay != nil {
+		item := mq.replay
+		mq.replay = nil
+		item.StartedAt = time.Now()
+		mq.current = item
+		return item
+	}
+
 	if len(mq.items) == 0 {
 		return nil
 	}
 
-	item := mq.items[0]
-	mq.items = mq.items[1:]
+	item := mq.orderedItems()[0]
+	if mq.mode == QueueModeFair {
+		// Persist the turn order so the next call continues the rotation after this requester
+		var queued []string
+		seen := make(map[string]bool)
+		for _, queuedItem := range mq.items {
+			if !seen[queuedItem.requester()] {
+				seen[queuedItem.requester()] = true
+				queued = append(queued, queuedItem.requester())
+			}
+		}
+		mq.rotation = mq.turnOrder(queued)
+	}
+	mq.lastServed = item.requester()
+	mq.removeItem(item)
+	item.StartedAt = time.Now()
 	mq.current = item
 	return item
 }
@@ -153,14 +510,155 @@ func (mq *MusicQueue) Current() *QueueItem {
 	return mq.current
 }
 
-// List returns all items in the queue
+// SetLoopMode changes whether finished items are played again
+func (mq *MusicQueue) SetLoopMode(mode LoopMode) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	mq.loop = mode
+	if mode != LoopTrack {
+		mq.replay = nil
+	}
+
+	if mq.logger != nil {
+		mq.logger.Info("Loop mode changed", map[string]interface{}{
+			"loop": string(mode),
+		})
+	}
+}
+
+// GetLoopMode returns the current loop mode
+func (mq *MusicQueue) GetLoopMode() LoopMode {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+	return mq.loop
+}
+
+// RequeueFinished schedules a finished item to play again according to the loop mode.
+// Skipped items are not passed here, so skipping always moves past a looped track.
+func (mq *MusicQueue) RequeueFinished(item *QueueItem) {
+	if item == nil {
+		return
+	}
+
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	// Copy so the replay is a distinct track for vote-skip and status tracking
+	again := *item
+	again.StartedAt = time.Time{}
+
+	switch mq.loop {
+	case LoopTrack:
+		mq.replay = &again
+	case LoopQueue:
+		// Looping bypasses per-user limits since the item was already accepted once
+		again.AddedAt = time.Now()
+		mq.items = append(mq.items, &again)
+	}
+}
+
+// SetVolume sets the playback volume percentage and applies it to the active pipeline
+func (mq *MusicQueue) SetVolume(percent int) int {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	mq.volume = audio.ClampVolume(percent)
+	if mq.pipeline != nil {
+		mq.pipeline.SetVolume(mq.volume)
+	}
+	return mq.volume
+}
+
+// GetVolume returns the playback volume percentage
+func (mq *MusicQueue) GetVolume() int {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+	return mq.volume
+}
+
+// IsPaused returns whether the current track is paused
+func (mq *MusicQueue) IsPaused() bool {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+	return mq.pipeline != nil && mq.pipeline.IsPaused()
+}
+
+// Elapsed returns how far into the current item playback is, or 0 if nothing is playing.
+// The pipeline's sent frame count is used when available so pauses are accounted for;
+// wall-clock time since StartedAt is only a fallback for queues without a pipeline.
+func (mq *MusicQueue) Elapsed() time.Duration {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+
+	if mq.current == nil {
+		return 0
+	}
+
+	var elapsed time.Duration
+	switch {
+	case mq.pipeline != nil:
+		elapsed = mq.pipeline.GetPosition()
+	case !mq.current.StartedAt.IsZero():
+		elapsed = time.Since(mq.current.StartedAt)
+	}
+	if mq.current.Duration > 0 && elapsed > mq.current.Duration {
+		return mq.current.Duration
+	}
+	return elapsed
+}
+
+// SetChapters stores the chapters of a queued or playing item
+func (mq *MusicQueue) SetChapters(item *QueueItem, chapters []extractor.Chapter) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	item.Chapters = chapters
+}
+
+// Seek moves playback of the current item to a position measured from its start
+func (mq *MusicQueue) Seek(position time.Duration) error {
+	mq.mu.RLock()
+	pipeline := mq.pipeline
+	current := mq.current
+	mq.mu.RUnlock()
+
+	if pipeline == nil || current == nil || !pipeline.IsPlaying() {
+		return fmt.Errorf("nothing is playing")
+	}
+	if current.Duration > 0 && position >= current.Duration {
+		return fmt.Errorf("position is past the end of the track")
+	}
+	return pipeline.Seek(position)
+}
+
+// List returns all items in the queue in effective play order
 func (mq *MusicQueue) List() []*QueueItem {
 	mq.mu.RLock()
 	defer mq.mu.RUnlock()
+	return mq.orderedItems()
+}
 
-	result := make([]*QueueItem, len(mq.items))
-	copy(result, mq.items)
-	return result
+// PositionOf returns the 1-based play position of the most recently added item of the
+// requester with the given user ID, or 0 if they have nothing queued
+func (mq *MusicQueue) PositionOf(requesterID string) int {
+	mq.mu.RLock()
+	defer mq.mu.RUnlock()
+
+	var latest *QueueItem
+	for _, item := range mq.items {
+		if item.requester() == requesterID {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return 0
+	}
+
+	for i, item := range mq.orderedItems() {
+		if item == latest {
+			return i + 1
+		}
+	}
+	return 0
 }
 
 // Size returns the number of items in the queue
@@ -178,6 +676,9 @@ func (mq *MusicQueue) Clear() {
 	queueSize := len(mq.items)
 	mq.items = make([]*QueueItem, 0)
 	mq.current = nil
+	mq.replay = nil
+	mq.rotation = nil
+	mq.lastServed = ""
 	
 	// Use centralized logging
 	if mq.logger != nil {
@@ -187,7 +688,17 @@ func (mq *MusicQueue) Clear() {
 	}
 }
 
-// Remove removes an item at the specified index
+// ReplaceItems replaces the pending items (e.g. after a shuffle) without
+// touching the current track or re-applying per-user limits
+func (mq *MusicQueue) ReplaceItems(items []*QueueItem) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	mq.items = make([]*QueueItem, len(items))
+	copy(mq.items, items)
+}
+
+// Remove removes an item at the specified index of the effective play order
 func (mq *MusicQueue) Remove(index int) error {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
@@ -196,8 +707,8 @@ func (mq *MusicQueue) Remove(index int) error {
 		return fmt.Errorf("invalid index: %d", index)
 	}
 
-	removed := mq.items[index]
-	mq.items = append(mq.items[:index], mq.items[index+1:]...)
+	removed := mq.orderedItems()[index]
+	mq.removeItem(removed)
 	
 	// Use centralized logging
 	if mq.logger != nil {
@@ -256,6 +767,19 @@ func (mq *MusicQueue) SetVoiceConnection(vc *discordgo.VoiceConnection) {
 	mq.voiceConn = vc
 }
 
+// RebindVoiceConnection switches the queue and its playing pipeline to another voice
+// connection, so a rejoin or channel move carries on with the current track
+func (mq *MusicQueue) RebindVoiceConnection(vc *discordgo.VoiceConnection) {
+	mq.mu.Lock()
+	mq.voiceConn = vc
+	pipeline := mq.pipeline
+	mq.mu.Unlock()
+
+	if pipeline != nil && pipeline.IsPlaying() {
+		pipeline.SetVoiceConnection(vc)
+	}
+}
+
 // GetVoiceConnection returns the voice connection
 func (mq *MusicQueue) GetVoiceConnection() *discordgo.VoiceConnection {
 	mq.mu.RLock()
@@ -268,6 +792,11 @@ func (mq *MusicQueue) SetPipeline(pipeline audio.AudioPipeline) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 	mq.pipeline = pipeline
+
+	// New pipelines inherit the guild's volume
+	if pipeline != nil {
+		pipeline.SetVolume(mq.volume)
+	}
 	
 	// Log pipeline state change
 	if mq.logger != nil {
@@ -340,6 +869,7 @@ func (mq *MusicQueue) StopAndCleanup() {
 	}
 
 	if mq.voiceConn != nil {
+		MarkLeavingVoice(mq.guildID)
 		mq.voiceConn.Disconnect()
 		mq.voiceConn = nil
 	}
@@ -405,8 +935,9 @@ func (mq *MusicQueue) CreateNewPipeline() (audio.AudioPipeline, error) {
 }
 
 // StartPlayback starts playback using the new AudioPipelineController
-// This method provides a high-level interface for starting playback with proper error handling
-func (mq *MusicQueue) StartPlayback(url string, voiceConn *discordgo.VoiceConnection) error {
+// This method provides a high-level interface for starting playback with proper error handling.
+// clip limits playback to part of the URL; pass a zero Clip to play all of it.
+func (mq *MusicQueue) StartPlayback(url string, clip audio.Clip, voiceConn *discordgo.VoiceConnection) error {
 	// Create new pipeline if needed
 	if mq.pipeline == nil {
 		pipeline, err := mq.CreateNewPipeline()
@@ -420,7 +951,7 @@ func (mq *MusicQueue) StartPlayback(url string, voiceConn *discordgo.VoiceConnec
 	mq.SetVoiceConnection(voiceConn)
 
 	// Start playback using new pipeline interface
-	if err := mq.pipeline.PlayURL(url, voiceConn); err != nil {
+	if err := mq.pipeline.PlayClip(url, clip, voiceConn); err != nil {
 		if mq.logger != nil {
 			mq.logger.Error("Failed to start playback", err, map[string]interface{}{
 				"url": url,
@@ -461,21 +992,22 @@ func (mq *MusicQueue) SetDB(db *gorm.DB) {
 	}
 }
 
-// GetQueueStatusEmbed creates a centralized embed for queue status
-func (mq *MusicQueue) GetQueueStatusEmbed() *discordgo.MessageEmbed {
+// GetQueueStatusEmbed creates a centralized embed for queue status in the given language
+func (mq *MusicQueue) GetQueueStatusEmbed(lang string) *discordgo.MessageEmbed {
 	mq.mu.RLock()
 	defer mq.mu.RUnlock()
 	
 	// Get current song info
 	var currentSong string
 	if mq.current != nil {
-		currentSong = fmt.Sprintf("**%s** (Requested by: %s)", mq.current.Title, mq.current.RequestedBy)
+		currentSong = i18n.T(lang, "audio.queue.item", mq.current.Title, mq.current.RequestedBy)
 	}
 	
-	// Get queue items as strings
-	queueItems := make([]string, len(mq.items))
-	for i, item := range mq.items {
-		queueItems[i] = fmt.Sprintf("**%s** (Requested by: %s)", item.Title, item.RequestedBy)
+	// Get queue items as strings, in the order they will actually play
+	ordered := mq.orderedItems()
+	queueItems := make([]string, len(ordered))
+	for i, item := range ordered {
+		queueItems[i] = i18n.T(lang, "audio.queue.item", item.Title, item.RequestedBy)
 	}
 	
 	// Log queue status request
@@ -483,9 +1015,18 @@ func (mq *MusicQueue) GetQueueStatusEmbed() *discordgo.MessageEmbed {
 		"current_song": currentSong,
 		"queue_size":   len(mq.items),
 		"is_playing":   mq.isPlaying,
+		"mode":         string(mq.mode),
 	})
-	
-	return mq.embedBuilder.QueueStatus(currentSong, queueItems, len(mq.items))
+
+	statusEmbed := mq.embedBuilder.WithLanguage(lang).QueueStatus(currentSong, queueItems, len(mq.items))
+	if mq.mode == QueueModeFair {
+		statusEmbed.Fields = append(statusEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(lang, "audio.queue.fair_mode.title"),
+			Value:  i18n.T(lang, "audio.queue.fair_mode.description"),
+			Inline: true,
+		})
+	}
+	return statusEmbed
 }
 
 // GetDetailedStatus returns detailed queue status information
@@ -501,6 +1042,11 @@ func (mq *MusicQueue) GetDetailedStatus() map[string]interface{} {
 		"pipeline_playing": mq.pipeline != nil && mq.pipeline.IsPlaying(),
 		"has_voice_conn":   mq.voiceConn != nil,
 		"was_skipped":      mq.wasSkipped,
+		"mode":             string(mq.mode),
+		"max_user_items":   mq.maxUserItems,
+		"max_user_time":    mq.maxUserTime.String(),
+		"loop":             string(mq.loop),
+		"volume":           mq.volume,
 	}
 	
 	if mq.current != nil {
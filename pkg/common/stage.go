@@ -0,0 +1,120 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// maxStageTopicLength is Discord's limit for a stage instance topic
+const maxStageTopicLength = 120
+
+// ErrStageAudienceOnly is returned when the bot could join a stage but never speak on it
+var ErrStageAudienceOnly = i18n.Errorf("voice.stage_audience_only")
+
+// StageSpeaker describes how the bot gets to speak on a stage
+type StageSpeaker int
+
+const (
+	// StageNotStage means the channel is a regular voice channel
+	StageNotStage StageSpeaker = iota
+	// StageModerator means the bot can unsuppress itself and set the topic
+	StageModerator
+	// StageRequestToSpeak means a stage moderator has to accept the bot as a speaker
+	StageRequestToSpeak
+	// StageAudienceOnly means the bot could only listen
+	StageAudienceOnly
+)
+
+// stageVoiceState is the body of a PATCH to the bot's own voice state
+type stageVoiceState struct {
+	ChannelID               string     `json:"channel_id"`
+	Suppress                *bool      `json:"suppress,omitempty"`
+	RequestToSpeakTimestamp *time.Time `json:"request_to_speak_timestamp,omitempty"`
+}
+
+// IsStageChannel reports whether a channel is a stage channel
+func IsStageChannel(s *discordgo.Session, channelID string) bool {
+	channel, err := s.State.Channel(channelID)
+	if err != nil {
+		if channel, err = s.Channel(channelID); err != nil {
+			return false
+		}
+	}
+	return channel.Type == discordgo.ChannelTypeGuildStageVoice
+}
+
+// StageSpeakerFor works out how the bot would get to speak in a channel
+func StageSpeakerFor(s *discordgo.Session, channelID string) StageSpeaker {
+	if !IsStageChannel(s, channelID) || s.State.User == nil {
+		return StageNotStage
+	}
+
+	perms, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		log.Printf("Failed to read stage permissions for channel %s: %v", channelID, err)
+		return StageAudienceOnly
+	}
+
+	switch {
+	case perms&discordgo.PermissionVoiceMuteMembers != 0:
+		return StageModerator
+	case perms&discordgo.PermissionVoiceRequestToSpeak != 0:
+		return StageRequestToSpeak
+	default:
+		return StageAudienceOnly
+	}
+}
+
+// BecomeStageSpeaker unsuppresses the bot on a stage it moderates, or raises its hand otherwise.
+// It returns how the bot will speak; playback can start either way.
+func BecomeStageSpeaker(s *discordgo.Session, guildID, channelID string) (StageSpeaker, error) {
+	speaker := StageSpeakerFor(s, channelID)
+
+	state := stageVoiceState{ChannelID: channelID}
+	switch speaker {
+	case StageNotStage:
+		return speaker, nil
+	case StageAudienceOnly:
+		return speaker, ErrStageAudienceOnly
+	case StageModerator:
+		unsuppressed := false
+		state.Suppress = &unsuppressed
+	case StageRequestToSpeak:
+		now := time.Now()
+		state.RequestToSpeakTimestamp = &now
+	}
+
+	endpoint := discordgo.EndpointGuild(guildID) + "/voice-states/@me"
+	if _, err := s.RequestWithBucketID("PATCH", endpoint, state, endpoint); err != nil {
+		return speaker, fmt.Errorf("failed to become a stage speaker: %w", err)
+	}
+	return speaker, nil
+}
+
+// SetStageTopic shows a topic on the stage, starting the stage if it isn't live yet.
+// Only stage moderators can do this, so callers treat failures as non-fatal.
+func SetStageTopic(s *discordgo.Session, channelID, topic string) error {
+	if runes := []rune(topic); len(runes) > maxStageTopicLength {
+		topic = string(runes[:maxStageTopicLength-1]) + "…"
+	}
+	if topic == "" {
+		return nil
+	}
+
+	params := &discordgo.StageInstanceParams{Topic: topic}
+	if _, err := s.StageInstanceEdit(channelID, params); err == nil {
+		return nil
+	}
+
+	// No live stage to edit; start one
+	params.ChannelID = channelID
+	params.PrivacyLevel = discordgo.StageInstancePrivacyLevelGuildOnly
+	if _, err := s.StageInstanceCreate(params); err != nil {
+		return fmt.Errorf("failed to set stage topic: %w", err)
+	}
+	return nil
+}
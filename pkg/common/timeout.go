@@ -15,23 +15,39 @@ type TimeoutManager struct {
 	mu               sync.RWMutex
 	logger           logging.Logger
 	embedBuilder     embed.AudioEmbedBuilder
-	session          *discordgo.Session
+	sessions         SessionGetter   // Routes each guild to its shard's session
 	presenceManager  PresenceManager // Interface for presence management
 	queueGetter      QueueGetter     // Interface to get queues
+	settingsGetter   SettingsGetter  // Per-guild idle timeouts, announce channels and languages
 }
 
+// DefaultIdleTimeout is how long a guild may stay idle when no setting says otherwise
+const DefaultIdleTimeout = 5 * time.Minute
+
 // QueueGetter interface for getting queues (to avoid circular dependencies)
 type QueueGetter interface {
 	GetQueue(guildID string) *MusicQueue
 }
 
+// SettingsGetter interface for the guild settings the timeout manager honours
+type SettingsGetter interface {
+	IdleTimeout(guildID string) time.Duration
+	AnnounceChannel(guildID string) string
+	Language(guildID string) string
+}
+
+// SessionGetter interface for finding the session of the shard a guild is on
+type SessionGetter interface {
+	SessionForGuild(guildID string) *discordgo.Session
+}
+
 // PresenceManager interface for managing bot presence
 type PresenceManager interface {
-	ClearMusicPresence()
+	ClearMusicPresence(guildID string)
 }
 
 // NewTimeoutManager creates a new TimeoutManager
-func NewTimeoutManager(session *discordgo.Session, presenceManager PresenceManager, queueGetter QueueGetter) *TimeoutManager {
+func NewTimeoutManager(sessions SessionGetter, presenceManager PresenceManager, queueGetter QueueGetter) *TimeoutManager {
 	loggerFactory := logging.GetGlobalLoggerFactory()
 	logger := loggerFactory.CreateLogger("timeout")
 	
@@ -39,12 +55,29 @@ func NewTimeoutManager(session *discordgo.Session, presenceManager PresenceManag
 		lastActivityTime: make(map[string]time.Time),
 		logger:           logger,
 		embedBuilder:     embed.GetGlobalAudioEmbedBuilder(),
-		session:          session,
+		sessions:         sessions,
 		presenceManager:  presenceManager,
 		queueGetter:      queueGetter,
 	}
 }
 
+// SetSettingsGetter makes idle timeouts and notifications follow the guild settings
+func (tm *TimeoutManager) SetSettingsGetter(settingsGetter SettingsGetter) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.settingsGetter = settingsGetter
+}
+
+// idleTimeout returns how long a guild may stay idle. Caller must hold the lock.
+func (tm *TimeoutManager) idleTimeout(guildID string) time.Duration {
+	if tm.settingsGetter != nil {
+		if timeout := tm.settingsGetter.IdleTimeout(guildID); timeout > 0 {
+			return timeout
+		}
+	}
+	return DefaultIdleTimeout
+}
+
 // UpdateActivity updates the last activity time for a guild
 func (tm *TimeoutManager) UpdateActivity(guildID string) {
 	tm.mu.Lock()
@@ -74,7 +107,7 @@ func (tm *TimeoutManager) RemoveGuild(guildID string) {
 func (tm *TimeoutManager) StartMonitoring() {
 	tm.logger.Info("Starting idle timeout monitoring", map[string]interface{}{
 		"check_interval": "30s",
-		"timeout_duration": "5m",
+		"timeout_duration": DefaultIdleTimeout.String(),
 	})
 	
 	go func() {
@@ -94,8 +127,8 @@ func (tm *TimeoutManager) checkForIdleTimeouts() {
 	
 	var timeoutGuilds []string
 	for guildID, lastActivity := range tm.lastActivityTime {
-		// Check if more than 5 minutes have passed since last activity
-		if now.Sub(lastActivity) > 5*time.Minute {
+		// Check if the guild's idle timeout has passed since last activity
+		if now.Sub(lastActivity) > tm.idleTimeout(guildID) {
 			timeoutGuilds = append(timeoutGuilds, guildID)
 		}
 	}
@@ -155,7 +188,7 @@ func (tm *TimeoutManager) handleIdleTimeout(guildID string) {
 	
 	// Clear presence
 	if tm.presenceManager != nil {
-		tm.presenceManager.ClearMusicPresence()
+		tm.presenceManager.ClearMusicPresence(guildID)
 		tm.logger.Debug("Cleared music presence due to timeout", map[string]interface{}{
 			"guild_id": guildID,
 		})
@@ -170,8 +203,31 @@ func (tm *TimeoutManager) handleIdleTimeout(guildID string) {
 
 // sendTimeoutNotification sends a timeout notification to the guild
 func (tm *TimeoutManager) sendTimeoutNotification(guildID string) {
+	tm.mu.RLock()
+	idle := tm.idleTimeout(guildID)
+	var announceChannelID string
+	if tm.settingsGetter != nil {
+		announceChannelID = tm.settingsGetter.AnnounceChannel(guildID)
+	}
+	tm.mu.RUnlock()
+
+	// Only the guild's shard can post to it
+	session := tm.sessions.SessionForGuild(guildID)
+	if session == nil {
+		tm.logger.Warn("Guild's shard is not run by this process, skipping timeout notification", map[string]interface{}{
+			"guild_id": guildID,
+		})
+		return
+	}
+
+	// Prefer the guild's announce channel
+	if announceChannelID != "" {
+		tm.sendTimeoutEmbed(session, guildID, announceChannelID, idle)
+		return
+	}
+
 	// Find a text channel to send the embed
-	_, err := tm.session.Guild(guildID)
+	_, err := session.Guild(guildID)
 	if err != nil {
 		tm.logger.Error("Failed to get guild for timeout notification", err, map[string]interface{}{
 			"guild_id": guildID,
@@ -179,7 +235,7 @@ func (tm *TimeoutManager) sendTimeoutNotification(guildID string) {
 		return
 	}
 	
-	channels, err := tm.session.GuildChannels(guildID)
+	channels, err := session.GuildChannels(guildID)
 	if err != nil {
 		tm.logger.Error("Failed to get guild channels for timeout notification", err, map[string]interface{}{
 			"guild_id": guildID,
@@ -203,10 +259,21 @@ func (tm *TimeoutManager) sendTimeoutNotification(guildID string) {
 		return
 	}
 	
+	tm.sendTimeoutEmbed(session, guildID, targetChannelID, idle)
+}
+
+// sendTimeoutEmbed posts the idle timeout notification to a channel
+func (tm *TimeoutManager) sendTimeoutEmbed(session *discordgo.Session, guildID, targetChannelID string, idle time.Duration) {
 	// Create and send timeout embed using centralized embed system
-	timeoutEmbed := tm.embedBuilder.IdleTimeout()
+	embedBuilder := tm.embedBuilder
+	tm.mu.RLock()
+	if tm.settingsGetter != nil {
+		embedBuilder = embedBuilder.WithLanguage(tm.settingsGetter.Language(guildID))
+	}
+	tm.mu.RUnlock()
+	timeoutEmbed := embedBuilder.IdleTimeout(idle)
 	
-	_, err = tm.session.ChannelMessageSendEmbed(targetChannelID, timeoutEmbed)
+	_, err := session.ChannelMessageSendEmbed(targetChannelID, timeoutEmbed)
 	if err != nil {
 		tm.logger.Error("Failed to send timeout notification", err, map[string]interface{}{
 			"guild_id": guildID,
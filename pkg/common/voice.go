@@ -3,52 +3,95 @@ package common
 import (
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 )
 
+// leavingVoice records guilds the bot is leaving voice in on its own, so the voice
+// state update that follows isn't mistaken for someone else disconnecting it
+var (
+	leavingVoice   = make(map[string]bool)
+	leavingVoiceMu sync.Mutex
+)
+
+// MarkLeavingVoice records that the bot is about to leave voice in a guild on its own
+func MarkLeavingVoice(guildID string) {
+	leavingVoiceMu.Lock()
+	defer leavingVoiceMu.Unlock()
+	leavingVoice[guildID] = true
+}
+
+// TakeLeavingVoice reports whether the bot chose to leave voice in a guild, clearing the record
+func TakeLeavingVoice(guildID string) bool {
+	leavingVoiceMu.Lock()
+	defer leavingVoiceMu.Unlock()
+	leaving := leavingVoice[guildID]
+	delete(leavingVoice, guildID)
+	return leaving
+}
+
 // FindAndJoinUserVoiceChannel finds the user's voice channel and joins it with retry logic
 func FindAndJoinUserVoiceChannel(s *discordgo.Session, userID, guildID string) (*discordgo.VoiceConnection, error) {
+	userChannelID, err := FindUserVoiceChannel(s, userID, guildID)
+	if err != nil {
+		return nil, err
+	}
+	return JoinVoiceChannel(s, guildID, userChannelID)
+}
+
+// FindUserVoiceChannel returns the voice channel a user is connected to
+func FindUserVoiceChannel(s *discordgo.Session, userID, guildID string) (string, error) {
 	guild, err := s.State.Guild(guildID)
 	if err != nil {
-		return nil, fmt.Errorf("could not find guild: %v", err)
+		return "", fmt.Errorf("could not find guild: %v", err)
 	}
 
-	var userChannelID string
 	for _, vs := range guild.VoiceStates {
-		if vs.UserID == userID {
-			userChannelID = vs.ChannelID
-			break
+		if vs.UserID == userID && vs.ChannelID != "" {
+			return vs.ChannelID, nil
 		}
 	}
+	return "", i18n.Errorf("voice.not_in_channel")
+}
 
-	if userChannelID == "" {
-		return nil, fmt.Errorf("you must be in a voice channel to play music")
+// JoinVoiceChannel joins (or moves an existing connection to) a voice channel, retrying
+// with exponential backoff, and waits for the connection to be ready. On a stage the bot
+// becomes a speaker, or requests to speak; stages it could only listen on are refused.
+func JoinVoiceChannel(s *discordgo.Session, guildID, channelID string) (*discordgo.VoiceConnection, error) {
+	if StageSpeakerFor(s, channelID) == StageAudienceOnly {
+		return nil, ErrStageAudienceOnly
 	}
 
 	// Get channel info for logging
-	channel, err := s.State.Channel(userChannelID)
+	channel, err := s.State.Channel(channelID)
 	channelName := "Unknown"
 	if err == nil {
 		channelName = channel.Name
 	}
 
-	log.Printf("Joining voice channel: %s (%s) in guild: %s", channelName, userChannelID, guildID)
+	log.Printf("Joining voice channel: %s (%s) in guild: %s", channelName, channelID, guildID)
+
+	// A leave that never produced a voice state update must not hide a later disconnect
+	TakeLeavingVoice(guildID)
 
 	// Join with retry logic
 	var vc *discordgo.VoiceConnection
-	maxRetries := 3
+	maxRetries := 4
+	backoff := time.Second
 
 	for i := 0; i < maxRetries; i++ {
-		vc, err = s.ChannelVoiceJoin(guildID, userChannelID, false, true)
+		vc, err = s.ChannelVoiceJoin(guildID, channelID, false, true)
 		if err == nil {
 			break
 		}
 
 		log.Printf("Voice join attempt %d/%d failed: %v", i+1, maxRetries, err)
 		if i < maxRetries-1 {
-			time.Sleep(time.Duration(i+1) * time.Second)
+			time.Sleep(backoff)
+			backoff *= 2
 		}
 	}
 
@@ -64,11 +107,20 @@ func FindAndJoinUserVoiceChannel(s *discordgo.Session, userID, guildID string) (
 	for {
 		select {
 		case <-timeout:
+			MarkLeavingVoice(guildID)
 			vc.Disconnect()
 			return nil, fmt.Errorf("voice connection timed out")
 		case <-ticker.C:
-			if vc.Ready {
+			vc.RLock()
+			ready := vc.Ready
+			vc.RUnlock()
+			if ready {
 				log.Printf("Voice connection ready for guild: %s", guildID)
+				if speaker, err := BecomeStageSpeaker(s, guildID, channelID); err != nil {
+					log.Printf("Stage speaker setup failed in guild %s: %v", guildID, err)
+				} else if speaker == StageRequestToSpeak {
+					log.Printf("Requested to speak on stage %s in guild: %s", channelID, guildID)
+				}
 				return vc, nil
 			}
 		}
@@ -80,6 +132,7 @@ func DisconnectFromVoiceChannel(s *discordgo.Session, guildID string) error {
 	// Get all voice connections for the guild
 	for _, vc := range s.VoiceConnections {
 		if vc.GuildID == guildID {
+			MarkLeavingVoice(guildID)
 			vc.Disconnect()
 			log.Printf("Disconnected from voice channel in guild: %s", guildID)
 			return nil
@@ -89,3 +142,53 @@ func DisconnectFromVoiceChannel(s *discordgo.Session, guildID string) error {
 	log.Printf("No voice connection found for guild: %s", guildID)
 	return nil
 }
+
+// GetBotVoiceChannelID returns the voice channel the bot is connected to in a guild, or "" if none
+func GetBotVoiceChannelID(s *discordgo.Session, guildID string) string {
+	if s.State == nil || s.State.User == nil {
+		return ""
+	}
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return ""
+	}
+
+	for _, vs := range guild.VoiceStates {
+		if vs.UserID == s.State.User.ID {
+			return vs.ChannelID
+		}
+	}
+	return ""
+}
+
+// GetVoiceChannelListeners returns the IDs of non-bot users in a voice channel
+func GetVoiceChannelListeners(s *discordgo.Session, guildID, channelID string) []string {
+	guild, err := s.State.Guild(guildID)
+	if err != nil || channelID == "" {
+		return nil
+	}
+
+	var listeners []string
+	for _, vs := range guild.VoiceStates {
+		if vs.ChannelID != channelID {
+			continue
+		}
+
+		// Skip bots, using the state cache first and the voice state member as fallback
+		if member, err := s.State.Member(guildID, vs.UserID); err == nil && member.User != nil {
+			if member.User.Bot {
+				continue
+			}
+		} else if vs.Member != nil && vs.Member.User != nil && vs.Member.User.Bot {
+			continue
+		}
+
+		if s.State.User != nil && vs.UserID == s.State.User.ID {
+			continue
+		}
+
+		listeners = append(listeners, vs.UserID)
+	}
+	return listeners
+}
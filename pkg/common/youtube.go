@@ -11,6 +11,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+	"github.com/latoulicious/HKTM/pkg/extractor"
 )
 
 // IsYouTubeURL checks if a URL appears to be from YouTube
@@ -72,28 +75,27 @@ func GetYouTubeThumbnailURL(videoID string) string {
 	return fmt.Sprintf("https://img.youtube.com/vi/%s/maxresdefault.jpg", videoID)
 }
 
-// GetYouTubeMetadata extracts both title and duration from a YouTube URL with timeout and retry
+// YouTubeWatchURL builds the canonical watch URL for a video ID
+func YouTubeWatchURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + videoID
+}
+
+// GetYouTubeMetadata extracts both title and duration from a YouTube URL with timeout and retry.
+// Results come from the shared metadata cache when the video was extracted recently.
 func GetYouTubeMetadata(urlStr string) (title string, duration time.Duration, err error) {
 	log.Printf("Extracting metadata from: %s", urlStr)
 
+	ytExtractor := extractor.GetGlobalExtractor()
+	videoID := ExtractYouTubeVideoID(urlStr)
+
 	// Add timeout and retry logic
 	maxRetries := 3
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		info, err := ytExtractor.Metadata(ctx, videoID, urlStr)
+		cancel()
 
-		// Use yt-dlp to get both title and duration
-		cmd := exec.CommandContext(ctx, "yt-dlp",
-			"--no-playlist",
-			"--no-warnings",
-			"--print", "title",
-			"--print", "duration",
-			urlStr)
-
-		var out bytes.Buffer
-		cmd.Stdout = &out
-
-		if err := cmd.Run(); err != nil {
-			cancel()
+		if err != nil {
 			if attempt < maxRetries-1 {
 				log.Printf("Metadata extraction attempt %d failed, retrying in 2 seconds...", attempt+1)
 				time.Sleep(2 * time.Second)
@@ -103,113 +105,87 @@ func GetYouTubeMetadata(urlStr string) (title string, duration time.Duration, er
 			return "Unknown Title", 0, fmt.Errorf("failed to extract metadata: %v", err)
 		}
 
-		cancel()
-
-		output := strings.TrimSpace(out.String())
-		lines := strings.Split(output, "\n")
+		log.Printf("Extracted metadata - Title: %s, Duration: %v", info.Title, info.Duration)
+		return info.Title, info.Duration, nil
+	}
 
-		if len(lines) >= 1 {
-			title = strings.TrimSpace(lines[0])
-		}
-		if len(lines) >= 2 {
-			durationStr := strings.TrimSpace(lines[1])
-			if durationStr != "" && durationStr != "None" {
-				// yt-dlp returns duration in seconds
-				if seconds, parseErr := strconv.ParseFloat(durationStr, 64); parseErr == nil {
-					duration = time.Duration(seconds * float64(time.Second))
-				}
-			}
-		}
+	return "Unknown Title", 0, fmt.Errorf("failed to extract metadata after %d attempts", maxRetries)
+}
 
-		if title == "" {
-			title = "Unknown Title"
-		}
+// GetYouTubeChapters returns a video's chapters from its metadata, which is usually
+// already cached from when the video was queued
+func GetYouTubeChapters(urlStr string) ([]extractor.Chapter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-		log.Printf("Extracted metadata - Title: %s, Duration: %v", title, duration)
-		return title, duration, nil
+	info, err := extractor.GetGlobalExtractor().Metadata(ctx, ExtractYouTubeVideoID(urlStr), urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract chapters: %w", err)
 	}
-
-	return "Unknown Title", 0, fmt.Errorf("failed to extract metadata after %d attempts", maxRetries)
+	return info.Chapters, nil
 }
 
-// GetYouTubeAudioStreamWithMetadata extracts stream URL, title, and duration with improved reliability
+// GetYouTubeAudioStreamWithMetadata extracts stream URL, title, and duration, reusing a cached
+// stream URL when another guild or a replay resolved the same video recently
 func GetYouTubeAudioStreamWithMetadata(urlStr string) (streamURL, title string, duration time.Duration, err error) {
 	log.Printf("Extracting audio stream and metadata from: %s", urlStr)
 
-	// First, get metadata (title and duration)
-	title, duration, metaErr := GetYouTubeMetadata(urlStr)
-	if metaErr != nil {
-		log.Printf("Warning: Failed to get metadata: %v", metaErr)
-		title = "Unknown Title"
-		duration = 0
+	stream, info, err := resolveStream(urlStr)
+	if err != nil {
+		// Fall back to cached metadata so the caller can still show what was requested
+		if cached, ok := extractor.GetGlobalExtractor().Cached(ExtractYouTubeVideoID(urlStr)); ok {
+			return "", cached.Title, cached.Duration, err
+		}
+		return "", "Unknown Title", 0, err
 	}
 
-	// Extract a fresh stream URL
-	streamURL, err = extractFreshStreamURL(urlStr)
-	if err != nil {
-		return "", title, duration, err
+	// A cached stream URL comes without metadata; it is usually cached as well
+	if info == nil {
+		title, duration, _ = GetYouTubeMetadata(urlStr)
+		return stream.URL, title, duration, nil
 	}
 
-	return streamURL, title, duration, nil
+	return stream.URL, info.Title, info.Duration, nil
 }
 
-// extractFreshStreamURL extracts a fresh stream URL with multiple strategies
-func extractFreshStreamURL(urlStr string) (streamURL string, err error) {
-	strategies := [][]string{
-		// Strategy 1: Best audio with format preference (updated for current YouTube)
-		{"-f", "bestaudio[ext=m4a]/bestaudio[ext=webm]/bestaudio[ext=mp4]/bestaudio"},
-
-		// Strategy 2: Android client (often bypasses restrictions)
-		{"-f", "bestaudio", "--extractor-args", "youtube:player_client=android"},
-
-		// Strategy 3: Web client with cookies
-		{"-f", "bestaudio", "--extractor-args", "youtube:player_client=web"},
-
-		// Strategy 4: Last resort - any audio
-		{"-f", "worst[ext=m4a]/worst"},
-	}
+// resolveStream resolves an audio stream URL through the shared stream cache, retrying
+// the extraction strategies once if they all fail
+func resolveStream(urlStr string) (*extractor.StreamURL, *extractor.VideoInfo, error) {
+	ytExtractor := extractor.GetGlobalExtractor()
 
+	var lastErr error
 	maxRetries := 2
 	for retry := 0; retry < maxRetries; retry++ {
-		for i, strategy := range strategies {
-			log.Printf("Trying extraction strategy %d/%d (retry %d/%d)", i+1, len(strategies), retry+1, maxRetries)
-
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-
-			args := append([]string{"--no-playlist", "--no-warnings", "-g"}, strategy...)
-			args = append(args, urlStr)
-
-			cmd := exec.CommandContext(ctx, "yt-dlp", args...)
-			var out bytes.Buffer
-			cmd.Stdout = &out
-
-			if err := cmd.Run(); err != nil {
-				cancel()
-				log.Printf("Strategy %d failed: %v", i+1, err)
-				continue
-			}
-
-			cancel()
+		ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+		stream, info, err := ytExtractor.ResolveStream(ctx, urlStr)
+		cancel()
 
-			streamURL = strings.TrimSpace(out.String())
-			if streamURL != "" {
-				// Take first URL if multiple are returned
-				urls := strings.Split(streamURL, "\n")
-				if len(urls) > 0 && urls[0] != "" {
-					streamURL = urls[0]
-					log.Printf("Successfully extracted stream URL using strategy %d", i+1)
-					return streamURL, nil
-				}
+		if err == nil {
+			if info == nil {
+				log.Printf("Reusing cached stream URL (strategy %s, expires %s)", stream.Strategy, stream.ExpiresAt.Format(time.RFC3339))
+			} else {
+				log.Printf("Successfully extracted stream URL using strategy %s (format %s)", stream.Strategy, stream.FormatID)
 			}
+			return stream, info, nil
 		}
 
+		lastErr = err
 		if retry < maxRetries-1 {
 			log.Printf("All strategies failed on attempt %d, retrying in 3 seconds...", retry+1)
 			time.Sleep(3 * time.Second)
 		}
 	}
 
-	return "", fmt.Errorf("failed to extract audio stream URL after trying all strategies with %d retries", maxRetries)
+	return nil, nil, fmt.Errorf("failed to extract audio stream URL after trying all strategies with %d retries: %w", maxRetries, lastErr)
+}
+
+// extractFreshStreamURL returns a stream URL that is valid for at least the refresh margin
+func extractFreshStreamURL(urlStr string) (streamURL string, err error) {
+	stream, _, err := resolveStream(urlStr)
+	if err != nil {
+		return "", err
+	}
+	return stream.URL, nil
 }
 
 // GetFreshYouTubeStreamURL extracts a fresh stream URL for immediate use
@@ -228,8 +204,15 @@ func SearchYouTubeAndGetURL(query string) (url string, title string, duration ti
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 
+		// Searches count against the extraction budget like any other yt-dlp run
+		release, admitErr := audio.GetGlobalProcessBudget().AdmitExtraction(ctx, false)
+		if admitErr != nil {
+			cancel()
+			return "", "", 0, fmt.Errorf("failed to search YouTube: %w", admitErr)
+		}
+
 		// Use yt-dlp to search for videos
-		cmd := exec.CommandContext(ctx, "yt-dlp",
+		cmd := exec.CommandContext(ctx, extractor.GetGlobalExtractor().BinaryPath(),
 			"--no-playlist",
 			"--no-warnings",
 			"--print", "webpage_url",
@@ -244,6 +227,7 @@ func SearchYouTubeAndGetURL(query string) (url string, title string, duration ti
 		cmd.Stderr = &stderr
 
 		runErr := cmd.Run()
+		release()
 		cancel()
 
 		output := strings.TrimSpace(out.String())
@@ -294,6 +278,89 @@ func SearchYouTubeAndGetURL(query string) (url string, title string, duration ti
 	return "", "", 0, fmt.Errorf("failed to search YouTube after %d attempts", maxRetries)
 }
 
+// SearchResult is a single YouTube search hit
+type SearchResult struct {
+	VideoID  string
+	URL      string
+	Title    string
+	Channel  string
+	Duration time.Duration
+}
+
+// searchResultTemplate prints one tab-separated line per hit; the title comes last so tabs in it survive
+const searchResultTemplate = "%(id)s\t%(duration)s\t%(channel,uploader)s\t%(title)s"
+
+// SearchYouTube returns up to limit search results for a query.
+// It uses a flat search so only the result listing is fetched, not every video page.
+func SearchYouTube(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	release, err := audio.GetGlobalProcessBudget().AdmitExtraction(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("search not started: %w", err)
+	}
+	defer release()
+
+	cmd := exec.CommandContext(ctx, extractor.GetGlobalExtractor().BinaryPath(),
+		"--flat-playlist",
+		"--no-warnings",
+		"--print", searchResultTemplate,
+		fmt.Sprintf("ytsearch%d:%s", limit, query))
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	results := parseSearchResults(out.String())
+
+	// Keep whatever was printed before a timeout or late failure
+	if len(results) > 0 {
+		return results, nil
+	}
+	if runErr != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("search timed out: %w", ctx.Err())
+		}
+		log.Printf("Failed to search YouTube: %v, stderr: %s", runErr, stderr.String())
+		return nil, fmt.Errorf("failed to search YouTube: %v", runErr)
+	}
+	return nil, fmt.Errorf("no search results found")
+}
+
+// parseSearchResults parses the lines printed with searchResultTemplate
+func parseSearchResults(output string) []SearchResult {
+	var results []SearchResult
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 4)
+		if len(fields) < 4 || fields[0] == "" || fields[0] == "NA" {
+			continue
+		}
+
+		var duration time.Duration
+		if seconds, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			duration = time.Duration(seconds * float64(time.Second))
+		}
+
+		channel := fields[2]
+		if channel == "NA" {
+			channel = ""
+		}
+
+		results = append(results, SearchResult{
+			VideoID:  fields[0],
+			URL:      YouTubeWatchURL(fields[0]),
+			Title:    strings.TrimSpace(fields[3]),
+			Channel:  channel,
+			Duration: duration,
+		})
+	}
+	return results
+}
+
 // IsURL checks if a string appears to be a URL
 func IsURL(str string) bool {
 	return strings.HasPrefix(str, "http://") || strings.HasPrefix(str, "https://") ||
@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// AddUmaTrigramIndexes installs pg_trgm and adds trigram indexes to the Uma character and
+// support card names so fuzzy searches don't scan the tables. Without the extension the
+// search still works by ranking every row, so a database that refuses it is only warned
+// about; the repositories check for the extension and skip the trigram lookups.
+func AddUmaTrigramIndexes(db *gorm.DB) error {
+	log.Println("Running migration: Add trigram indexes for Uma search...")
+
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("Warning: pg_trgm is not available, Uma search will run without trigram indexes: %v", err)
+		return nil
+	}
+
+	// GIN trigram indexes serve ILIKE, % and <% lookups on the searched names
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_characters_name_en_trgm ON characters USING gin (name_en gin_trgm_ops)").Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_characters_name_jp_trgm ON characters USING gin (name_jp gin_trgm_ops)").Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_support_cards_title_en_trgm ON support_cards USING gin (title_en gin_trgm_ops)").Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_support_cards_title_trgm ON support_cards USING gin (title gin_trgm_ops)").Error; err != nil {
+		return err
+	}
+
+	log.Println("Uma trigram indexes migration completed successfully!")
+	return nil
+}
+
+// RollbackUmaTrigramIndexes drops the Uma trigram indexes. The pg_trgm extension is left
+// installed since other database objects may depend on it.
+func RollbackUmaTrigramIndexes(db *gorm.DB) error {
+	log.Println("Running rollback: Remove trigram indexes for Uma search...")
+
+	for _, index := range []string{
+		"idx_characters_name_en_trgm",
+		"idx_characters_name_jp_trgm",
+		"idx_support_cards_title_en_trgm",
+		"idx_support_cards_title_trgm",
+	} {
+		if err := db.Exec("DROP INDEX IF EXISTS " + index).Error; err != nil {
+			log.Printf("Warning: Failed to drop %s: %v", index, err)
+		}
+	}
+
+	log.Println("Uma trigram indexes rollback completed successfully!")
+	return nil
+}
@@ -26,6 +26,14 @@ func RunMigration(db *gorm.DB) error {
 		&models.AudioMetric{},
 		&models.AudioLog{},
 		&models.QueueTimeout{},
+		&models.GuildPermission{},
+		&models.PlayHistory{},
+		&models.VideoMetadata{},
+		&models.GuildSettings{},
+		&models.GuildSettingsAudit{},
+		&models.CustomCommand{},
+		&models.GuildRateLimit{},
+		&models.UmaAlias{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
@@ -36,6 +44,11 @@ func RunMigration(db *gorm.DB) error {
 		log.Fatalf("Failed to run centralized logging migration: %v", err)
 	}
 
+	// Add trigram indexes for the fuzzy Uma search
+	if err := AddUmaTrigramIndexes(db); err != nil {
+		log.Fatalf("Failed to run Uma trigram index migration: %v", err)
+	}
+
 	log.Println("Migrations completed successfully!")
 	return nil
 }
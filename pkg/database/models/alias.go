@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UmaAlias is a nickname players use for an Uma Musume character or support card, such as
+// "Mac" for Mejiro McQueen. Aliases without a guild are bundled with the bot and apply
+// everywhere; a guild's own alias with the same key takes precedence, and a hidden one
+// turns the bundled alias off in that guild.
+type UmaAlias struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID     string    `gorm:"uniqueIndex:idx_uma_aliases_key;not null"` // Empty for bundled aliases
+	Kind        string    `gorm:"uniqueIndex:idx_uma_aliases_key;not null"` // character or support
+	Key         string    `gorm:"uniqueIndex:idx_uma_aliases_key;not null"` // Normalized alias, as searches look it up
+	Alias       string    `gorm:"not null"`                                 // The alias as it was written
+	Target      string    `gorm:"not null"`                                 // English name of the character or title of the card
+	CharacterID int       `gorm:"index"`                                    // API data ID of the aliased character, 0 until linked
+	SupportID   int       `gorm:"index"`                                    // API data ID of the aliased support card, 0 until linked
+	CreatedBy   string    `gorm:"index"`                                    // User ID of whoever added it, empty for bundled aliases
+	Hidden      bool      `gorm:"not null;default:false"`                   // Hides the bundled alias with the same key in the guild
+	CreatedAt   time.Time `gorm:"default:now()"`
+}
+
+// TableName returns the table name for UmaAlias
+func (UmaAlias) TableName() string {
+	return "uma_aliases"
+}
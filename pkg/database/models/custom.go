@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomCommand is a guild-defined command: an alias for a built-in command or a
+// canned text or embed response
+type CustomCommand struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID   string    `gorm:"uniqueIndex:idx_custom_commands_name;not null"`
+	Name      string    `gorm:"uniqueIndex:idx_custom_commands_name;not null"`
+	Kind      string    `gorm:"not null"` // alias, text or embed
+	Content   string    `gorm:"not null"` // The aliased command line, or the response template
+	CreatedBy string    `gorm:"index"`    // User ID of whoever defined the command
+	CreatedAt time.Time `gorm:"default:now()"`
+}
+
+// TableName returns the table name for CustomCommand
+func (CustomCommand) TableName() string {
+	return "custom_commands"
+}
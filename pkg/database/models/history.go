@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayHistory records a track that started playing in a guild
+type PlayHistory struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID     string    `gorm:"index:idx_play_history_guild_played;not null"`
+	VideoID     string    `gorm:"index;not null"`
+	URL         string    `gorm:"not null"`
+	Title       string    `gorm:"not null"`
+	Duration    int64     // Seconds, 0 when unknown
+	RequestedBy string    // Username of whoever queued the track, for display
+	RequesterID string    `gorm:"index"` // Discord user ID of whoever queued the track
+	PlayedAt    time.Time `gorm:"index:idx_play_history_guild_played;not null"`
+}
+
+// TableName returns the table name for PlayHistory
+func (PlayHistory) TableName() string {
+	return "play_history"
+}
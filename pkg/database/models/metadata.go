@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// VideoMetadata caches the parsed yt-dlp metadata for a video
+type VideoMetadata struct {
+	VideoID     string `gorm:"primaryKey"`
+	Title       string `gorm:"not null"`
+	Uploader    string
+	Thumbnail   string
+	WebpageURL  string
+	Duration    float64   // Seconds, 0 when unknown or live
+	LiveStatus  string    // not_live, is_live, was_live, is_upcoming, post_live
+	Chapters    string    `gorm:"type:jsonb"` // JSON array of {title, start, end} in seconds
+	ExtractedAt time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
+
+// TableName returns the table name for VideoMetadata
+func (VideoMetadata) TableName() string {
+	return "video_metadata"
+}
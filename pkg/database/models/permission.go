@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuildPermission grants a capability to a role within a guild
+type GuildPermission struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID    string    `gorm:"uniqueIndex:idx_guild_permissions_grant;not null"`
+	Capability string    `gorm:"uniqueIndex:idx_guild_permissions_grant;not null"` // e.g. queue.add, playback.control
+	RoleID     string    `gorm:"uniqueIndex:idx_guild_permissions_grant;not null"`
+	GrantedBy  string    `gorm:"index"` // User ID of whoever created the mapping
+	CreatedAt  time.Time `gorm:"default:now()"`
+}
+
+// TableName returns the table name for GuildPermission
+func (GuildPermission) TableName() string {
+	return "guild_permissions"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuildRateLimit overrides a command's default rate limit within a guild
+type GuildRateLimit struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID    string    `gorm:"uniqueIndex:idx_guild_rate_limits_bucket;not null"`
+	Bucket     string    `gorm:"uniqueIndex:idx_guild_rate_limits_bucket;not null"` // Command name, or command and subcommand, e.g. "uma sync"
+	Uses       int       `gorm:"not null"`                                          // 0 removes the limit
+	PerSeconds int       `gorm:"not null"`
+	UpdatedBy  string    `gorm:"index"` // User ID of whoever set the override
+	UpdatedAt  time.Time
+}
+
+// TableName returns the table name for GuildRateLimit
+func (GuildRateLimit) TableName() string {
+	return "guild_rate_limits"
+}
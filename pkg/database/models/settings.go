@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuildSettings holds a guild's configuration; guilds without a row use the defaults
+type GuildSettings struct {
+	GuildID                string `gorm:"primaryKey"`
+	Prefix                 string `gorm:"not null;default:'!'"`
+	AnnounceChannelID      string // Empty announces in the channel the music was requested in
+	Language               string `gorm:"not null;default:'en'"`
+	IdleTimeoutSeconds     int    `gorm:"not null;default:300"`
+	MaxQueueLength         int    `gorm:"not null;default:0"` // 0 = unlimited
+	MusicChannelIDs        string // Comma-separated text channels music commands are allowed in; empty allows all
+	QueueMode              string `gorm:"not null;default:'fifo'"`
+	MaxUserSongs           int    `gorm:"not null;default:0"` // 0 = unlimited
+	MaxUserDurationSeconds int    `gorm:"not null;default:0"` // 0 = unlimited
+	VoteSkipThreshold      int    `gorm:"not null;default:0"` // Percentage of listeners; 0 uses the bot's default
+	UpdatedBy              string // User ID of whoever changed the settings last
+	UpdatedAt              time.Time
+}
+
+// TableName returns the table name for GuildSettings
+func (GuildSettings) TableName() string {
+	return "guild_settings"
+}
+
+// GuildSettingsAudit records a single change to a guild setting
+type GuildSettingsAudit struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	GuildID   string    `gorm:"index;not null"`
+	Key       string    `gorm:"not null"`
+	OldValue  string
+	NewValue  string
+	ChangedBy string    `gorm:"index"` // User ID of whoever made the change
+	CreatedAt time.Time `gorm:"default:now()"`
+}
+
+// TableName returns the table name for GuildSettingsAudit
+func (GuildSettingsAudit) TableName() string {
+	return "guild_settings_audit"
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UmaAliasRepository handles database operations for UmaAlias model
+type UmaAliasRepository struct {
+	db *gorm.DB
+}
+
+func NewUmaAliasRepository(db *gorm.DB) *UmaAliasRepository {
+	return &UmaAliasRepository{db: db}
+}
+
+// FindAlias returns the guild's alias for a key, or the bundled one when the guild has
+// none; nil when neither exists. A hidden guild alias is returned as is.
+func (r *UmaAliasRepository) FindAlias(guildID, kind, key string) (*models.UmaAlias, error) {
+	var alias models.UmaAlias
+	// Guild aliases sort after the empty guild ID of bundled ones
+	err := r.db.Where("kind = ? AND key = ? AND guild_id IN (?, '')", kind, key, guildID).
+		Order("guild_id DESC").
+		First(&alias).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// GetAliases returns the aliases of a kind that work in a guild, grouped by target: the
+// guild's own and the bundled ones it neither replaced nor hid
+func (r *UmaAliasRepository) GetAliases(guildID, kind string) ([]models.UmaAlias, error) {
+	var rows []models.UmaAlias
+	err := r.db.Where("kind = ? AND guild_id IN (?, '')", kind, guildID).
+		Order("target, guild_id, alias").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	overridden := make(map[string]bool)
+	for _, row := range rows {
+		if row.GuildID != "" {
+			overridden[row.Key] = true
+		}
+	}
+
+	aliases := rows[:0]
+	for _, row := range rows {
+		if row.Hidden || (row.GuildID == "" && overridden[row.Key]) {
+			continue
+		}
+		aliases = append(aliases, row)
+	}
+	return aliases, nil
+}
+
+// SaveAlias creates the alias, or points an existing one with the same key elsewhere
+func (r *UmaAliasRepository) SaveAlias(alias *models.UmaAlias) error {
+	return r.db.Where(models.UmaAlias{
+		GuildID: alias.GuildID,
+		Kind:    alias.Kind,
+		Key:     alias.Key,
+	}).Assign(map[string]interface{}{
+		// A map so zero values, such as un-hiding, are written too
+		"alias":        alias.Alias,
+		"target":       alias.Target,
+		"character_id": alias.CharacterID,
+		"support_id":   alias.SupportID,
+		"created_by":   alias.CreatedBy,
+		"hidden":       alias.Hidden,
+	}).FirstOrCreate(alias).Error
+}
+
+// SeedAliases inserts bundled aliases, leaving any that already exist untouched, and
+// deletes the bundled aliases that are no longer in the bundle
+func (r *UmaAliasRepository) SeedAliases(aliases []models.UmaAlias) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(aliases) == 0 {
+			return tx.Where("guild_id = ''").Delete(&models.UmaAlias{}).Error
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&aliases).Error; err != nil {
+			return err
+		}
+
+		bundled := make([][]interface{}, 0, len(aliases))
+		for _, alias := range aliases {
+			bundled = append(bundled, []interface{}{alias.Kind, alias.Key})
+		}
+		return tx.Where("guild_id = '' AND (kind, key) NOT IN ?", bundled).Delete(&models.UmaAlias{}).Error
+	})
+}
+
+// LinkAlias records the character or support card an alias resolved to
+func (r *UmaAliasRepository) LinkAlias(id uuid.UUID, characterID, supportID int) error {
+	return r.db.Model(&models.UmaAlias{}).Where("id = ?", id).Updates(models.UmaAlias{
+		CharacterID: characterID,
+		SupportID:   supportID,
+	}).Error
+}
+
+func (r *UmaAliasRepository) DeleteAlias(guildID, kind, key string) (int64, error) {
+	result := r.db.Where("guild_id = ? AND kind = ? AND key = ?", guildID, kind, key).Delete(&models.UmaAlias{})
+	return result.RowsAffected, result.Error
+}
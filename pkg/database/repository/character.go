@@ -4,11 +4,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/latoulicious/HKTM/pkg/database/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CharacterRepository handles database operations for Character model
 type CharacterRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	trigram trigramSupport
 }
 
 func NewCharacterRepository(db *gorm.DB) *CharacterRepository {
@@ -39,6 +41,59 @@ func (r *CharacterRepository) GetCharacterByName(name string) (*models.Character
 	return &character, nil
 }
 
+// HasTrigramSearch reports whether FindCharacterCandidates can run, which needs pg_trgm
+func (r *CharacterRepository) HasTrigramSearch() bool {
+	return r.trigram.isAvailable(r.db)
+}
+
+// FindCharacterCandidates returns up to limit characters whose English or Japanese name
+// contains the query or resembles it by pg_trgm trigram similarity, most similar first.
+// It fails when the pg_trgm extension isn't installed; see HasTrigramSearch.
+func (r *CharacterRepository) FindCharacterCandidates(query string, limit int) ([]models.Character, error) {
+	var characters []models.Character
+	pattern := "%" + escapeLike(query) + "%"
+
+	err := r.db.
+		Where("name_en ILIKE ? OR name_jp ILIKE ? OR name_en % ? OR name_jp % ? OR ? <% name_en OR ? <% name_jp",
+			pattern, pattern, query, query, query, query).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "GREATEST(word_similarity(?, name_en), word_similarity(?, name_jp)) DESC",
+			Vars:               []interface{}{query, query},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&characters).Error
+	if err != nil {
+		return nil, err
+	}
+	return characters, nil
+}
+
+// SuggestCharacters returns up to limit distinct characters whose English or Japanese
+// name contains the query, names starting with it first
+func (r *CharacterRepository) SuggestCharacters(query string, limit int) ([]models.Character, error) {
+	var characters []models.Character
+	pattern := "%" + escapeLike(query) + "%"
+	prefix := escapeLike(query) + "%"
+
+	// Syncs may store a character more than once, so group by name
+	err := r.db.Model(&models.Character{}).
+		Select("name_en, MAX(name_jp) AS name_jp, MAX(character_id) AS character_id").
+		Where("name_en ILIKE ? OR name_jp ILIKE ?", pattern, pattern).
+		Group("name_en").
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "MIN(CASE WHEN name_en ILIKE ? OR name_jp ILIKE ? THEN 0 ELSE 1 END), name_en",
+			Vars:               []interface{}{prefix, prefix},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&characters).Error
+	if err != nil {
+		return nil, err
+	}
+	return characters, nil
+}
+
 func (r *CharacterRepository) GetCharacterByCharacterID(characterID int) (*models.Character, error) {
 	var character models.Character
 	if err := r.db.Where("character_id = ?", characterID).First(&character).Error; err != nil {
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// CustomCommandRepository handles database operations for CustomCommand model
+type CustomCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomCommandRepository(db *gorm.DB) *CustomCommandRepository {
+	return &CustomCommandRepository{db: db}
+}
+
+func (r *CustomCommandRepository) GetCustomCommandsByGuildID(guildID string) ([]models.CustomCommand, error) {
+	var commands []models.CustomCommand
+	if err := r.db.Where("guild_id = ?", guildID).Order("name").Find(&commands).Error; err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// SaveCustomCommand creates the command, or replaces the definition of an existing one with the same name
+func (r *CustomCommandRepository) SaveCustomCommand(command *models.CustomCommand) error {
+	return r.db.Where(models.CustomCommand{
+		GuildID: command.GuildID,
+		Name:    command.Name,
+	}).Assign(models.CustomCommand{
+		Kind:      command.Kind,
+		Content:   command.Content,
+		CreatedBy: command.CreatedBy,
+	}).FirstOrCreate(command).Error
+}
+
+func (r *CustomCommandRepository) DeleteCustomCommand(guildID, name string) (int64, error) {
+	result := r.db.Where("guild_id = ? AND name = ?", guildID, name).Delete(&models.CustomCommand{})
+	return result.RowsAffected, result.Error
+}
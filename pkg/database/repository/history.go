@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// PlayHistoryRepository handles database operations for PlayHistory model
+type PlayHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayHistoryRepository(db *gorm.DB) *PlayHistoryRepository {
+	return &PlayHistoryRepository{db: db}
+}
+
+func (r *PlayHistoryRepository) RecordPlay(entry *models.PlayHistory) error {
+	return r.db.Create(entry).Error
+}
+
+// SearchGuildHistory returns the most recent plays in a guild whose title contains the query,
+// one row per video
+func (r *PlayHistoryRepository) SearchGuildHistory(guildID, query string, limit int) ([]models.PlayHistory, error) {
+	var history []models.PlayHistory
+
+	latest := r.db.Model(&models.PlayHistory{}).
+		Select("video_id, MAX(played_at) AS played_at").
+		Where("guild_id = ?", guildID).
+		Group("video_id")
+
+	tx := r.db.Table("play_history AS h").
+		Select("h.*").
+		Joins("JOIN (?) AS latest ON latest.video_id = h.video_id AND latest.played_at = h.played_at", latest).
+		Where("h.guild_id = ?", guildID)
+
+	if query = strings.TrimSpace(query); query != "" {
+		tx = tx.Where("h.title ILIKE ?", "%"+escapeLike(query)+"%")
+	}
+
+	if err := tx.Order("h.played_at DESC").Limit(limit).Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VideoMetadataRepository handles database operations for VideoMetadata model
+type VideoMetadataRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoMetadataRepository(db *gorm.DB) *VideoMetadataRepository {
+	return &VideoMetadataRepository{db: db}
+}
+
+// GetVideoMetadata returns cached metadata for a video, or nil if none is fresh
+func (r *VideoMetadataRepository) GetVideoMetadata(videoID string) (*models.VideoMetadata, error) {
+	var metadata []models.VideoMetadata
+	if err := r.db.Where("video_id = ? AND expires_at > ?", videoID, time.Now()).Limit(1).Find(&metadata).Error; err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return &metadata[0], nil
+}
+
+// SaveVideoMetadata inserts or replaces the cached metadata for a video
+func (r *VideoMetadataRepository) SaveVideoMetadata(metadata *models.VideoMetadata) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(metadata).Error
+}
+
+// DeleteExpiredVideoMetadata removes cache entries past their expiry
+func (r *VideoMetadataRepository) DeleteExpiredVideoMetadata() (int64, error) {
+	result := r.db.Where("expires_at <= ?", time.Now()).Delete(&models.VideoMetadata{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// GuildPermissionRepository handles database operations for GuildPermission model
+type GuildPermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewGuildPermissionRepository(db *gorm.DB) *GuildPermissionRepository {
+	return &GuildPermissionRepository{db: db}
+}
+
+func (r *GuildPermissionRepository) GetPermissionsByGuildID(guildID string) ([]models.GuildPermission, error) {
+	var permissions []models.GuildPermission
+	if err := r.db.Where("guild_id = ?", guildID).Order("capability, created_at").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *GuildPermissionRepository) GrantPermission(permission *models.GuildPermission) error {
+	return r.db.Where(models.GuildPermission{
+		GuildID:    permission.GuildID,
+		Capability: permission.Capability,
+		RoleID:     permission.RoleID,
+	}).FirstOrCreate(permission).Error
+}
+
+func (r *GuildPermissionRepository) RevokePermission(guildID, capability, roleID string) (int64, error) {
+	result := r.db.Where("guild_id = ? AND capability = ? AND role_id = ?", guildID, capability, roleID).Delete(&models.GuildPermission{})
+	return result.RowsAffected, result.Error
+}
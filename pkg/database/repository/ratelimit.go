@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// GuildRateLimitRepository handles database operations for GuildRateLimit model
+type GuildRateLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewGuildRateLimitRepository(db *gorm.DB) *GuildRateLimitRepository {
+	return &GuildRateLimitRepository{db: db}
+}
+
+func (r *GuildRateLimitRepository) GetRateLimitsByGuildID(guildID string) ([]models.GuildRateLimit, error) {
+	var limits []models.GuildRateLimit
+	if err := r.db.Where("guild_id = ?", guildID).Order("bucket").Find(&limits).Error; err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// SaveRateLimit creates the override, or replaces an existing one for the same bucket
+func (r *GuildRateLimitRepository) SaveRateLimit(limit *models.GuildRateLimit) error {
+	return r.db.Where(models.GuildRateLimit{
+		GuildID: limit.GuildID,
+		Bucket:  limit.Bucket,
+	}).Assign(models.GuildRateLimit{
+		Uses:       limit.Uses,
+		PerSeconds: limit.PerSeconds,
+		UpdatedBy:  limit.UpdatedBy,
+	}).FirstOrCreate(limit).Error
+}
+
+func (r *GuildRateLimitRepository) DeleteRateLimit(guildID, bucket string) (int64, error) {
+	result := r.db.Where("guild_id = ? AND bucket = ?", guildID, bucket).Delete(&models.GuildRateLimit{})
+	return result.RowsAffected, result.Error
+}
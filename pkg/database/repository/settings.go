@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"gorm.io/gorm"
+)
+
+// GuildSettingsRepository handles database operations for GuildSettings and its audit trail
+type GuildSettingsRepository struct {
+	db *gorm.DB
+}
+
+func NewGuildSettingsRepository(db *gorm.DB) *GuildSettingsRepository {
+	return &GuildSettingsRepository{db: db}
+}
+
+// GetSettingsByGuildID returns the guild's settings, or nil if it never changed any
+func (r *GuildSettingsRepository) GetSettingsByGuildID(guildID string) (*models.GuildSettings, error) {
+	var settings models.GuildSettings
+	err := r.db.Where("guild_id = ?", guildID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveSettings stores the guild's settings together with the audit entries describing the change
+func (r *GuildSettingsRepository) SaveSettings(settings *models.GuildSettings, audits []models.GuildSettingsAudit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(settings).Error; err != nil {
+			return err
+		}
+		if len(audits) == 0 {
+			return nil
+		}
+		return tx.Create(&audits).Error
+	})
+}
+
+// GetAuditByGuildID returns the guild's most recent setting changes, newest first
+func (r *GuildSettingsRepository) GetAuditByGuildID(guildID string, limit int) ([]models.GuildSettingsAudit, error) {
+	var audits []models.GuildSettingsAudit
+	if err := r.db.Where("guild_id = ?", guildID).Order("created_at DESC").Limit(limit).Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
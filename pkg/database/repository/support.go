@@ -4,10 +4,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/latoulicious/HKTM/pkg/database/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SupportCardRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	trigram trigramSupport
 }
 
 func NewSupportCardRepository(db *gorm.DB) *SupportCardRepository {
@@ -41,3 +43,74 @@ func (r *SupportCardRepository) GetSupportCardsByTitle(title string) ([]models.S
 	}
 	return supportCards, nil
 }
+
+// GetSupportCardsByCharaID returns the support cards of a character, highest rarity first
+func (r *SupportCardRepository) GetSupportCardsByCharaID(charaID int) ([]models.SupportCard, error) {
+	var supportCards []models.SupportCard
+	if err := r.db.Where("chara_id = ?", charaID).Order("rarity DESC, support_id").Find(&supportCards).Error; err != nil {
+		return nil, err
+	}
+	return supportCards, nil
+}
+
+// GetSupportCardBySupportID returns a support card by its API data ID
+func (r *SupportCardRepository) GetSupportCardBySupportID(supportID int) (*models.SupportCard, error) {
+	var supportCard models.SupportCard
+	if err := r.db.Where("support_id = ?", supportID).First(&supportCard).Error; err != nil {
+		return nil, err
+	}
+	return &supportCard, nil
+}
+
+// HasTrigramSearch reports whether FindSupportCardCandidates can run, which needs pg_trgm
+func (r *SupportCardRepository) HasTrigramSearch() bool {
+	return r.trigram.isAvailable(r.db)
+}
+
+// FindSupportCardCandidates returns up to limit support cards whose English or Japanese
+// title contains the query or resembles it by pg_trgm trigram similarity, most similar
+// first. It fails when the pg_trgm extension isn't installed; see HasTrigramSearch.
+func (r *SupportCardRepository) FindSupportCardCandidates(query string, limit int) ([]models.SupportCard, error) {
+	var supportCards []models.SupportCard
+	pattern := "%" + escapeLike(query) + "%"
+
+	err := r.db.
+		Where("title_en ILIKE ? OR title ILIKE ? OR title_en % ? OR title % ? OR ? <% title_en OR ? <% title",
+			pattern, pattern, query, query, query, query).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "GREATEST(word_similarity(?, title_en), word_similarity(?, title)) DESC, rarity DESC",
+			Vars:               []interface{}{query, query},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&supportCards).Error
+	if err != nil {
+		return nil, err
+	}
+	return supportCards, nil
+}
+
+// SuggestSupportCards returns up to limit support cards, one per English title, whose
+// English or Japanese title contains the query, titles starting with it first
+func (r *SupportCardRepository) SuggestSupportCards(query string, limit int) ([]models.SupportCard, error) {
+	var supportCards []models.SupportCard
+	pattern := "%" + escapeLike(query) + "%"
+	prefix := escapeLike(query) + "%"
+
+	// Every rarity of a card shares its title, so group by it
+	err := r.db.Model(&models.SupportCard{}).
+		Select("title_en, MAX(title) AS title, MAX(rarity) AS rarity").
+		Where("title_en ILIKE ? OR title ILIKE ?", pattern, pattern).
+		Group("title_en").
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "MIN(CASE WHEN title_en ILIKE ? OR title ILIKE ? THEN 0 ELSE 1 END), title_en",
+			Vars:               []interface{}{prefix, prefix},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&supportCards).Error
+	if err != nil {
+		return nil, err
+	}
+	return supportCards, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// trigramSupport remembers whether the database has the pg_trgm extension the fuzzy
+// candidate lookups need. The migration only warns when it can't install it.
+type trigramSupport struct {
+	mu        sync.Mutex
+	checked   bool
+	available bool
+}
+
+// isAvailable reports whether pg_trgm is installed, asking the database until it answers
+func (t *trigramSupport) isAvailable(db *gorm.DB) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.checked {
+		var installed bool
+		if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").Scan(&installed).Error; err != nil {
+			return false
+		}
+		t.checked, t.available = true, installed
+	}
+	return t.available
+}
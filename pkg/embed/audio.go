@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 )
 
 // AudioEmbeds implements AudioEmbedBuilder interface
 type AudioEmbeds struct {
 	baseColor int
 	botName   string
+	lang      string
 }
 
 // NewAudioEmbedBuilder creates a new AudioEmbedBuilder
@@ -19,9 +21,17 @@ func NewAudioEmbedBuilder() AudioEmbedBuilder {
 	return &AudioEmbeds{
 		baseColor: 0x0099ff,
 		botName:   "Hokko Tarumae",
+		lang:      i18n.Default,
 	}
 }
 
+// WithLanguage returns a copy of the builder that renders its messages in lang
+func (a *AudioEmbeds) WithLanguage(lang string) AudioEmbedBuilder {
+	localized := *a
+	localized.lang = lang
+	return &localized
+}
+
 // Success creates a success embed
 func (a *AudioEmbeds) Success(title, description string) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
@@ -77,7 +87,7 @@ func (a *AudioEmbeds) Warning(title, description string) *discordgo.MessageEmbed
 // NowPlaying creates a now playing embed
 func (a *AudioEmbeds) NowPlaying(title, url string, duration time.Duration) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
-		Title:       "🎵 Now Playing",
+		Title:       i18n.T(a.lang, "audio.now_playing.title"),
 		Description: fmt.Sprintf("[%s](%s)", title, url),
 		Color:       0x00ff00, // Green
 		Timestamp:   time.Now().Format(time.RFC3339),
@@ -88,7 +98,7 @@ func (a *AudioEmbeds) NowPlaying(title, url string, duration time.Duration) *dis
 
 	if duration > 0 {
 		embed.Fields = []*discordgo.MessageEmbedField{
-			{Name: "Duration", Value: formatDuration(duration), Inline: true},
+			{Name: i18n.T(a.lang, "audio.duration"), Value: formatDuration(duration), Inline: true},
 		}
 	}
 
@@ -98,7 +108,7 @@ func (a *AudioEmbeds) NowPlaying(title, url string, duration time.Duration) *dis
 // QueueStatus creates a queue status embed
 func (a *AudioEmbeds) QueueStatus(current string, queue []string, queueSize int) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
-		Title:     "🎵 Music Queue",
+		Title:     i18n.T(a.lang, "audio.queue.title"),
 		Color:     a.baseColor,
 		Timestamp: time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -111,7 +121,7 @@ func (a *AudioEmbeds) QueueStatus(current string, queue []string, queueSize int)
 	// Show currently playing
 	if current != "" {
 		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "🎶 Now Playing",
+			Name:   i18n.T(a.lang, "audio.queue.now_playing"),
 			Value:  current,
 			Inline: false,
 		})
@@ -122,29 +132,29 @@ func (a *AudioEmbeds) QueueStatus(current string, queue []string, queueSize int)
 		var queueText strings.Builder
 		for i, item := range queue {
 			if i >= 10 { // Limit to first 10 items to avoid embed limits
-				queueText.WriteString(fmt.Sprintf("... and %d more songs\n", len(queue)-10))
+				queueText.WriteString(i18n.T(a.lang, "audio.queue.more", len(queue)-10) + "\n")
 				break
 			}
 			queueText.WriteString(fmt.Sprintf("%d. %s\n", i+1, item))
 		}
 
 		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "📋 Up Next",
+			Name:   i18n.T(a.lang, "audio.queue.up_next"),
 			Value:  queueText.String(),
 			Inline: false,
 		})
 	} else {
 		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "📋 Up Next",
-			Value:  "No songs in queue.",
+			Name:   i18n.T(a.lang, "audio.queue.up_next"),
+			Value:  i18n.T(a.lang, "audio.queue.empty"),
 			Inline: false,
 		})
 	}
 
 	// Add queue size info
 	fields = append(fields, &discordgo.MessageEmbedField{
-		Name:   "📊 Queue Info",
-		Value:  fmt.Sprintf("Total songs: %d", queueSize),
+		Name:   i18n.T(a.lang, "audio.queue.info"),
+		Value:  i18n.T(a.lang, "audio.queue.total", queueSize),
 		Inline: true,
 	})
 
@@ -155,24 +165,52 @@ func (a *AudioEmbeds) QueueStatus(current string, queue []string, queueSize int)
 // PlaybackError creates a playback error embed
 func (a *AudioEmbeds) PlaybackError(url string, err error) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:       "❌ Playback Error",
-		Description: fmt.Sprintf("Failed to play: %s", url),
+		Title:       i18n.T(a.lang, "audio.playback_error.title"),
+		Description: i18n.T(a.lang, "audio.playback_error.description", url),
 		Color:       0xff0000, // Red
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: a.botName,
 		},
 		Fields: []*discordgo.MessageEmbedField{
-			{Name: "Error", Value: err.Error(), Inline: false},
+			{Name: i18n.T(a.lang, "audio.playback_error.field"), Value: err.Error(), Inline: false},
 		},
 	}
 }
 
 // IdleTimeout creates an idle timeout embed
-func (a *AudioEmbeds) IdleTimeout() *discordgo.MessageEmbed {
+func (a *AudioEmbeds) IdleTimeout(idle time.Duration) *discordgo.MessageEmbed {
+	minutes := int(idle.Minutes())
+	idleFor := i18n.T(a.lang, "duration.minutes", minutes)
+	if minutes == 1 {
+		idleFor = i18n.T(a.lang, "duration.minute")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(a.lang, "audio.idle_timeout.title"),
+		Description: i18n.T(a.lang, "audio.idle_timeout.description", idleFor),
+		Color:       0xffa500, // Orange
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: a.botName,
+		},
+	}
+}
+
+// Cooldown creates an embed telling a user they've hit a command's rate limit
+func (a *AudioEmbeds) Cooldown(command string, retryAfter time.Duration) *discordgo.MessageEmbed {
+	// Round up so users never retry a moment too early
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	wait := i18n.T(a.lang, "duration.seconds", seconds)
+	if seconds == 1 {
+		wait = i18n.T(a.lang, "duration.second")
+	} else if seconds >= 120 {
+		wait = i18n.T(a.lang, "duration.minutes", (seconds+59)/60)
+	}
+
 	return &discordgo.MessageEmbed{
-		Title:       "⏰ Idle Timeout",
-		Description: "Bot has been idle for 5 minutes. Disconnected from voice channel to preserve resources.\nUse `/play` to start playing again!",
+		Title:       i18n.T(a.lang, "audio.cooldown.title"),
+		Description: i18n.T(a.lang, "audio.cooldown.description", command, wait),
 		Color:       0xffa500, // Orange
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -184,8 +222,8 @@ func (a *AudioEmbeds) IdleTimeout() *discordgo.MessageEmbed {
 // QueueEnded creates a queue ended embed
 func (a *AudioEmbeds) QueueEnded() *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:       "📭 Queue Ended",
-		Description: "All songs in the queue have been played. Add more songs with `/play` or `/queue add`!",
+		Title:       i18n.T(a.lang, "audio.queue_ended.title"),
+		Description: i18n.T(a.lang, "audio.queue_ended.description"),
 		Color:       0x808080, // Gray
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -197,7 +235,7 @@ func (a *AudioEmbeds) QueueEnded() *discordgo.MessageEmbed {
 // SongFinished creates a song finished embed
 func (a *AudioEmbeds) SongFinished(title, requestedBy string) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:     "🎵 Song Finished",
+		Title:     i18n.T(a.lang, "audio.song_finished.title"),
 		Color:     0x00ff00, // Green
 		Timestamp: time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -205,8 +243,8 @@ func (a *AudioEmbeds) SongFinished(title, requestedBy string) *discordgo.Message
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Finished Playing",
-				Value:  fmt.Sprintf("**%s**\nRequested by: %s", title, requestedBy),
+				Name:   i18n.T(a.lang, "audio.song_finished.field"),
+				Value:  i18n.T(a.lang, "audio.requested_by", title, requestedBy),
 				Inline: false,
 			},
 		},
@@ -216,7 +254,7 @@ func (a *AudioEmbeds) SongFinished(title, requestedBy string) *discordgo.Message
 // SongSkipped creates a song skipped embed
 func (a *AudioEmbeds) SongSkipped(title, requestedBy, skippedBy string) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:     "⏭️ Song Skipped",
+		Title:     i18n.T(a.lang, "audio.song_skipped.title"),
 		Color:     0xffa500, // Orange
 		Timestamp: time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -224,12 +262,12 @@ func (a *AudioEmbeds) SongSkipped(title, requestedBy, skippedBy string) *discord
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Skipped Song",
-				Value:  fmt.Sprintf("**%s**\nRequested by: %s", title, requestedBy),
+				Name:   i18n.T(a.lang, "audio.song_skipped.field"),
+				Value:  i18n.T(a.lang, "audio.requested_by", title, requestedBy),
 				Inline: false,
 			},
 			{
-				Name:   "Skipped By",
+				Name:   i18n.T(a.lang, "audio.song_skipped.by"),
 				Value:  skippedBy,
 				Inline: false,
 			},
@@ -240,8 +278,8 @@ func (a *AudioEmbeds) SongSkipped(title, requestedBy, skippedBy string) *discord
 // PlaybackStopped creates a playback stopped embed
 func (a *AudioEmbeds) PlaybackStopped(stoppedBy string) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:       "⏹️ Playback Stopped",
-		Description: "Music playback has been stopped. Use `/play` to start playing again!",
+		Title:       i18n.T(a.lang, "audio.playback_stopped.title"),
+		Description: i18n.T(a.lang, "audio.playback_stopped.description"),
 		Color:       0xff0000, // Red
 		Timestamp:   time.Now().Format(time.RFC3339),
 		Footer: &discordgo.MessageEmbedFooter{
@@ -249,7 +287,7 @@ func (a *AudioEmbeds) PlaybackStopped(stoppedBy string) *discordgo.MessageEmbed
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Stopped By",
+				Name:   i18n.T(a.lang, "audio.playback_stopped.by"),
 				Value:  stoppedBy,
 				Inline: false,
 			},
@@ -271,4 +309,29 @@ func formatDuration(d time.Duration) string {
 		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
 	}
 	return fmt.Sprintf("%d:%02d", minutes, seconds)
-}
\ No newline at end of file
+}
+// ProgressBar renders a text progress bar with elapsed and total time, e.g.
+// "▬▬▬▬🔘▬▬▬▬▬ `1:23 / 3:45`". Tracks of unknown length only show the elapsed time.
+func ProgressBar(elapsed, total time.Duration, width int) string {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if total <= 0 {
+		return fmt.Sprintf("🔴 `%s`", formatClock(elapsed))
+	}
+	if elapsed > total {
+		elapsed = total
+	}
+
+	marker := int(int64(width-1) * int64(elapsed) / int64(total))
+	bar := strings.Repeat("▬", marker) + "🔘" + strings.Repeat("▬", width-1-marker)
+	return fmt.Sprintf("%s `%s / %s`", bar, formatClock(elapsed), formatClock(total))
+}
+
+// formatClock formats a position as m:ss or h:mm:ss, showing 0:00 rather than Unknown
+func formatClock(d time.Duration) string {
+	if d < time.Second {
+		return "0:00"
+	}
+	return formatDuration(d)
+}
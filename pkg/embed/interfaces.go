@@ -20,9 +20,13 @@ type AudioEmbedBuilder interface {
 	NowPlaying(title, url string, duration time.Duration) *discordgo.MessageEmbed
 	QueueStatus(current string, queue []string, queueSize int) *discordgo.MessageEmbed
 	PlaybackError(url string, err error) *discordgo.MessageEmbed
-	IdleTimeout() *discordgo.MessageEmbed
+	IdleTimeout(idle time.Duration) *discordgo.MessageEmbed
+	Cooldown(command string, retryAfter time.Duration) *discordgo.MessageEmbed
 	QueueEnded() *discordgo.MessageEmbed
 	SongFinished(title, requestedBy string) *discordgo.MessageEmbed
 	SongSkipped(title, requestedBy, skippedBy string) *discordgo.MessageEmbed
 	PlaybackStopped(stoppedBy string) *discordgo.MessageEmbed
+
+	// WithLanguage returns a builder that renders its messages in the given language
+	WithLanguage(lang string) AudioEmbedBuilder
 }
\ No newline at end of file
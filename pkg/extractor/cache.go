@@ -0,0 +1,68 @@
+package extractor
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+)
+
+// cachedChapter is how chapters are stored in the metadata cache, in seconds
+type cachedChapter struct {
+	Title string  `json:"title"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// modelFromInfo converts parsed metadata to its cache row
+func modelFromInfo(info *VideoInfo, ttl time.Duration) *models.VideoMetadata {
+	chapters := make([]cachedChapter, len(info.Chapters))
+	for i, chapter := range info.Chapters {
+		chapters[i] = cachedChapter{
+			Title: chapter.Title,
+			Start: chapter.Start.Seconds(),
+			End:   chapter.End.Seconds(),
+		}
+	}
+	encoded, _ := json.Marshal(chapters)
+
+	return &models.VideoMetadata{
+		VideoID:     info.ID,
+		Title:       info.Title,
+		Uploader:    info.Uploader,
+		Thumbnail:   info.Thumbnail,
+		WebpageURL:  info.WebpageURL,
+		Duration:    info.Duration.Seconds(),
+		LiveStatus:  info.LiveStatus,
+		Chapters:    string(encoded),
+		ExtractedAt: info.ExtractedAt,
+		ExpiresAt:   info.ExtractedAt.Add(ttl),
+	}
+}
+
+// infoFromModel converts a cache row back to parsed metadata
+func infoFromModel(metadata *models.VideoMetadata) *VideoInfo {
+	info := &VideoInfo{
+		ID:          metadata.VideoID,
+		Title:       metadata.Title,
+		Uploader:    metadata.Uploader,
+		Thumbnail:   metadata.Thumbnail,
+		WebpageURL:  metadata.WebpageURL,
+		Duration:    secondsToDuration(metadata.Duration),
+		LiveStatus:  metadata.LiveStatus,
+		ExtractedAt: metadata.ExtractedAt,
+	}
+
+	var chapters []cachedChapter
+	if err := json.Unmarshal([]byte(metadata.Chapters), &chapters); err == nil {
+		for _, chapter := range chapters {
+			info.Chapters = append(info.Chapters, Chapter{
+				Title: chapter.Title,
+				Start: secondsToDuration(chapter.Start),
+				End:   secondsToDuration(chapter.End),
+			})
+		}
+	}
+
+	return info
+}
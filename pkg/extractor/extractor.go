@@ -0,0 +1,202 @@
+// Package extractor runs yt-dlp once per video and shares the parsed result
+// between the commands layer and the audio pipeline.
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/database/repository"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultBinaryPath is used when no yt-dlp path is configured
+	DefaultBinaryPath = "yt-dlp"
+	// DefaultCacheTTL is how long parsed metadata is reused
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// Extractor runs `yt-dlp -J` and caches the parsed metadata by video ID
+type Extractor struct {
+	binaryPath string
+	cache      *repository.VideoMetadataRepository // nil disables the cache
+	cacheTTL   time.Duration
+	streams    *StreamCache
+	admission  Admission // nil runs yt-dlp without waiting
+}
+
+// Admission decides when a yt-dlp run may start, so the number of concurrent
+// processes stays within a budget. playback is set when a listener is waiting on the result.
+type Admission interface {
+	AdmitExtraction(ctx context.Context, playback bool) (release func(), err error)
+}
+
+// New creates an Extractor using the given yt-dlp binary. A nil db disables the metadata cache.
+func New(binaryPath string, db *gorm.DB, cacheTTL time.Duration) *Extractor {
+	if binaryPath == "" {
+		binaryPath = DefaultBinaryPath
+	}
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+
+	var cache *repository.VideoMetadataRepository
+	if db != nil {
+		cache = repository.NewVideoMetadataRepository(db)
+	}
+
+	return &Extractor{
+		binaryPath: binaryPath,
+		cache:      cache,
+		cacheTTL:   cacheTTL,
+		streams:    NewStreamCache(DefaultRefreshMargin),
+	}
+}
+
+// BinaryPath returns the yt-dlp binary this extractor runs
+func (e *Extractor) BinaryPath() string {
+	return e.binaryPath
+}
+
+// Streams returns the cache of resolved stream URLs
+func (e *Extractor) Streams() *StreamCache {
+	return e.streams
+}
+
+// SetAdmission makes every yt-dlp run wait for the given admission control first
+func (e *Extractor) SetAdmission(admission Admission) {
+	e.admission = admission
+}
+
+// WithBinaryPath returns an Extractor sharing this one's caches but running a different yt-dlp binary
+func (e *Extractor) WithBinaryPath(binaryPath string) *Extractor {
+	if binaryPath == "" || binaryPath == e.binaryPath {
+		return e
+	}
+
+	clone := *e
+	clone.binaryPath = binaryPath
+	return &clone
+}
+
+// Extract runs `yt-dlp -J` for a URL and returns the parsed info including fresh formats.
+// extraArgs are passed through to yt-dlp, e.g. to pick a player client.
+func (e *Extractor) Extract(ctx context.Context, url string, extraArgs ...string) (*VideoInfo, error) {
+	return e.extract(ctx, false, url, extraArgs...)
+}
+
+// extract runs yt-dlp once admitted; playback extractions are admitted ahead of metadata lookups
+func (e *Extractor) extract(ctx context.Context, playback bool, url string, extraArgs ...string) (*VideoInfo, error) {
+	if e.admission != nil {
+		release, err := e.admission.AdmitExtraction(ctx, playback)
+		if err != nil {
+			return nil, fmt.Errorf("yt-dlp extraction not started: %w", err)
+		}
+		defer release()
+	}
+
+	args := []string{"-J", "--no-playlist", "--no-warnings"}
+	args = append(args, extraArgs...)
+	args = append(args, url)
+
+	cmd := exec.CommandContext(ctx, e.binaryPath, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("yt-dlp extraction failed: %w: %s", err, message)
+		}
+		return nil, fmt.Errorf("yt-dlp extraction failed: %w", err)
+	}
+
+	info, err := ParseInfo(stdout.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	e.store(info)
+	return info, nil
+}
+
+// Metadata returns cached metadata for a video ID when fresh, extracting it otherwise.
+// Cached results carry no formats; use Extract when a stream URL is needed.
+func (e *Extractor) Metadata(ctx context.Context, videoID, url string) (*VideoInfo, error) {
+	if info, ok := e.Cached(videoID); ok {
+		return info, nil
+	}
+	return e.Extract(ctx, url)
+}
+
+// Cached returns the cached metadata for a video ID, if any is fresh
+func (e *Extractor) Cached(videoID string) (*VideoInfo, bool) {
+	if e.cache == nil || videoID == "" {
+		return nil, false
+	}
+
+	metadata, err := e.cache.GetVideoMetadata(videoID)
+	if err != nil {
+		logging.GetGlobalLoggerFactory().CreateLogger("extractor").Warn("Failed to read metadata cache", map[string]interface{}{
+			"video_id": videoID,
+			"error":    err.Error(),
+		})
+		return nil, false
+	}
+	if metadata == nil {
+		return nil, false
+	}
+
+	return infoFromModel(metadata), true
+}
+
+// store caches the metadata of a finished, non-live video
+func (e *Extractor) store(info *VideoInfo) {
+	if e.cache == nil || info.ID == "" || info.IsLive() || info.LiveStatus == LiveStatusIsUpcoming {
+		return
+	}
+
+	if err := e.cache.SaveVideoMetadata(modelFromInfo(info, e.cacheTTL)); err != nil {
+		logging.GetGlobalLoggerFactory().CreateLogger("extractor").Warn("Failed to write metadata cache", map[string]interface{}{
+			"video_id": info.ID,
+			"error":    err.Error(),
+		})
+	}
+}
+
+var (
+	globalExtractor *Extractor
+	globalMutex     sync.RWMutex
+)
+
+// GetGlobalExtractor returns the shared Extractor, creating an uncached default if none was set
+func GetGlobalExtractor() *Extractor {
+	globalMutex.RLock()
+	extractor := globalExtractor
+	globalMutex.RUnlock()
+
+	if extractor != nil {
+		return extractor
+	}
+
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	if globalExtractor == nil {
+		globalExtractor = New(DefaultBinaryPath, nil, DefaultCacheTTL)
+	}
+	return globalExtractor
+}
+
+// SetGlobalExtractor sets the shared Extractor instance
+func SetGlobalExtractor(extractor *Extractor) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	globalExtractor = extractor
+}
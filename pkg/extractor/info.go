@@ -0,0 +1,195 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Live status values reported by yt-dlp
+const (
+	LiveStatusNotLive    = "not_live"
+	LiveStatusIsLive     = "is_live"
+	LiveStatusWasLive    = "was_live"
+	LiveStatusIsUpcoming = "is_upcoming"
+	LiveStatusPostLive   = "post_live"
+)
+
+// VideoInfo is the parsed result of a single `yt-dlp -J` run
+type VideoInfo struct {
+	ID          string
+	Title       string
+	Uploader    string
+	Thumbnail   string
+	WebpageURL  string
+	Duration    time.Duration // Zero when unknown or live
+	LiveStatus  string
+	Chapters    []Chapter
+	Formats     []Format // Only present on fresh extractions, never cached
+	ExtractedAt time.Time
+}
+
+// Chapter is a named section of a video
+type Chapter struct {
+	Title string
+	Start time.Duration
+	End   time.Duration
+}
+
+// Format is one downloadable stream offered for a video
+type Format struct {
+	FormatID     string
+	URL          string
+	Ext          string
+	Protocol     string
+	AudioCodec   string
+	VideoCodec   string
+	AudioBitrate float64 // kbit/s
+	TotalBitrate float64 // kbit/s
+}
+
+// IsLive reports whether the video is currently streaming live
+func (v *VideoInfo) IsLive() bool {
+	return v.LiveStatus == LiveStatusIsLive
+}
+
+// HasAudio reports whether the format carries an audio track
+func (f Format) HasAudio() bool {
+	return f.AudioCodec != "" && f.AudioCodec != "none"
+}
+
+// IsAudioOnly reports whether the format carries audio without video
+func (f Format) IsAudioOnly() bool {
+	return f.HasAudio() && (f.VideoCodec == "" || f.VideoCodec == "none")
+}
+
+// BestAudioFormat picks the highest bitrate audio-only format, falling back to the best
+// format with any audio (e.g. HLS for live streams). yt-dlp lists formats worst to best.
+func (v *VideoInfo) BestAudioFormat() (Format, bool) {
+	var best Format
+	found := false
+	for _, format := range v.Formats {
+		if format.URL == "" || !format.IsAudioOnly() {
+			continue
+		}
+		if !found || format.AudioBitrate >= best.AudioBitrate {
+			best = format
+			found = true
+		}
+	}
+	if found {
+		return best, true
+	}
+
+	for i := len(v.Formats) - 1; i >= 0; i-- {
+		if v.Formats[i].URL != "" && v.Formats[i].HasAudio() {
+			return v.Formats[i], true
+		}
+	}
+	return Format{}, false
+}
+
+// rawInfo mirrors the subset of yt-dlp's JSON output we use
+type rawInfo struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Uploader   string   `json:"uploader"`
+	Channel    string   `json:"channel"`
+	Thumbnail  string   `json:"thumbnail"`
+	WebpageURL string   `json:"webpage_url"`
+	URL        string   `json:"url"` // Set instead of formats by some extractors
+	Duration   *float64 `json:"duration"`
+	IsLive     *bool    `json:"is_live"`
+	LiveStatus string   `json:"live_status"`
+	Chapters   []struct {
+		Title     string  `json:"title"`
+		StartTime float64 `json:"start_time"`
+		EndTime   float64 `json:"end_time"`
+	} `json:"chapters"`
+	Formats []struct {
+		FormatID string   `json:"format_id"`
+		URL      string   `json:"url"`
+		Ext      string   `json:"ext"`
+		Protocol string   `json:"protocol"`
+		ACodec   string   `json:"acodec"`
+		VCodec   string   `json:"vcodec"`
+		ABR      *float64 `json:"abr"`
+		TBR      *float64 `json:"tbr"`
+	} `json:"formats"`
+}
+
+// ParseInfo parses the JSON printed by `yt-dlp -J`
+func ParseInfo(data []byte) (*VideoInfo, error) {
+	var raw rawInfo
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp output: %w", err)
+	}
+	if raw.ID == "" && raw.Title == "" {
+		return nil, fmt.Errorf("yt-dlp output has no video information")
+	}
+
+	info := &VideoInfo{
+		ID:          raw.ID,
+		Title:       strings.TrimSpace(raw.Title),
+		Uploader:    raw.Uploader,
+		Thumbnail:   raw.Thumbnail,
+		WebpageURL:  raw.WebpageURL,
+		LiveStatus:  raw.LiveStatus,
+		ExtractedAt: time.Now(),
+	}
+
+	if info.Uploader == "" {
+		info.Uploader = raw.Channel
+	}
+	if info.Title == "" {
+		info.Title = "Unknown Title"
+	}
+	if info.LiveStatus == "" {
+		info.LiveStatus = LiveStatusNotLive
+		if raw.IsLive != nil && *raw.IsLive {
+			info.LiveStatus = LiveStatusIsLive
+		}
+	}
+	if raw.Duration != nil && !info.IsLive() {
+		info.Duration = secondsToDuration(*raw.Duration)
+	}
+
+	for _, chapter := range raw.Chapters {
+		info.Chapters = append(info.Chapters, Chapter{
+			Title: chapter.Title,
+			Start: secondsToDuration(chapter.StartTime),
+			End:   secondsToDuration(chapter.EndTime),
+		})
+	}
+
+	for _, format := range raw.Formats {
+		parsed := Format{
+			FormatID:   format.FormatID,
+			URL:        format.URL,
+			Ext:        format.Ext,
+			Protocol:   format.Protocol,
+			AudioCodec: format.ACodec,
+			VideoCodec: format.VCodec,
+		}
+		if format.ABR != nil {
+			parsed.AudioBitrate = *format.ABR
+		}
+		if format.TBR != nil {
+			parsed.TotalBitrate = *format.TBR
+		}
+		info.Formats = append(info.Formats, parsed)
+	}
+
+	// Extractors without a format list expose a single direct URL
+	if len(info.Formats) == 0 && raw.URL != "" {
+		info.Formats = []Format{{FormatID: "default", URL: raw.URL, AudioCodec: "unknown"}}
+	}
+
+	return info, nil
+}
+
+// secondsToDuration converts yt-dlp's fractional seconds to a Duration
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,285 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRefreshMargin is how long before expiry a stream URL stops being reused
+	DefaultRefreshMargin = time.Minute
+	// fallbackStreamTTL is assumed when a stream URL carries no expiry
+	fallbackStreamTTL = 5 * time.Minute
+)
+
+// Strategy is a way of asking yt-dlp for stream URLs
+type Strategy struct {
+	Name string
+	Args []string
+}
+
+// DefaultStrategies are tried in order until one yields an audio format, since YouTube
+// restricts individual player clients from time to time
+var DefaultStrategies = []Strategy{
+	{Name: "default"},
+	{Name: "android", Args: []string{"--extractor-args", "youtube:player_client=android"}},
+	{Name: "web", Args: []string{"--extractor-args", "youtube:player_client=web"}},
+}
+
+// StreamURL is a resolved, directly playable stream URL
+type StreamURL struct {
+	URL        string
+	FormatID   string
+	Strategy   string
+	ResolvedAt time.Time
+	ExpiresAt  time.Time
+}
+
+// StrategyStats counts how a strategy's URLs were served
+type StrategyStats struct {
+	Hits          int64 // Served from the cache
+	Misses        int64 // Required a yt-dlp run with this strategy
+	Failures      int64 // yt-dlp runs that produced no usable URL
+	Invalidations int64 // Cached URLs dropped after the server rejected them
+}
+
+// StreamCache shares resolved stream URLs across guilds and replays until shortly before they expire
+type StreamCache struct {
+	mu      sync.Mutex
+	entries map[string]*StreamURL
+	stats   map[string]*StrategyStats
+	margin  time.Duration
+}
+
+// NewStreamCache creates an empty StreamCache
+func NewStreamCache(margin time.Duration) *StreamCache {
+	if margin <= 0 {
+		margin = DefaultRefreshMargin
+	}
+	return &StreamCache{
+		entries: make(map[string]*StreamURL),
+		stats:   make(map[string]*StrategyStats),
+		margin:  margin,
+	}
+}
+
+// SetRefreshMargin sets how long before expiry URLs are refreshed
+func (c *StreamCache) SetRefreshMargin(margin time.Duration) {
+	if margin <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.margin = margin
+}
+
+// RefreshMargin returns how long before expiry URLs are refreshed
+func (c *StreamCache) RefreshMargin() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.margin
+}
+
+// Get returns a cached stream URL that is still outside the refresh margin
+func (c *StreamCache) Get(key string) (*StreamURL, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stream, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if !time.Now().Before(stream.ExpiresAt.Add(-c.margin)) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.statsFor(stream.Strategy).Hits++
+	return stream, true
+}
+
+// Put caches a resolved stream URL
+func (c *StreamCache) Put(key string, stream *StreamURL) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired entries so the cache can't grow unbounded
+	now := time.Now()
+	for cachedKey, entry := range c.entries {
+		if now.After(entry.ExpiresAt) {
+			delete(c.entries, cachedKey)
+		}
+	}
+	c.entries[key] = stream
+}
+
+// Invalidate drops a cached URL after the server rejected it. Only the rejected URL is
+// dropped, so a replacement resolved in the meantime is kept.
+func (c *StreamCache) Invalidate(key, streamURL string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stream, exists := c.entries[key]
+	if !exists || (streamURL != "" && stream.URL != streamURL) {
+		return false
+	}
+
+	delete(c.entries, key)
+	c.statsFor(stream.Strategy).Invalidations++
+	return true
+}
+
+// Stats returns a copy of the per-strategy counters, sorted by strategy name
+func (c *StreamCache) Stats() (names []string, stats map[string]StrategyStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats = make(map[string]StrategyStats, len(c.stats))
+	for name, counters := range c.stats {
+		names = append(names, name)
+		stats[name] = *counters
+	}
+	sort.Strings(names)
+	return names, stats
+}
+
+// Size returns how many URLs are cached
+func (c *StreamCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// recordMiss counts a yt-dlp run for a strategy
+func (c *StreamCache) recordMiss(strategy string, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counters := c.statsFor(strategy)
+	counters.Misses++
+	if failed {
+		counters.Failures++
+	}
+}
+
+// statsFor returns a strategy's counters; the caller must hold the lock
+func (c *StreamCache) statsFor(strategy string) *StrategyStats {
+	counters, exists := c.stats[strategy]
+	if !exists {
+		counters = &StrategyStats{}
+		c.stats[strategy] = counters
+	}
+	return counters
+}
+
+// ResolveStream returns a playable audio stream URL for a video, reusing a cached URL when
+// one is still valid. info is only set when yt-dlp had to run.
+func (e *Extractor) ResolveStream(ctx context.Context, videoURL string) (*StreamURL, *VideoInfo, error) {
+	key := StreamKey(videoURL)
+	if cached, ok := e.streams.Get(key); ok {
+		return cached, nil, nil
+	}
+
+	var lastErr error
+	for _, strategy := range DefaultStrategies {
+		if ctx.Err() != nil {
+			break
+		}
+
+		info, err := e.extract(ctx, true, videoURL, strategy.Args...)
+		if err != nil {
+			e.streams.recordMiss(strategy.Name, true)
+			lastErr = err
+			continue
+		}
+
+		format, ok := info.BestAudioFormat()
+		if !ok {
+			e.streams.recordMiss(strategy.Name, true)
+			lastErr = fmt.Errorf("no audio formats available")
+			continue
+		}
+		e.streams.recordMiss(strategy.Name, false)
+
+		now := time.Now()
+		stream := &StreamURL{
+			URL:        format.URL,
+			FormatID:   format.FormatID,
+			Strategy:   strategy.Name,
+			ResolvedAt: now,
+			ExpiresAt:  StreamExpiry(format.URL, now),
+		}
+
+		// Live stream URLs are tied to a moving window, so only cache finished videos
+		if !info.IsLive() {
+			e.streams.Put(key, stream)
+		}
+		return stream, info, nil
+	}
+
+	if lastErr == nil {
+		lastErr = ctx.Err()
+	}
+	return nil, nil, fmt.Errorf("failed to resolve stream URL with %d strategies: %w", len(DefaultStrategies), lastErr)
+}
+
+// StreamExpiry reads the expiry of a googlevideo URL from its `expire` query parameter
+// (or `/expire/<unix>/` path segment for manifests), assuming a short lifetime otherwise
+func StreamExpiry(streamURL string, resolvedAt time.Time) time.Time {
+	parsed, err := url.Parse(streamURL)
+	if err != nil {
+		return resolvedAt.Add(fallbackStreamTTL)
+	}
+
+	value := parsed.Query().Get("expire")
+	if value == "" {
+		segments := strings.Split(parsed.Path, "/")
+		for i := 0; i < len(segments)-1; i++ {
+			if segments[i] == "expire" {
+				value = segments[i+1]
+				break
+			}
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds > 0 {
+		return time.Unix(seconds, 0)
+	}
+	return resolvedAt.Add(fallbackStreamTTL)
+}
+
+// StreamKey identifies a video for the stream cache: the YouTube video ID when there is one,
+// the URL itself otherwise
+func StreamKey(videoURL string) string {
+	parsed, err := url.Parse(videoURL)
+	if err != nil {
+		return videoURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	host = strings.TrimPrefix(host, "music.")
+
+	switch host {
+	case "youtu.be":
+		if id := strings.Trim(parsed.Path, "/"); id != "" {
+			return "youtube:" + id
+		}
+	case "youtube.com":
+		if id := parsed.Query().Get("v"); id != "" {
+			return "youtube:" + id
+		}
+		for _, prefix := range []string{"/shorts/", "/live/", "/embed/"} {
+			if strings.HasPrefix(parsed.Path, prefix) {
+				return "youtube:" + strings.Trim(strings.TrimPrefix(parsed.Path, prefix), "/")
+			}
+		}
+	}
+	return videoURL
+}
@@ -0,0 +1,107 @@
+// Package i18n renders bot responses in the reader's language from the message
+// catalogs in locales/. Every key must exist in the English catalog, which the
+// other languages fall back to.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	English    = "en"
+	Japanese   = "ja"
+	Indonesian = "id"
+
+	// Default is the language used when no other applies
+	Default = English
+)
+
+// Languages lists every language with a catalog
+var Languages = []string{English, Japanese, Indonesian}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs holds the messages of every language by key
+var catalogs = make(map[string]map[string]string)
+
+func init() {
+	for _, language := range Languages {
+		data, err := localeFiles.ReadFile(path.Join("locales", language+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %s: %v", language, err))
+		}
+
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for %s: %v", language, err))
+		}
+		catalogs[language] = messages
+	}
+}
+
+// Supported reports whether there is a catalog for the language
+func Supported(language string) bool {
+	_, exists := catalogs[language]
+	return exists
+}
+
+// T returns the message for key in the language, formatting args into it with
+// fmt verbs. Missing messages fall back to English, then to the key itself.
+func T(language, key string, args ...interface{}) string {
+	message, exists := catalogs[language][key]
+	if !exists {
+		message, exists = catalogs[Default][key]
+	}
+	if !exists {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Error is an error whose message comes from the catalog, so it can be shown in the
+// reader's language. Error() returns the English message.
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+// Errorf returns an Error for the catalog message key
+func Errorf(key string, args ...interface{}) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error returns the English message
+func (e *Error) Error() string {
+	return T(English, e.Key, e.Args...)
+}
+
+// Keys returns every key of a language's catalog
+func Keys(language string) []string {
+	keys := make([]string, 0, len(catalogs[language]))
+	for key := range catalogs[language] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// FromDiscordLocale returns the language for a Discord client locale, and false when
+// there is no catalog for it
+func FromDiscordLocale(locale discordgo.Locale) (string, bool) {
+	// Discord locales are either a bare language or language-region, e.g. en-US
+	language := strings.ToLower(strings.SplitN(string(locale), "-", 2)[0])
+	if !Supported(language) {
+		return "", false
+	}
+	return language, true
+}
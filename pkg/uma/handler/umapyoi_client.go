@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/latoulicious/HKTM/pkg/logging"
+	"github.com/latoulicious/HKTM/pkg/uma/search"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
 )
 
@@ -108,7 +108,11 @@ func (c *Client) SearchCharacter(query string) *shared.CharacterSearchResult {
 	}
 
 	// Find the best match
-	bestMatch := c.findBestMatch(query, apiResp)
+	var bestMatch *shared.Character
+	matches := c.findBestMatches(query, apiResp)
+	if len(matches) > 0 {
+		bestMatch = &matches[0]
+	}
 
 	if bestMatch != nil {
 		c.logger.Info("Umapyoi character search successful", map[string]interface{}{
@@ -118,6 +122,7 @@ func (c *Client) SearchCharacter(query string) *shared.CharacterSearchResult {
 			"duration_ms":      time.Since(startTime).Milliseconds(),
 			"character_id":     bestMatch.ID,
 			"character_name":   bestMatch.NameEn,
+			"candidates":       len(matches),
 			"total_characters": len(apiResp),
 		})
 	} else {
@@ -135,6 +140,9 @@ func (c *Client) SearchCharacter(query string) *shared.CharacterSearchResult {
 		Character: bestMatch,
 		Query:     query,
 	}
+	if len(matches) > 1 {
+		result.Candidates = matches
+	}
 
 	// c.setCache(cacheKey, result)
 	return result
@@ -221,44 +229,20 @@ func (c *Client) GetAllCharacters() *shared.CharacterListResult {
 	return result
 }
 
-// findBestMatch finds the best character match for the given query
-func (c *Client) findBestMatch(query string, characters []shared.Character) *shared.Character {
-	query = strings.ToLower(query)
-
-	// First, try exact match with English name
-	for _, char := range characters {
-		if strings.ToLower(char.NameEn) == query {
-			return &char
-		}
-	}
-
-	// Then, try contains match with English name
-	for _, char := range characters {
-		if strings.Contains(strings.ToLower(char.NameEn), query) {
-			return &char
-		}
-	}
-
-	// Try Japanese name
-	for _, char := range characters {
-		if strings.Contains(strings.ToLower(char.NameJp), query) {
-			return &char
-		}
+// findBestMatches ranks the characters against the query and returns the best match
+// followed by any runners-up close enough that the query is ambiguous
+func (c *Client) findBestMatches(query string, characters []shared.Character) []shared.Character {
+	names := make([][]string, len(characters))
+	for i, char := range characters {
+		names[i] = []string{char.NameEn, char.NameJp, char.NameEnInternal}
 	}
 
-	// Finally, try partial word match
-	queryWords := strings.Fields(query)
-	for _, char := range characters {
-		charNameEn := strings.ToLower(char.NameEn)
-		charNameJp := strings.ToLower(char.NameJp)
-		for _, word := range queryWords {
-			if strings.Contains(charNameEn, word) || strings.Contains(charNameJp, word) {
-				return &char
-			}
-		}
+	contenders := search.Contenders(search.Rank(query, names))
+	matches := make([]shared.Character, len(contenders))
+	for i, match := range contenders {
+		matches[i] = characters[match.Index]
 	}
-
-	return nil
+	return matches
 }
 
 // GetCharacterImages fetches all images for a character by ID
@@ -527,87 +511,26 @@ func (c *Client) GetSupportCard(supportID int) *shared.SupportCardSearchResult {
 // findAllSupportCardMatches finds all support cards that match the query,
 // grouping by character ID to find all versions of the same character's support cards.
 func (c *Client) findAllSupportCardMatches(query string, supportCards []shared.SupportCard) []shared.SupportCard {
-	query = strings.ToLower(query)
-	var matches []shared.SupportCard
-	var matchedCharIDs []int
-
-	// First pass: find all cards that match the query
-	for _, card := range supportCards {
-		titleEn := strings.ToLower(card.TitleEn)
-		titleJp := strings.ToLower(card.Title)
-		gametora := strings.ToLower(card.Gametora)
-
-		// Check if any field contains the query
-		matched := false
-
-		// Direct match (highest priority)
-		if strings.Contains(titleEn, query) || strings.Contains(titleJp, query) || strings.Contains(gametora, query) {
-			matched = true
-		}
-
-		// Handle space-separated queries vs hyphen-separated gametora
-		if !matched && strings.Contains(query, " ") {
-			// Replace spaces with hyphens for gametora matching
-			queryWithHyphens := strings.ReplaceAll(query, " ", "-")
-			if strings.Contains(gametora, queryWithHyphens) {
-				matched = true
-			}
-
-			// Also try replacing hyphens with spaces in gametora
-			gametoraWithSpaces := strings.ReplaceAll(gametora, "-", " ")
-			if strings.Contains(gametoraWithSpaces, query) {
-				matched = true
-			}
-		}
-
-		// Word-by-word matching (only if no direct match found)
-		if !matched {
-			queryWords := strings.Fields(query)
-			// Only do word-by-word matching if we have multiple words
-			if len(queryWords) > 1 {
-				allWordsMatched := true
-				for _, word := range queryWords {
-					wordMatched := false
-					if strings.Contains(titleEn, word) || strings.Contains(titleJp, word) || strings.Contains(gametora, word) {
-						wordMatched = true
-					}
-					if !wordMatched {
-						allWordsMatched = false
-						break
-					}
-				}
-				if allWordsMatched {
-					matched = true
-				}
-			}
-		}
-
-		if matched {
-			matches = append(matches, card)
-			matchedCharIDs = append(matchedCharIDs, card.CharaID)
-		}
+	names := make([][]string, len(supportCards))
+	for i, card := range supportCards {
+		names[i] = []string{card.TitleEn, card.Title, card.Gametora}
 	}
 
-	// If we found matches, also include all other cards for the same characters
-	if len(matches) > 0 {
-		// Create a set of matched character IDs
-		charIDSet := make(map[int]bool)
-		for _, id := range matchedCharIDs {
-			charIDSet[id] = true
-		}
+	// Take the characters of the best match and any close runners-up
+	charIDSet := make(map[int]bool)
+	for _, match := range search.Contenders(search.Rank(query, names)) {
+		charIDSet[supportCards[match.Index].CharaID] = true
+	}
 
-		// Find all cards for the same characters
-		var allCardsForCharacter []shared.SupportCard
-		for _, card := range supportCards {
-			if charIDSet[card.CharaID] {
-				allCardsForCharacter = append(allCardsForCharacter, card)
-			}
+	// Find all cards for the same characters
+	var allCardsForCharacter []shared.SupportCard
+	for _, card := range supportCards {
+		if charIDSet[card.CharaID] {
+			allCardsForCharacter = append(allCardsForCharacter, card)
 		}
-
-		return allCardsForCharacter
 	}
 
-	return matches
+	return allCardsForCharacter
 }
 
 // // getFromCache retrieves an item from cache
@@ -1,6 +1,7 @@
 package uma
 
 import (
+	"github.com/latoulicious/HKTM/pkg/database/models"
 	"github.com/latoulicious/HKTM/pkg/database/repository"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
@@ -10,22 +11,31 @@ import (
 type Service struct {
 	CharacterRepo   *repository.CharacterRepository
 	SupportCardRepo *repository.SupportCardRepository
+	AliasRepo       *repository.UmaAliasRepository
 	UmapyoiClient   *handler.Client
 	GametoraClient  *handler.GametoraClient
 }
 
 // CharacterServiceInterface defines the interface for character-related operations
 type CharacterServiceInterface interface {
-	SearchCharacter(query string) (*shared.CharacterSearchResult, error)
+	SearchCharacter(guildID, query string) (*shared.CharacterSearchResult, error)
 	GetCharacterImages(charaID int) (*shared.CharacterImagesResult, error)
 }
 
 // SupportCardServiceInterface defines the interface for support card-related operations
 type SupportCardServiceInterface interface {
-	SearchSupportCard(query string) (*shared.SupportCardSearchResult, error)
+	SearchSupportCard(guildID, query string) (*shared.SupportCardSearchResult, error)
 	GetSupportCardList() (*shared.SupportCardListResult, error)
 }
 
+// AliasServiceInterface defines the interface for character and support card nicknames
+type AliasServiceInterface interface {
+	SeedAliases() error
+	AddAlias(guildID, kind, alias, target string, targetID int, createdBy string) (*models.UmaAlias, error)
+	RemoveAlias(guildID, kind, alias string) (bool, error)
+	ListAliases(guildID, kind string) ([]models.UmaAlias, error)
+}
+
 // SyncServiceInterface defines the interface for synchronization operations
 type SyncServiceInterface interface {
 	SyncAllCharacters() error
@@ -36,6 +46,7 @@ type SyncServiceInterface interface {
 type UmaServiceInterface interface {
 	CharacterServiceInterface
 	SupportCardServiceInterface
+	AliasServiceInterface
 	SyncServiceInterface
 }
 
@@ -43,6 +54,7 @@ type UmaServiceInterface interface {
 type ServiceFactoryInterface interface {
 	NewCharacterService() CharacterServiceInterface
 	NewSupportCardService() SupportCardServiceInterface
+	NewAliasService() AliasServiceInterface
 	NewSyncService() SyncServiceInterface
 }
 
@@ -50,12 +62,14 @@ type ServiceFactoryInterface interface {
 func NewService(
 	characterRepo *repository.CharacterRepository,
 	supportCardRepo *repository.SupportCardRepository,
+	aliasRepo *repository.UmaAliasRepository,
 	umapyoiClient *handler.Client,
 	gametoraClient *handler.GametoraClient,
 ) *Service {
 	return &Service{
 		CharacterRepo:   characterRepo,
 		SupportCardRepo: supportCardRepo,
+		AliasRepo:       aliasRepo,
 		UmapyoiClient:   umapyoiClient,
 		GametoraClient:  gametoraClient,
 	}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
 )
@@ -16,6 +17,7 @@ type NavigationState struct {
 	CurrentIndex int
 	MessageID    string
 	ChannelID    string
+	Language     string
 }
 
 // NavigationManager manages image navigation for Uma character embeds
@@ -85,7 +87,7 @@ func (nm *NavigationManager) HandleReaction(s *discordgo.Session, r *discordgo.M
 	}
 
 	// Update the embed
-	embed := nm.createCharacterEmbed(state.Character, state.ImagesResult, state.CurrentIndex)
+	embed := nm.createCharacterEmbed(state.Character, state.ImagesResult, state.CurrentIndex, state.Language)
 
 	// Update the message
 	_, err := s.ChannelMessageEditEmbed(state.ChannelID, state.MessageID, embed)
@@ -98,8 +100,8 @@ func (nm *NavigationManager) HandleReaction(s *discordgo.Session, r *discordgo.M
 	s.MessageReactionRemove(state.ChannelID, state.MessageID, reaction, r.UserID)
 }
 
-// RegisterNavigation registers a new Uma character navigation
-func (nm *NavigationManager) RegisterNavigation(messageID string, character *shared.Character, imagesResult *shared.CharacterImagesResult, channelID string) {
+// RegisterNavigation registers a new Uma character navigation, keeping the embed in lang
+func (nm *NavigationManager) RegisterNavigation(messageID string, character *shared.Character, imagesResult *shared.CharacterImagesResult, channelID, lang string) {
 	nm.mutex.Lock()
 	defer nm.mutex.Unlock()
 
@@ -109,6 +111,7 @@ func (nm *NavigationManager) RegisterNavigation(messageID string, character *sha
 		CurrentIndex: 0,
 		MessageID:    messageID,
 		ChannelID:    channelID,
+		Language:     lang,
 	}
 }
 
@@ -132,26 +135,26 @@ func (nm *NavigationManager) getTotalImages(imagesResult *shared.CharacterImages
 }
 
 // CreateCharacterEmbed creates an embed for character display with image navigation
-func (nm *NavigationManager) CreateCharacterEmbed(character *shared.Character, imagesResult *shared.CharacterImagesResult, imageIndex int) *discordgo.MessageEmbed {
-	return nm.createCharacterEmbed(character, imagesResult, imageIndex)
+func (nm *NavigationManager) CreateCharacterEmbed(character *shared.Character, imagesResult *shared.CharacterImagesResult, imageIndex int, lang string) *discordgo.MessageEmbed {
+	return nm.createCharacterEmbed(character, imagesResult, imageIndex, lang)
 }
 
 // createCharacterEmbed creates an embed for character display with image navigation
-func (nm *NavigationManager) createCharacterEmbed(character *shared.Character, imagesResult *shared.CharacterImagesResult, imageIndex int) *discordgo.MessageEmbed {
+func (nm *NavigationManager) createCharacterEmbed(character *shared.Character, imagesResult *shared.CharacterImagesResult, imageIndex int, lang string) *discordgo.MessageEmbed {
 	// Build footer text
-	footerText := "Data from umapyoi.net"
+	footerText := i18n.T(lang, "uma.footer.umapyoi")
 	if imagesResult.Found && len(imagesResult.Images) > 0 {
 		totalImages := 0
 		for _, category := range imagesResult.Images {
 			totalImages += len(category.Images)
 		}
 		if totalImages > 1 {
-			footerText = fmt.Sprintf("Data from umapyoi.net | Image %d of %d", imageIndex+1, totalImages)
+			footerText = i18n.T(lang, "uma.footer.umapyoi_image", imageIndex+1, totalImages)
 		}
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:       "🏇 Character Found",
+		Title:       i18n.T(lang, "uma.character_found"),
 		Description: fmt.Sprintf("**%s** (%s)", character.NameEn, character.NameJp),
 		Color:       0x00ff00, // Green color
 		Footer: &discordgo.MessageEmbedFooter{
@@ -160,17 +163,28 @@ func (nm *NavigationManager) createCharacterEmbed(character *shared.Character, i
 		Fields: []*discordgo.MessageEmbedField{},
 	}
 
+	// Japanese readers get the Japanese names first
+	categoryLabel := character.CategoryLabelEn
+	if lang == i18n.Japanese {
+		if character.NameJp != "" {
+			embed.Description = fmt.Sprintf("**%s** (%s)", character.NameJp, character.NameEn)
+		}
+		if character.CategoryLabel != "" {
+			categoryLabel = character.CategoryLabel
+		}
+	}
+
 	// Add character metadata fields
-	if character.CategoryLabelEn != "" {
+	if categoryLabel != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "Category",
-			Value:  character.CategoryLabelEn,
+			Name:   i18n.T(lang, "uma.category"),
+			Value:  categoryLabel,
 			Inline: true,
 		})
 	}
 
 	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-		Name:   "Character ID",
+		Name:   i18n.T(lang, "uma.character_id"),
 		Value:  fmt.Sprintf("%d", character.ID),
 		Inline: true,
 	})
@@ -183,7 +197,11 @@ func (nm *NavigationManager) createCharacterEmbed(character *shared.Character, i
 		for _, category := range imagesResult.Images {
 			for _, image := range category.Images {
 				allImages = append(allImages, image)
-				allCategories = append(allCategories, category.LabelEn)
+				if lang == i18n.Japanese && category.Label != "" {
+					allCategories = append(allCategories, category.Label)
+				} else {
+					allCategories = append(allCategories, category.LabelEn)
+				}
 			}
 		}
 
@@ -197,7 +215,7 @@ func (nm *NavigationManager) createCharacterEmbed(character *shared.Character, i
 			// Add image details
 			if len(allImages) > 1 {
 				embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-					Value:  fmt.Sprintf("**Type:** %s", category),
+					Value:  i18n.T(lang, "uma.image_type", category),
 					Inline: false,
 				})
 			}
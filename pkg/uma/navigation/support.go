@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/i18n"
 	"github.com/latoulicious/HKTM/pkg/uma/handler"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
 )
@@ -17,6 +18,7 @@ type SupportCardNavigationState struct {
 	MessageID    string
 	ChannelID    string
 	Query        string
+	Language     string
 }
 
 // SupportCardNavigationManager manages version navigation for support card embeds
@@ -121,7 +123,7 @@ func (scnm *SupportCardNavigationManager) HandleSupportCardReaction(s *discordgo
 	}
 
 	// Update the embed
-	embed := scnm.createSupportCardEmbed(state.SupportCards[state.CurrentIndex], state.SupportCards, state.CurrentIndex)
+	embed := scnm.createSupportCardEmbed(state.SupportCards[state.CurrentIndex], state.SupportCards, state.CurrentIndex, state.Language)
 
 	// Update the message
 	_, err := s.ChannelMessageEditEmbed(state.ChannelID, state.MessageID, embed)
@@ -134,8 +136,8 @@ func (scnm *SupportCardNavigationManager) HandleSupportCardReaction(s *discordgo
 	s.MessageReactionRemove(state.ChannelID, state.MessageID, reaction, r.UserID)
 }
 
-// RegisterSupportCardNavigation registers a new support card version navigation
-func (scnm *SupportCardNavigationManager) RegisterSupportCardNavigation(messageID string, supportCards []*shared.SimplifiedSupportCard, channelID string, query string) {
+// RegisterSupportCardNavigation registers a new support card version navigation, keeping the embed in lang
+func (scnm *SupportCardNavigationManager) RegisterSupportCardNavigation(messageID string, supportCards []*shared.SimplifiedSupportCard, channelID string, query string, lang string) {
 	scnm.mutex.Lock()
 	defer scnm.mutex.Unlock()
 
@@ -145,6 +147,7 @@ func (scnm *SupportCardNavigationManager) RegisterSupportCardNavigation(messageI
 		MessageID:    messageID,
 		ChannelID:    channelID,
 		Query:        query,
+		Language:     lang,
 	}
 }
 
@@ -157,12 +160,12 @@ func (scnm *SupportCardNavigationManager) CleanupSupportCardNavigation(messageID
 }
 
 // CreateSupportCardEmbed creates an embed for support card display with version navigation
-func (scnm *SupportCardNavigationManager) CreateSupportCardEmbed(supportCard *shared.SimplifiedSupportCard, allCards []*shared.SimplifiedSupportCard, currentIndex int) *discordgo.MessageEmbed {
-	return scnm.createSupportCardEmbed(supportCard, allCards, currentIndex)
+func (scnm *SupportCardNavigationManager) CreateSupportCardEmbed(supportCard *shared.SimplifiedSupportCard, allCards []*shared.SimplifiedSupportCard, currentIndex int, lang string) *discordgo.MessageEmbed {
+	return scnm.createSupportCardEmbed(supportCard, allCards, currentIndex, lang)
 }
 
 // createSupportCardEmbed creates an embed for support card display with version navigation
-func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *shared.SimplifiedSupportCard, allCards []*shared.SimplifiedSupportCard, currentIndex int) *discordgo.MessageEmbed {
+func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *shared.SimplifiedSupportCard, allCards []*shared.SimplifiedSupportCard, currentIndex int, lang string) *discordgo.MessageEmbed {
 	// Determine embed color based on rarity
 	var color int
 	switch supportCard.Rarity {
@@ -177,32 +180,32 @@ func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *sh
 	}
 
 	// Build footer text
-	footerText := "Data from Gametora API"
+	footerText := i18n.T(lang, "uma.footer.gametora")
 	if len(allCards) > 1 {
-		footerText = fmt.Sprintf("Data from Gametora API | %s Version (%d of %d)", shared.GetRarityText(supportCard.Rarity), currentIndex+1, len(allCards))
+		footerText = i18n.T(lang, "uma.footer.gametora_version", shared.GetRarityText(supportCard.Rarity), currentIndex+1, len(allCards))
 	}
 
 	// Create embed
 	embed := &discordgo.MessageEmbed{
 		Title:       supportCard.NameJp,
-		Description: fmt.Sprintf("**Character:** %s", supportCard.CharName),
+		Description: i18n.T(lang, "uma.character", supportCard.CharName),
 		Color:       color,
 		Footer: &discordgo.MessageEmbedFooter{
 			Text: footerText,
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "🎴 Rarity",
+				Name:   i18n.T(lang, "uma.rarity"),
 				Value:  shared.GetRarityText(supportCard.Rarity),
 				Inline: true,
 			},
 			{
-				Name:   "🎯 Type",
+				Name:   i18n.T(lang, "uma.type"),
 				Value:  supportCard.Type,
 				Inline: true,
 			},
 			{
-				Name:   "🆔 Support ID",
+				Name:   i18n.T(lang, "uma.support_id"),
 				Value:  fmt.Sprintf("%d", supportCard.SupportID),
 				Inline: true,
 			},
@@ -212,7 +215,7 @@ func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *sh
 	// Add obtained info if available
 	if supportCard.Obtained != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "📦 Obtained",
+			Name:   i18n.T(lang, "uma.obtained"),
 			Value:  supportCard.Obtained,
 			Inline: true,
 		})
@@ -242,7 +245,7 @@ func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *sh
 		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("💡 Support Hints (%d)", len(supportCard.Hints.HintSkills)),
+			Name:   i18n.T(lang, "uma.support_hints", len(supportCard.Hints.HintSkills)),
 			Value:  hintsText.String(),
 			Inline: false,
 		})
@@ -259,7 +262,7 @@ func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *sh
 		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("🎉 Event Skills (%d)", len(supportCard.EventSkills)),
+			Name:   i18n.T(lang, "uma.event_skills", len(supportCard.EventSkills)),
 			Value:  eventsText.String(),
 			Inline: false,
 		})
@@ -281,7 +284,7 @@ func (scnm *SupportCardNavigationManager) createSupportCardEmbed(supportCard *sh
 		}
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("📋 Available Versions (%d)", len(allCards)),
+			Name:   i18n.T(lang, "uma.available_versions", len(allCards)),
 			Value:  versionsText.String(),
 			Inline: false,
 		})
@@ -0,0 +1,178 @@
+// Package search ranks Uma Musume characters and support cards against what a player
+// typed, tolerating typos, kana and romaji spellings, punctuation and full width text.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// hiraganaRomaji spells each hiragana in Hepburn romaji
+var hiraganaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// romanization folds the Hepburn spellings onto their Kunrei-shiki equivalents so
+// "Machikane" and "Matikane" compare equal
+var romanization = strings.NewReplacer(
+	"shi", "si", "sha", "sya", "shu", "syu", "sho", "syo",
+	"chi", "ti", "cha", "tya", "chu", "tyu", "cho", "tyo",
+	"tsu", "tu", "fu", "hu",
+	"ji", "zi", "ja", "zya", "ju", "zyu", "jo", "zyo",
+)
+
+// longVowels drops doubled vowels, since "Tōkai", "Toukai" and "Tokai" are all written
+var longVowels = strings.NewReplacer("ou", "o", "oo", "o", "uu", "u", "aa", "a", "ii", "i", "ee", "e")
+
+// Normalize reduces a name or query to lowercase romaji words separated by single spaces.
+// Width is folded, kana are romanized, accents, macrons and punctuation are dropped and
+// romanization variants are unified. Kanji are kept as they are.
+func Normalize(s string) string {
+	// NFKC folds full width letters to ASCII and half width katakana to full width
+	s = strings.ToLower(norm.NFKC.String(s))
+	s = romanizeKana(s)
+
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents and macrons decompose into combining marks
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(norm.NFC.String(b.String()))
+	for i, word := range words {
+		words[i] = longVowels.Replace(romanization.Replace(word))
+	}
+	return strings.Join(words, " ")
+}
+
+// Key reduces a name to the form nicknames are stored and looked up by: normalized with
+// the spaces removed, so "Spe-chan" and "spe chan" share one key, as do "スペちゃん" and "すぺちゃん"
+func Key(s string) string {
+	return strings.ReplaceAll(Normalize(s), " ", "")
+}
+
+// Terms returns the forms of a query worth looking up in the database: as typed and, when
+// it has hiragana, written in katakana like the stored Japanese names
+func Terms(query string) []string {
+	terms := []string{query}
+	if converted := katakana(query); converted != query {
+		terms = append(terms, converted)
+	}
+	return terms
+}
+
+// katakana writes hiragana as katakana
+func katakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + 0x60
+		}
+		return r
+	}, s)
+}
+
+// romanizeKana spells hiragana and katakana in Hepburn romaji, leaving other text alone
+func romanizeKana(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	doubleNext := false
+
+	for i := 0; i < len(runes); i++ {
+		r := hiragana(runes[i])
+		switch r {
+		case 'っ':
+			doubleNext = true
+			continue
+		case 'ー':
+			// Long vowel marks are dropped like other vowel lengthening
+			continue
+		}
+
+		syllable, isKana := hiraganaRomaji[r]
+		if !isKana {
+			doubleNext = false
+			b.WriteRune(runes[i])
+			continue
+		}
+
+		// Small kana after a syllable combine with it, as in きゃ or ティ
+		if i+1 < len(runes) {
+			if combined, ok := combineSmallKana(syllable, hiragana(runes[i+1])); ok {
+				syllable = combined
+				i++
+			}
+		}
+
+		if doubleNext {
+			if strings.HasPrefix(syllable, "ch") {
+				b.WriteByte('t')
+			} else if !strings.ContainsRune("aiueon", rune(syllable[0])) {
+				b.WriteByte(syllable[0])
+			}
+			doubleNext = false
+		}
+		b.WriteString(syllable)
+	}
+	return b.String()
+}
+
+// combineSmallKana joins a syllable with the small kana that follows it
+func combineSmallKana(syllable string, small rune) (string, bool) {
+	switch small {
+	case 'ゃ', 'ゅ', 'ょ':
+		if len(syllable) < 2 || !strings.HasSuffix(syllable, "i") {
+			return "", false
+		}
+		consonant := strings.TrimSuffix(syllable, "i")
+		if consonant != "sh" && consonant != "ch" && consonant != "j" {
+			consonant += "y"
+		}
+		return consonant + hiraganaRomaji[small][1:], true
+	case 'ぁ', 'ぃ', 'ぅ', 'ぇ', 'ぉ':
+		var consonant string
+		switch syllable {
+		case "u":
+			consonant = "w"
+		case "i":
+			consonant = "y"
+		case "shi", "chi", "ji":
+			consonant = strings.TrimSuffix(syllable, "i")
+		default:
+			consonant = syllable[:len(syllable)-1]
+		}
+		return consonant + hiraganaRomaji[small], true
+	}
+	return "", false
+}
+
+// hiragana maps katakana to hiragana so both share one romaji table
+func hiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
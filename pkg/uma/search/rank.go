@@ -0,0 +1,184 @@
+package search
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// MinScore is the lowest score still counted as a match
+	MinScore = 0.5
+	// AmbiguityMargin is how close a runner-up must score to the best match for the
+	// searcher to be asked which one they meant
+	AmbiguityMargin = 0.1
+	// MaxContenders is the most matches offered when a query is ambiguous
+	MaxContenders = 5
+)
+
+// Match is a candidate that resembles the query
+type Match struct {
+	Index int     // Position of the candidate in the ranked slice
+	Score float64 // 1 for an exact match, lower for looser ones
+}
+
+// Exact reports whether the query names the candidate exactly, after normalization
+func (m Match) Exact() bool {
+	return m.Score >= 1
+}
+
+// Rank scores each candidate by the best of its names and returns the matches, best
+// first. Ties keep the candidates' order.
+func Rank(query string, names [][]string) []Match {
+	normalizedQuery := Normalize(query)
+	if normalizedQuery == "" {
+		return nil
+	}
+
+	var matches []Match
+	for index, candidateNames := range names {
+		best := 0.0
+		for _, name := range candidateNames {
+			if score := similarity(normalizedQuery, Normalize(name)); score > best {
+				best = score
+			}
+		}
+		if best >= MinScore {
+			matches = append(matches, Match{Index: index, Score: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// Contenders returns the matches that score within AmbiguityMargin of the best one, or
+// just the best one when it's exact
+func Contenders(matches []Match) []Match {
+	if len(matches) == 0 {
+		return nil
+	}
+	if matches[0].Exact() {
+		return matches[:1]
+	}
+
+	count := 1
+	for count < len(matches) && count < MaxContenders && matches[0].Score-matches[count].Score < AmbiguityMargin {
+		count++
+	}
+	return matches[:count]
+}
+
+// Ambiguous reports whether the searcher should pick between several close matches
+func Ambiguous(matches []Match) bool {
+	return len(Contenders(matches)) > 1
+}
+
+// similarity scores a normalized query against a normalized name. Exact matches score 1,
+// names containing the query from 0.7 and misspellings at most 0.85 of their likeness.
+func similarity(query, name string) float64 {
+	compactQuery := strings.ReplaceAll(query, " ", "")
+	compactName := strings.ReplaceAll(name, " ", "")
+	if compactQuery == "" || compactName == "" {
+		return 0
+	}
+	if compactQuery == compactName {
+		return 1
+	}
+
+	if strings.Contains(compactName, compactQuery) {
+		// Longer queries cover more of the name and say more about which one was meant
+		score := 0.7 + 0.2*float64(runeCount(compactQuery))/float64(runeCount(compactName))
+		if strings.HasPrefix(name, query) || strings.Contains(name, " "+query) {
+			score += 0.05
+		}
+		return score
+	}
+
+	likeness := trigramSimilarity(query, name)
+	if edit := editSimilarity(compactQuery, compactName); edit > likeness {
+		likeness = edit
+	}
+
+	// The query may misspell only part of the name, such as the given name alone
+	queryWords := len(strings.Fields(query))
+	nameWords := strings.Fields(name)
+	for start := 0; start+queryWords <= len(nameWords) && queryWords < len(nameWords); start++ {
+		span := strings.Join(nameWords[start:start+queryWords], "")
+		if partial := 0.9 * editSimilarity(compactQuery, span); partial > likeness {
+			likeness = partial
+		}
+	}
+
+	return 0.85 * likeness
+}
+
+// trigramSimilarity compares the trigrams of two strings the way pg_trgm does, padding
+// each word so beginnings and endings count
+func trigramSimilarity(a, b string) float64 {
+	gramsA, gramsB := trigrams(a), trigrams(b)
+	if len(gramsA) == 0 || len(gramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for gram := range gramsA {
+		if gramsB[gram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(gramsA)+len(gramsB)-shared)
+}
+
+// trigrams returns the set of three character sequences in the padded words of s
+func trigrams(s string) map[string]bool {
+	grams := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			grams[string(padded[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// editSimilarity turns the Levenshtein distance between two strings into a 0 to 1 likeness
+func editSimilarity(a, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	longest := len(runesA)
+	if len(runesB) > longest {
+		longest = len(runesB)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(runesA, runesB))/float64(longest)
+}
+
+// levenshtein counts the insertions, deletions and substitutions turning a into b
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// runeCount counts characters rather than bytes so kanji weigh the same as letters
+func runeCount(s string) int {
+	return len([]rune(s))
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/latoulicious/HKTM/pkg/database/models"
+	"github.com/latoulicious/HKTM/pkg/logging"
+	"github.com/latoulicious/HKTM/pkg/uma"
+	"github.com/latoulicious/HKTM/pkg/uma/search"
+)
+
+// Alias kinds
+const (
+	AliasCharacter = "character"
+	AliasSupport   = "support"
+)
+
+// bundledAliases lists well known nicknames by the English name of what they stand for
+//
+//go:embed aliases.json
+var bundledAliases []byte
+
+type AliasService struct {
+	service *uma.Service
+	logger  logging.Logger
+}
+
+var _ uma.AliasServiceInterface = (*AliasService)(nil)
+
+func NewAliasService(s *uma.Service) uma.AliasServiceInterface {
+	return &AliasService{
+		service: s,
+		logger:  logging.GetGlobalLoggerFactory().CreateLogger("uma_service"),
+	}
+}
+
+// SeedAliases stores the bundled aliases that aren't in the database yet and drops the ones
+// taken out of the bundle. They are linked to their character or card the first time a
+// search resolves them.
+func (as *AliasService) SeedAliases() error {
+	if as.service.AliasRepo == nil {
+		return fmt.Errorf("alias storage is not available")
+	}
+
+	var bundle struct {
+		Characters   map[string][]string `json:"characters"`
+		SupportCards map[string][]string `json:"support_cards"`
+	}
+	if err := json.Unmarshal(bundledAliases, &bundle); err != nil {
+		return fmt.Errorf("failed to parse bundled aliases: %w", err)
+	}
+
+	var aliases []models.UmaAlias
+	add := func(kind string, targets map[string][]string) {
+		for target, names := range targets {
+			for _, name := range names {
+				aliases = append(aliases, models.UmaAlias{Kind: kind, Key: search.Key(name), Alias: name, Target: target})
+			}
+		}
+	}
+	add(AliasCharacter, bundle.Characters)
+	add(AliasSupport, bundle.SupportCards)
+
+	if err := as.service.AliasRepo.SeedAliases(aliases); err != nil {
+		return fmt.Errorf("failed to seed aliases: %w", err)
+	}
+
+	as.logger.Info("Seeded bundled Uma aliases", map[string]interface{}{
+		"aliases": len(aliases),
+	})
+	return nil
+}
+
+// AddAlias makes a nickname find a character or support card in a guild, replacing what
+// the guild's alias with the same key pointed to before
+func (as *AliasService) AddAlias(guildID, kind, alias, target string, targetID int, createdBy string) (*models.UmaAlias, error) {
+	if as.service.AliasRepo == nil {
+		return nil, fmt.Errorf("alias storage is not available")
+	}
+
+	key := search.Key(alias)
+	if key == "" {
+		return nil, fmt.Errorf("the alias needs at least one letter or number")
+	}
+
+	row := &models.UmaAlias{
+		GuildID:   guildID,
+		Kind:      kind,
+		Key:       key,
+		Alias:     alias,
+		Target:    target,
+		CreatedBy: createdBy,
+	}
+	if kind == AliasCharacter {
+		row.CharacterID = targetID
+	} else {
+		row.SupportID = targetID
+	}
+
+	if err := as.service.AliasRepo.SaveAlias(row); err != nil {
+		return nil, fmt.Errorf("failed to save alias: %w", err)
+	}
+
+	as.logger.Info("Uma alias added", map[string]interface{}{
+		"guild_id":   guildID,
+		"kind":       kind,
+		"alias":      alias,
+		"target":     target,
+		"target_id":  targetID,
+		"created_by": createdBy,
+	})
+	return row, nil
+}
+
+// RemoveAlias stops an alias from working in a guild, returning false when the guild has
+// no such alias. The guild's own alias is deleted; a bundled one is hidden in the guild
+// until the alias is added again.
+func (as *AliasService) RemoveAlias(guildID, kind, alias string) (bool, error) {
+	if as.service.AliasRepo == nil {
+		return false, fmt.Errorf("alias storage is not available")
+	}
+
+	key := search.Key(alias)
+	current, err := as.service.AliasRepo.FindAlias(guildID, kind, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove alias: %w", err)
+	}
+	if current == nil || current.Hidden {
+		return false, nil
+	}
+
+	bundled, err := as.service.AliasRepo.FindAlias("", kind, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove alias: %w", err)
+	}
+
+	if bundled != nil {
+		// Replacing the guild's alias with a hidden one also turns the bundled one off
+		err = as.service.AliasRepo.SaveAlias(&models.UmaAlias{
+			GuildID: guildID,
+			Kind:    kind,
+			Key:     key,
+			Alias:   bundled.Alias,
+			Target:  bundled.Target,
+			Hidden:  true,
+		})
+	} else {
+		_, err = as.service.AliasRepo.DeleteAlias(guildID, kind, key)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to remove alias: %w", err)
+	}
+
+	as.logger.Info("Uma alias removed", map[string]interface{}{
+		"guild_id": guildID,
+		"kind":     kind,
+		"alias":    alias,
+		"bundled":  bundled != nil,
+	})
+	return true, nil
+}
+
+// ListAliases returns the aliases of a kind a guild can use, bundled ones included
+func (as *AliasService) ListAliases(guildID, kind string) ([]models.UmaAlias, error) {
+	if as.service.AliasRepo == nil {
+		return nil, fmt.Errorf("alias storage is not available")
+	}
+	return as.service.AliasRepo.GetAliases(guildID, kind)
+}
+
+// findAlias returns the alias a query spells in a guild, or nil when it isn't one
+func findAlias(s *uma.Service, logger logging.Logger, guildID, kind, query string) *models.UmaAlias {
+	if s.AliasRepo == nil {
+		return nil
+	}
+
+	key := search.Key(query)
+	if key == "" {
+		return nil
+	}
+
+	alias, err := s.AliasRepo.FindAlias(guildID, kind, key)
+	if err != nil {
+		logger.Error("Database query error during alias lookup", err, map[string]interface{}{
+			"guild_id": guildID,
+			"query":    query,
+			"kind":     kind,
+		})
+		return nil
+	}
+	if alias != nil && alias.Hidden {
+		return nil
+	}
+	return alias
+}
+
+// linkAlias remembers what an alias resolved to so later searches skip the name lookup
+func linkAlias(s *uma.Service, logger logging.Logger, alias *models.UmaAlias, characterID, supportID int) {
+	if err := s.AliasRepo.LinkAlias(alias.ID, characterID, supportID); err != nil {
+		logger.Warn("Failed to link alias", map[string]interface{}{
+			"alias":  alias.Alias,
+			"target": alias.Target,
+			"error":  err.Error(),
+		})
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"github.com/latoulicious/HKTM/pkg/database/models"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"github.com/latoulicious/HKTM/pkg/uma"
+	"github.com/latoulicious/HKTM/pkg/uma/search"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
 )
 
+// candidateLimit is how many database rows a search ranks
+const candidateLimit = 50
+
 type CharacterService struct {
 	service *uma.Service
 	logger  logging.Logger
@@ -23,35 +27,78 @@ func NewCharacterService(s *uma.Service) uma.CharacterServiceInterface {
 	}
 }
 
-// SearchCharacter searches for a character by name, checking database first then API
-func (cs *CharacterService) SearchCharacter(query string) (*shared.CharacterSearchResult, error) {
+// SearchCharacter searches for a character by nickname or name in a guild. Nicknames
+// resolve first; other queries are ranked against the database, then the API.
+func (cs *CharacterService) SearchCharacter(guildID, query string) (*shared.CharacterSearchResult, error) {
+	alias := findAlias(cs.service, cs.logger, guildID, AliasCharacter, query)
+	if alias == nil {
+		return cs.searchByName(query)
+	}
+
+	cs.logger.Info("Query is a character alias", map[string]interface{}{
+		"query":        query,
+		"guild_id":     guildID,
+		"target":       alias.Target,
+		"character_id": alias.CharacterID,
+		"stage":        "alias_found",
+	})
+
+	if alias.CharacterID != 0 {
+		if dbCharacter, err := cs.service.CharacterRepo.GetCharacterByCharacterID(alias.CharacterID); err == nil {
+			return &shared.CharacterSearchResult{
+				Found:     true,
+				Character: cs.convertDBCharacterToShared(dbCharacter),
+				Query:     query,
+			}, nil
+		}
+	}
+
+	// The character isn't cached or the alias isn't linked yet, so look its name up
+	result, err := cs.searchByName(alias.Target)
+	if err != nil {
+		return nil, err
+	}
+	result.Query = query
+	if result.Found && len(result.Candidates) == 0 && alias.CharacterID != result.Character.ID {
+		linkAlias(cs.service, cs.logger, alias, result.Character.ID, 0)
+	}
+	return result, nil
+}
+
+// searchByName searches for a character by name, checking database first then API
+func (cs *CharacterService) searchByName(query string) (*shared.CharacterSearchResult, error) {
 	cs.logger.Info("Starting character search", map[string]interface{}{
 		"query": query,
 		"stage": "database_lookup",
 	})
 
 	// First, try to find in database
-	dbCharacter, err := cs.service.CharacterRepo.GetCharacterByName(query)
+	matches, err := cs.rankDatabaseCharacters(query)
 	if err != nil {
 		cs.logger.Error("Database query error during character search", err, map[string]interface{}{
 			"query": query,
 			"stage": "database_lookup",
 		})
-	} else if dbCharacter != nil {
+	} else if len(matches) > 0 {
 		cs.logger.Info("Found character in database", map[string]interface{}{
 			"query":          query,
-			"character_name": dbCharacter.NameEn,
-			"character_id":   dbCharacter.CharacterID,
+			"character_name": matches[0].NameEn,
+			"character_id":   matches[0].CharacterID,
+			"candidates":     len(matches),
 			"stage":          "database_found",
 		})
-		// Convert database model to API model
-		character := cs.convertDBCharacterToShared(dbCharacter)
-
-		return &shared.CharacterSearchResult{
+		// Convert database models to API models
+		result := &shared.CharacterSearchResult{
 			Found:     true,
-			Character: character,
+			Character: cs.convertDBCharacterToShared(&matches[0]),
 			Query:     query,
-		}, nil
+		}
+		if len(matches) > 1 {
+			for i := range matches {
+				result.Candidates = append(result.Candidates, *cs.convertDBCharacterToShared(&matches[i]))
+			}
+		}
+		return result, nil
 	} else {
 		cs.logger.Info("Character not found in database", map[string]interface{}{
 			"query": query,
@@ -100,6 +147,58 @@ func (cs *CharacterService) SearchCharacter(query string) (*shared.CharacterSear
 	return result, nil
 }
 
+// characterCandidates returns the cached characters worth ranking for a query: those the
+// trigram lookup finds, or every one when pg_trgm isn't available
+func (cs *CharacterService) characterCandidates(query string) ([]models.Character, error) {
+	if !cs.service.CharacterRepo.HasTrigramSearch() {
+		return cs.service.CharacterRepo.GetAllCharacters()
+	}
+
+	var candidates []models.Character
+	// Japanese names are stored in katakana, so also look up a hiragana query in katakana
+	for _, term := range search.Terms(query) {
+		found, err := cs.service.CharacterRepo.FindCharacterCandidates(term, candidateLimit)
+		if err != nil {
+			cs.logger.Warn("Trigram character lookup failed, ranking every cached character", map[string]interface{}{
+				"query": query,
+				"error": err.Error(),
+			})
+			return cs.service.CharacterRepo.GetAllCharacters()
+		}
+		candidates = append(candidates, found...)
+	}
+	return candidates, nil
+}
+
+// rankDatabaseCharacters returns the cached characters the query most likely means, best
+// first. More than one means the query is ambiguous between them.
+func (cs *CharacterService) rankDatabaseCharacters(query string) ([]models.Character, error) {
+	candidates, err := cs.characterCandidates(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syncs may store a character more than once
+	seen := make(map[int]bool)
+	var unique []models.Character
+	var names [][]string
+	for _, character := range candidates {
+		if seen[character.CharacterID] {
+			continue
+		}
+		seen[character.CharacterID] = true
+		unique = append(unique, character)
+		names = append(names, []string{character.NameEn, character.NameJp, character.NameEnInternal})
+	}
+
+	contenders := search.Contenders(search.Rank(query, names))
+	characters := make([]models.Character, len(contenders))
+	for i, match := range contenders {
+		characters[i] = unique[match.Index]
+	}
+	return characters, nil
+}
+
 // GetCharacterImages gets character images, checking database first then API
 func (cs *CharacterService) GetCharacterImages(charaID int) (*shared.CharacterImagesResult, error) {
 	// First, try to find in database
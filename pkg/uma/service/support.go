@@ -1,10 +1,13 @@
 package service
 
 import (
+	"sort"
+
 	"github.com/google/uuid"
 	"github.com/latoulicious/HKTM/pkg/database/models"
 	"github.com/latoulicious/HKTM/pkg/logging"
 	"github.com/latoulicious/HKTM/pkg/uma"
+	"github.com/latoulicious/HKTM/pkg/uma/search"
 	"github.com/latoulicious/HKTM/pkg/uma/shared"
 )
 
@@ -22,15 +25,87 @@ func NewSupportCardService(s *uma.Service) uma.SupportCardServiceInterface {
 	}
 }
 
-// SearchSupportCard searches for a support card by name, checking database first then API
-func (scs *SupportCardService) SearchSupportCard(query string) (*shared.SupportCardSearchResult, error) {
+// SearchSupportCard searches for a support card by nickname or name in a guild. Nicknames
+// of cards resolve first, then nicknames of characters, which find that character's cards;
+// other queries are ranked against the database, then the API.
+func (scs *SupportCardService) SearchSupportCard(guildID, query string) (*shared.SupportCardSearchResult, error) {
+	if alias := findAlias(scs.service, scs.logger, guildID, AliasSupport, query); alias != nil {
+		scs.logger.Info("Query is a support card alias", map[string]interface{}{
+			"query":      query,
+			"guild_id":   guildID,
+			"target":     alias.Target,
+			"support_id": alias.SupportID,
+			"stage":      "alias_found",
+		})
+
+		// Follow the linked card in case its title changed since
+		title := alias.Target
+		if alias.SupportID != 0 {
+			if dbCard, err := scs.service.SupportCardRepo.GetSupportCardBySupportID(alias.SupportID); err == nil {
+				title = dbCard.TitleEn
+			}
+		}
+
+		result, err := scs.searchByName(title)
+		if err != nil {
+			return nil, err
+		}
+		result.Query = query
+		if result.Found && len(result.Candidates) == 0 && alias.SupportID == 0 && result.SupportCard != nil {
+			linkAlias(scs.service, scs.logger, alias, 0, result.SupportCard.ID)
+		}
+		return result, nil
+	}
+
+	if alias := findAlias(scs.service, scs.logger, guildID, AliasCharacter, query); alias != nil {
+		scs.logger.Info("Query is a character alias, searching its support cards", map[string]interface{}{
+			"query":        query,
+			"guild_id":     guildID,
+			"target":       alias.Target,
+			"character_id": alias.CharacterID,
+			"stage":        "alias_found",
+		})
+
+		if alias.CharacterID != 0 {
+			dbSupportCards, err := scs.service.SupportCardRepo.GetSupportCardsByCharaID(alias.CharacterID)
+			if err == nil && len(dbSupportCards) > 0 {
+				// Every card of the character is a candidate
+				titles, cardsByTitle := groupSupportCardsByTitle(dbSupportCards)
+				supportCards := scs.convertDBSupportCardsToShared(cardsByTitle[titles[0].TitleEn])
+				result := &shared.SupportCardSearchResult{
+					Found:        true,
+					SupportCard:  &supportCards[0],
+					SupportCards: supportCards,
+					Query:        query,
+				}
+				if len(titles) > 1 {
+					result.Candidates = scs.convertDBSupportCardsToShared(titles)
+				}
+				return result, nil
+			}
+		}
+
+		// Card titles and Gametora IDs carry the character's name
+		result, err := scs.searchByName(alias.Target)
+		if err != nil {
+			return nil, err
+		}
+		result.Query = query
+		return result, nil
+	}
+
+	return scs.searchByName(query)
+}
+
+// searchByName searches for a support card by name, checking database first then API
+func (scs *SupportCardService) searchByName(query string) (*shared.SupportCardSearchResult, error) {
 	scs.logger.Info("Starting support card search", map[string]interface{}{
 		"query": query,
 		"stage": "database_lookup",
 	})
 
 	// First, try to find in database
-	dbSupportCards, err := scs.service.SupportCardRepo.GetSupportCardsByTitle(query)
+	dbSupportCards, contenders, err := scs.rankDatabaseSupportCards(query)
 	if err != nil {
 		scs.logger.Error("Database query error during support card search", err, map[string]interface{}{
 			"query": query,
@@ -40,16 +115,22 @@ func (scs *SupportCardService) SearchSupportCard(query string) (*shared.SupportC
 		scs.logger.Info("Found support cards in database", map[string]interface{}{
 			"query":       query,
 			"cards_count": len(dbSupportCards),
+			"candidates":  len(contenders),
 			"stage":       "database_found",
 		})
 		// Convert database models to API models
 		supportCards := scs.convertDBSupportCardsToShared(dbSupportCards)
 
-		return &shared.SupportCardSearchResult{
+		result := &shared.SupportCardSearchResult{
 			Found:        true,
+			SupportCard:  &supportCards[0],
 			SupportCards: supportCards,
 			Query:        query,
-		}, nil
+		}
+		if len(contenders) > 1 {
+			result.Candidates = scs.convertDBSupportCardsToShared(contenders)
+		}
+		return result, nil
 	} else {
 		scs.logger.Info("Support cards not found in database", map[string]interface{}{
 			"query": query,
@@ -95,6 +176,82 @@ func (scs *SupportCardService) SearchSupportCard(query string) (*shared.SupportC
 	return result, nil
 }
 
+// supportCardCandidates returns the cached support cards worth ranking for a query: those
+// the trigram lookup finds, or every one when pg_trgm isn't available
+func (scs *SupportCardService) supportCardCandidates(query string) ([]models.SupportCard, error) {
+	if !scs.service.SupportCardRepo.HasTrigramSearch() {
+		return scs.service.SupportCardRepo.GetAllSupportCards()
+	}
+
+	var candidates []models.SupportCard
+	// Japanese titles are stored in katakana and kanji, so also look up a hiragana query in katakana
+	for _, term := range search.Terms(query) {
+		found, err := scs.service.SupportCardRepo.FindSupportCardCandidates(term, candidateLimit)
+		if err != nil {
+			scs.logger.Warn("Trigram support card lookup failed, ranking every cached support card", map[string]interface{}{
+				"query": query,
+				"error": err.Error(),
+			})
+			return scs.service.SupportCardRepo.GetAllSupportCards()
+		}
+		candidates = append(candidates, found...)
+	}
+	return candidates, nil
+}
+
+// rankDatabaseSupportCards returns every rarity of the cached card the query most likely
+// means, highest rarity first, and one card per title for each close contender. More than
+// one contender means the query is ambiguous between them.
+func (scs *SupportCardService) rankDatabaseSupportCards(query string) ([]models.SupportCard, []models.SupportCard, error) {
+	candidates, err := scs.supportCardCandidates(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	titles, cardsByTitle := groupSupportCardsByTitle(candidates)
+	names := make([][]string, len(titles))
+	for i, card := range titles {
+		names[i] = []string{card.TitleEn, card.Title, card.Gametora}
+	}
+
+	contenders := search.Contenders(search.Rank(query, names))
+	if len(contenders) == 0 {
+		return nil, nil, nil
+	}
+
+	best := cardsByTitle[titles[contenders[0].Index].TitleEn]
+	sort.SliceStable(best, func(i, j int) bool {
+		return best[i].Rarity > best[j].Rarity
+	})
+
+	contenderCards := make([]models.SupportCard, len(contenders))
+	for i, match := range contenders {
+		contenderCards[i] = titles[match.Index]
+	}
+	return best, contenderCards, nil
+}
+
+// groupSupportCardsByTitle collapses cards stored more than once by syncs and groups the
+// rest by title, since every rarity of a card shares it. Titles keep the order they first
+// appear in, each represented by its first card.
+func groupSupportCardsByTitle(cards []models.SupportCard) ([]models.SupportCard, map[string][]models.SupportCard) {
+	seen := make(map[int]bool)
+	cardsByTitle := make(map[string][]models.SupportCard)
+	var titles []models.SupportCard
+	for _, card := range cards {
+		if seen[card.SupportID] {
+			continue
+		}
+		seen[card.SupportID] = true
+
+		if _, exists := cardsByTitle[card.TitleEn]; !exists {
+			titles = append(titles, card)
+		}
+		cardsByTitle[card.TitleEn] = append(cardsByTitle[card.TitleEn], card)
+	}
+	return titles, cardsByTitle
+}
+
 // GetSupportCardList gets all support cards, checking database first then API
 func (scs *SupportCardService) GetSupportCardList() (*shared.SupportCardListResult, error) {
 	// First, try to find in database
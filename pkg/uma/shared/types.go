@@ -20,10 +20,11 @@ type Character struct {
 
 // CharacterSearchResult represents the result of a character search
 type CharacterSearchResult struct {
-	Found     bool
-	Character *Character
-	Error     error
-	Query     string
+	Found      bool
+	Character  *Character
+	Candidates []Character // Close matches to choose from when the query is ambiguous
+	Error      error
+	Query      string
 }
 
 // CharacterListResult represents the result of fetching all characters
@@ -88,6 +89,7 @@ type SupportCardSearchResult struct {
 	Found        bool
 	SupportCard  *SupportCard
 	SupportCards []SupportCard // Multiple cards for the same character
+	Candidates   []SupportCard // Close matches, one per title, when the query is ambiguous
 	Error        error
 	Query        string
 }
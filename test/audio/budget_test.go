@@ -0,0 +1,65 @@
+package audio_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+)
+
+func TestProcessBudget(t *testing.T) {
+	budget := audio.NewProcessBudget(1, 1, time.Second)
+	ctx := context.Background()
+
+	release, err := budget.Acquire(ctx, audio.ProcessExtraction, audio.PriorityMetadata, nil)
+	if err != nil {
+		t.Fatalf("Acquire() with a free slot failed: %v", err)
+	}
+	if position := budget.Position(audio.ProcessExtraction, audio.PriorityMetadata); position != 1 {
+		t.Errorf("Position() with a full pool = %d, expected 1", position)
+	}
+	if position := budget.Position(audio.ProcessStream, audio.PriorityPlayback); position != 0 {
+		t.Errorf("Position() for streams = %d, expected 0 since pools are separate", position)
+	}
+
+	// Playback overtakes a metadata lookup that was queued first
+	order := make(chan audio.ProcessPriority, 2)
+	queued := make(chan int, 2)
+	for _, priority := range []audio.ProcessPriority{audio.PriorityMetadata, audio.PriorityPlayback} {
+		go func(priority audio.ProcessPriority) {
+			done, err := budget.Acquire(ctx, audio.ProcessExtraction, priority, func(position int) { queued <- position })
+			if err != nil {
+				t.Errorf("Acquire() while queued failed: %v", err)
+				order <- -1
+				return
+			}
+			order <- priority
+			done()
+		}(priority)
+		<-queued
+	}
+
+	usage := budget.Usage()
+	if usage[1].Kind != audio.ProcessExtraction || usage[1].Active != 1 || usage[1].Waiting != 2 {
+		t.Errorf("unexpected usage: %+v", usage[1])
+	}
+
+	release()
+	release() // Releasing twice must not free a second slot
+	if first := <-order; first != audio.PriorityPlayback {
+		t.Errorf("expected playback to be admitted first, got priority %d", first)
+	}
+	<-order
+
+	// Requests give up once the wait timeout passes
+	hold, _ := budget.Acquire(ctx, audio.ProcessStream, audio.PriorityPlayback, nil)
+	defer hold()
+	if _, err := budget.Acquire(ctx, audio.ProcessStream, audio.PriorityPlayback, nil); !errors.Is(err, audio.ErrBudgetTimeout) {
+		t.Errorf("expected ErrBudgetTimeout, got %v", err)
+	}
+	if usage := budget.Usage(); usage[0].Active != 1 || usage[0].Waiting != 0 {
+		t.Errorf("unexpected stream usage after timeout: %+v", usage[0])
+	}
+}
@@ -0,0 +1,192 @@
+package audio_test
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/audio"
+)
+
+// TestPipelineStopAfterVoiceReconnect stops playback while the streaming loop waits for
+// the voice connection and after it was rebound. Run with -race: stopping swaps the stop
+// channel and context the loop waits on.
+func TestPipelineStopAfterVoiceReconnect(t *testing.T) {
+	t.Run("stop while waiting for voice", func(t *testing.T) {
+		processor := &fakeStreamProcessor{}
+		pipeline := newFakePipeline(t, processor)
+
+		if err := pipeline.PlayURL("https://example.com/a.mp3", &discordgo.VoiceConnection{GuildID: "test-guild"}); err != nil {
+			t.Fatalf("PlayURL() failed: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+
+		if err := pipeline.Stop(); err != nil {
+			t.Fatalf("Stop() failed: %v", err)
+		}
+		processor.waitForClosed(t)
+	})
+
+	t.Run("stop after reconnect", func(t *testing.T) {
+		processor := &fakeStreamProcessor{}
+		pipeline := newFakePipeline(t, processor)
+
+		if err := pipeline.PlayURL("https://example.com/a.mp3", &discordgo.VoiceConnection{GuildID: "test-guild"}); err != nil {
+			t.Fatalf("PlayURL() failed: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+
+		ready := &discordgo.VoiceConnection{GuildID: "test-guild", Ready: true, OpusSend: make(chan []byte, 16)}
+		pipeline.SetVoiceConnection(ready)
+
+		select {
+		case <-ready.OpusSend:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no audio was sent after the voice connection came back")
+		}
+
+		if err := pipeline.Stop(); err != nil {
+			t.Fatalf("Stop() failed: %v", err)
+		}
+		if pipeline.IsPlaying() {
+			t.Error("expected playback to have stopped")
+		}
+		processor.waitForClosed(t)
+	})
+}
+
+func newFakePipeline(t *testing.T, processor *fakeStreamProcessor) *audio.AudioPipelineController {
+	t.Helper()
+
+	pipeline := audio.NewAudioPipelineController(
+		processor,
+		fakeEncoder{},
+		audio.NewBasicErrorHandler(&audio.RetryConfig{}, nil, nil, "test-guild"),
+		fakeMetrics{},
+		fakeLogger{},
+		fakeConfig{},
+	)
+	if err := pipeline.Initialize(); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	t.Cleanup(func() { pipeline.Stop() })
+	return pipeline
+}
+
+// fakeStreamProcessor hands out endless silent streams
+type fakeStreamProcessor struct {
+	mu      sync.Mutex
+	streams []*silentStream
+}
+
+func (p *fakeStreamProcessor) StartStream(url string) (io.ReadCloser, error) {
+	return p.StartClip(url, audio.Clip{})
+}
+
+func (p *fakeStreamProcessor) StartClip(url string, clip audio.Clip) (io.ReadCloser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stream := &silentStream{}
+	p.streams = append(p.streams, stream)
+	return stream, nil
+}
+
+func (p *fakeStreamProcessor) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, stream := range p.streams {
+		stream.Close()
+	}
+	return nil
+}
+
+// waitForClosed waits until every stream handed out was closed by the streaming loop
+func (p *fakeStreamProcessor) waitForClosed(t *testing.T) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		open := 0
+		for _, stream := range p.streams {
+			if !stream.closed.Load() {
+				open++
+			}
+		}
+		p.mu.Unlock()
+
+		if open == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("streaming loop did not end after stopping")
+}
+
+func (p *fakeStreamProcessor) IsRunning() bool                                 { return true }
+func (p *fakeStreamProcessor) IsProcessAlive() bool                            { return true }
+func (p *fakeStreamProcessor) Restart(url string) error                        { return nil }
+func (p *fakeStreamProcessor) WaitForExit(timeout time.Duration) error         { return nil }
+func (p *fakeStreamProcessor) GetProcessInfo() map[string]interface{}          { return nil }
+func (p *fakeStreamProcessor) DetectStreamFailure(err error) bool              { return false }
+func (p *fakeStreamProcessor) HandleStreamFailureWithRefresh(url string) error { return nil }
+
+type silentStream struct {
+	closed atomic.Bool
+}
+
+func (s *silentStream) Read(buffer []byte) (int, error) {
+	if s.closed.Load() {
+		return 0, io.ErrClosedPipe
+	}
+	time.Sleep(time.Millisecond)
+	clear(buffer)
+	return len(buffer), nil
+}
+
+func (s *silentStream) Close() error {
+	s.closed.Store(true)
+	return nil
+}
+
+type fakeEncoder struct{}
+
+func (fakeEncoder) Initialize() error                            { return nil }
+func (fakeEncoder) Encode(pcmData []int16) ([]byte, error)       { return []byte{0}, nil }
+func (fakeEncoder) Close() error                                 { return nil }
+func (fakeEncoder) IsInitialized() bool                          { return true }
+func (fakeEncoder) EncodeFrame(pcmFrame []int16) ([]byte, error) { return []byte{0}, nil }
+func (fakeEncoder) GetFrameSize() int                            { return 1920 }
+func (fakeEncoder) GetFrameDuration() time.Duration              { return 20 * time.Millisecond }
+func (fakeEncoder) ValidateFrameSize(pcmData []int16) error      { return nil }
+func (fakeEncoder) PrepareForStreaming() error                   { return nil }
+
+type fakeMetrics struct{}
+
+func (fakeMetrics) RecordStartupTime(duration time.Duration)      {}
+func (fakeMetrics) RecordError(errorType string)                  {}
+func (fakeMetrics) RecordPlaybackDuration(duration time.Duration) {}
+func (fakeMetrics) GetStats() audio.MetricsStats                  { return audio.MetricsStats{} }
+
+type fakeLogger struct{}
+
+func (fakeLogger) Info(msg string, fields map[string]interface{})             {}
+func (fakeLogger) Error(msg string, err error, fields map[string]interface{}) {}
+func (fakeLogger) Warn(msg string, fields map[string]interface{})             {}
+func (fakeLogger) Debug(msg string, fields map[string]interface{})            {}
+func (l fakeLogger) WithPipeline(pipeline string) audio.AudioLogger           { return l }
+func (l fakeLogger) WithContext(ctx map[string]interface{}) audio.AudioLogger { return l }
+
+type fakeConfig struct{}
+
+func (fakeConfig) GetPipelineConfig() *audio.PipelineConfig { return &audio.PipelineConfig{} }
+func (fakeConfig) GetFFmpegConfig() *audio.FFmpegConfig     { return &audio.FFmpegConfig{} }
+func (fakeConfig) GetYtDlpConfig() *audio.YtDlpConfig       { return &audio.YtDlpConfig{} }
+func (fakeConfig) GetOpusConfig() *audio.OpusConfig         { return &audio.OpusConfig{} }
+func (fakeConfig) GetRetryConfig() *audio.RetryConfig       { return &audio.RetryConfig{} }
+func (fakeConfig) GetLoggerConfig() *audio.LoggerConfig     { return &audio.LoggerConfig{} }
+func (fakeConfig) Validate() error                          { return nil }
+func (fakeConfig) ValidateDependencies() error              { return nil }
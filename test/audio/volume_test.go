@@ -0,0 +1,54 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/latoulicious/HKTM/pkg/audio"
+)
+
+func TestApplyVolume(t *testing.T) {
+	tests := []struct {
+		name     string
+		percent  int
+		input    []int16
+		expected []int16
+	}{
+		{
+			name:     "half volume",
+			percent:  50,
+			input:    []int16{1000, -1000, 0},
+			expected: []int16{500, -500, 0},
+		},
+		{
+			name:     "muted",
+			percent:  0,
+			input:    []int16{1000, -1000},
+			expected: []int16{0, 0},
+		},
+		{
+			name:     "amplified samples clip",
+			percent:  200,
+			input:    []int16{20000, -20000, 100},
+			expected: []int16{32767, -32768, 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := append([]int16(nil), tt.input...)
+			audio.ApplyVolume(samples, tt.percent)
+			for i := range samples {
+				if samples[i] != tt.expected[i] {
+					t.Errorf("sample %d = %d, expected %d", i, samples[i], tt.expected[i])
+				}
+			}
+		})
+	}
+
+	if got := audio.ClampVolume(500); got != audio.MaxVolume {
+		t.Errorf("ClampVolume(500) = %d, expected %d", got, audio.MaxVolume)
+	}
+	if got := audio.ClampVolume(-10); got != 0 {
+		t.Errorf("ClampVolume(-10) = %d, expected 0", got)
+	}
+}
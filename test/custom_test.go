@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/latoulicious/HKTM/internal/custom"
+)
+
+// TestCustomCommandTemplates tests placeholder rendering and custom command validation
+func TestCustomCommandTemplates(t *testing.T) {
+	values := custom.Values{
+		User:      "<@1>",
+		UserName:  "Hokko",
+		Channel:   "<#2>",
+		QueueSize: 3,
+	}
+	rendered := custom.Render("Hi {user.name} ({user}) in {channel}: {track}, {queue.size} queued", values)
+	if expected := "Hi Hokko (<@1>) in <#2>: nothing, 3 queued"; rendered != expected {
+		t.Errorf("Render() = %q, want %q", rendered, expected)
+	}
+
+	title, body := custom.Command{Kind: custom.KindEmbed, Content: "Rules | Be nice, {user}"}.EmbedParts()
+	if title != "Rules" || body != "Be nice, {user}" {
+		t.Errorf("EmbedParts() = %q, %q", title, body)
+	}
+
+	// Responses showing the music follow the music channel restriction
+	if !(custom.Command{Content: "Now playing: {track}"}).ShowsMusic() || (custom.Command{Content: "Be nice, {user}"}).ShowsMusic() {
+		t.Error("ShowsMusic() should only report responses with the track or queue")
+	}
+
+	if _, err := custom.ParseKind("Embed"); err != nil {
+		t.Errorf("ParseKind(Embed) error = %v", err)
+	}
+	if _, err := custom.ParseKind("script"); err == nil {
+		t.Error("ParseKind(script) should fail")
+	}
+
+	// Without storage guilds have no custom commands and none can be added
+	service := custom.NewService(nil)
+	if err := service.Add("guild", custom.Command{Name: "mc", Kind: custom.KindAlias, Content: "uma char McQueen"}); err == nil {
+		t.Error("Add() without storage should fail")
+	}
+	if _, exists := service.Get("guild", "mc"); exists {
+		t.Error("Get() should not find unsaved commands")
+	}
+}
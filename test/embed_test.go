@@ -0,0 +1,37 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/embed"
+)
+
+// TestProgressBar tests the now playing progress bar rendering
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		total    time.Duration
+		expected string
+	}{
+		{"start", 0, 4 * time.Minute, "🔘▬▬▬▬ `0:00 / 4:00`"},
+		{"halfway", 2 * time.Minute, 4 * time.Minute, "▬▬🔘▬▬ `2:00 / 4:00`"},
+		{"clamped to end", 5 * time.Minute, 4 * time.Minute, "▬▬▬▬🔘 `4:00 / 4:00`"},
+		{"unknown length", 83 * time.Second, 0, "🔴 `1:23`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := embed.ProgressBar(tt.elapsed, tt.total, 5); got != tt.expected {
+				t.Errorf("ProgressBar() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	// Hour-long tracks switch to h:mm:ss
+	if got := embed.ProgressBar(time.Hour, 2*time.Hour, 5); !strings.HasSuffix(got, "`1:00:00 / 2:00:00`") {
+		t.Errorf("unexpected hour format: %q", got)
+	}
+}
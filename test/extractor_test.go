@@ -0,0 +1,119 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/extractor"
+)
+
+// TestParseVideoInfo tests parsing yt-dlp -J output and picking the best audio format
+func TestParseVideoInfo(t *testing.T) {
+	output := []byte(`{
+		"id": "dQw4w9WgXcQ",
+		"title": "Test Video",
+		"channel": "Test Channel",
+		"thumbnail": "https://i.ytimg.com/vi/dQw4w9WgXcQ/maxresdefault.jpg",
+		"duration": 212.5,
+		"live_status": "not_live",
+		"chapters": [
+			{"title": "Intro", "start_time": 0, "end_time": 30},
+			{"title": "Song", "start_time": 30, "end_time": 212.5}
+		],
+		"formats": [
+			{"format_id": "139", "url": "https://example.com/139", "acodec": "mp4a.40.5", "vcodec": "none", "abr": 48},
+			{"format_id": "251", "url": "https://example.com/251", "acodec": "opus", "vcodec": "none", "abr": 160},
+			{"format_id": "18", "url": "https://example.com/18", "acodec": "mp4a.40.2", "vcodec": "avc1", "tbr": 500}
+		]
+	}`)
+
+	info, err := extractor.ParseInfo(output)
+	if err != nil {
+		t.Fatalf("ParseInfo() failed: %v", err)
+	}
+
+	if info.Title != "Test Video" || info.Uploader != "Test Channel" {
+		t.Errorf("unexpected title/uploader: %q / %q", info.Title, info.Uploader)
+	}
+	if info.Duration != 212500*time.Millisecond {
+		t.Errorf("Duration = %v, expected 3m32.5s", info.Duration)
+	}
+	if info.IsLive() {
+		t.Error("expected a video that is not live")
+	}
+	if len(info.Chapters) != 2 || info.Chapters[1].Start != 30*time.Second {
+		t.Errorf("unexpected chapters: %+v", info.Chapters)
+	}
+
+	format, ok := info.BestAudioFormat()
+	if !ok || format.FormatID != "251" {
+		t.Errorf("BestAudioFormat() = %q, expected 251", format.FormatID)
+	}
+
+	// Live streams have no duration and fall back to a muxed format
+	live, err := extractor.ParseInfo([]byte(`{
+		"id": "live123", "title": "Live", "is_live": true, "duration": 10,
+		"formats": [{"format_id": "95", "url": "https://example.com/95.m3u8", "acodec": "mp4a.40.2", "vcodec": "avc1"}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseInfo() failed for live stream: %v", err)
+	}
+	if !live.IsLive() || live.Duration != 0 {
+		t.Errorf("expected live stream without duration, got %q / %v", live.LiveStatus, live.Duration)
+	}
+	if format, ok := live.BestAudioFormat(); !ok || format.FormatID != "95" {
+		t.Errorf("BestAudioFormat() for live = %q, expected 95", format.FormatID)
+	}
+}
+
+// TestStreamCache tests stream URL expiry parsing, reuse and invalidation
+func TestStreamCache(t *testing.T) {
+	now := time.Now()
+
+	// Expiry comes from the googlevideo expire parameter or manifest path
+	expiry := now.Add(6 * time.Hour).Unix()
+	queryURL := fmt.Sprintf("https://rr1---sn-abc.googlevideo.com/videoplayback?expire=%d&itag=251", expiry)
+	if got := extractor.StreamExpiry(queryURL, now); got.Unix() != expiry {
+		t.Errorf("StreamExpiry() from query = %v, expected %v", got.Unix(), expiry)
+	}
+	manifestURL := fmt.Sprintf("https://manifest.googlevideo.com/api/manifest/hls_playlist/expire/%d/ei/abc/index.m3u8", expiry)
+	if got := extractor.StreamExpiry(manifestURL, now); got.Unix() != expiry {
+		t.Errorf("StreamExpiry() from path = %v, expected %v", got.Unix(), expiry)
+	}
+	if got := extractor.StreamExpiry("https://example.com/audio.mp3", now); !got.Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("StreamExpiry() fallback = %v, expected 5 minutes after resolving", got)
+	}
+
+	// Different URL forms of the same video share an entry
+	if extractor.StreamKey("https://youtu.be/dQw4w9WgXcQ") != extractor.StreamKey("https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42") {
+		t.Error("expected youtu.be and youtube.com URLs to share a stream key")
+	}
+
+	cache := extractor.NewStreamCache(time.Minute)
+	cache.Put("video", &extractor.StreamURL{URL: "https://a", Strategy: "default", ExpiresAt: now.Add(time.Hour)})
+	cache.Put("closing", &extractor.StreamURL{URL: "https://b", Strategy: "default", ExpiresAt: now.Add(30 * time.Second)})
+
+	if _, ok := cache.Get("video"); !ok {
+		t.Error("expected a valid URL to be reused")
+	}
+	if _, ok := cache.Get("closing"); ok {
+		t.Error("expected a URL inside the refresh margin to be refreshed")
+	}
+
+	// Only the rejected URL is invalidated
+	if cache.Invalidate("video", "https://other") {
+		t.Error("expected invalidation of a different URL to be ignored")
+	}
+	if !cache.Invalidate("video", "https://a") {
+		t.Error("expected the rejected URL to be invalidated")
+	}
+	if _, ok := cache.Get("video"); ok {
+		t.Error("expected an invalidated URL not to be reused")
+	}
+
+	_, stats := cache.Stats()
+	if stats["default"].Hits != 1 || stats["default"].Invalidations != 1 {
+		t.Errorf("unexpected stats: %+v", stats["default"])
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/pkg/embed"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// TestLocaleCatalog tests that every catalog has the same keys, the fallbacks and
+// the Discord locale mapping
+func TestLocaleCatalog(t *testing.T) {
+	english := make(map[string]bool)
+	for _, key := range i18n.Keys(i18n.English) {
+		english[key] = true
+	}
+	for _, language := range i18n.Languages {
+		keys := make(map[string]bool)
+		for _, key := range i18n.Keys(language) {
+			keys[key] = true
+			if !english[key] {
+				t.Errorf("%s has key %q that English lacks", language, key)
+			}
+		}
+		for key := range english {
+			if !keys[key] {
+				t.Errorf("%s is missing key %q", language, key)
+			}
+		}
+	}
+
+	if got := i18n.T(i18n.Japanese, "audio.queue.total", 3); got != "合計: 3曲" {
+		t.Errorf("T(ja, audio.queue.total) = %q", got)
+	}
+	if got := i18n.T("fr", "audio.queue.total", 3); got != "Total songs: 3" {
+		t.Errorf("T(fr) = %q, want the English message", got)
+	}
+	if got := i18n.T(i18n.Indonesian, "no.such.key"); got != "no.such.key" {
+		t.Errorf("T(missing key) = %q, want the key", got)
+	}
+
+	locales := []struct {
+		locale   discordgo.Locale
+		expected string
+		ok       bool
+	}{
+		{discordgo.Japanese, i18n.Japanese, true},
+		{discordgo.EnglishUS, i18n.English, true},
+		{"id", i18n.Indonesian, true},
+		{discordgo.French, "", false},
+	}
+	for _, tt := range locales {
+		got, ok := i18n.FromDiscordLocale(tt.locale)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("FromDiscordLocale(%q) = %q, %t, want %q, %t", tt.locale, got, ok, tt.expected, tt.ok)
+		}
+	}
+
+	// The shared builder stays English while localized copies render their language
+	builder := embed.NewAudioEmbedBuilder()
+	if title := builder.WithLanguage(i18n.Indonesian).QueueEnded().Title; !strings.Contains(title, "Antrean Selesai") {
+		t.Errorf("Indonesian QueueEnded title = %q", title)
+	}
+	if title := builder.QueueEnded().Title; !strings.Contains(title, "Queue Ended") {
+		t.Errorf("default QueueEnded title = %q", title)
+	}
+}
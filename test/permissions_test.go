@@ -0,0 +1,70 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/latoulicious/HKTM/internal/commands"
+	"github.com/latoulicious/HKTM/internal/permissions"
+)
+
+// TestRequiredCapability tests the command to capability declarations
+func TestRequiredCapability(t *testing.T) {
+	tests := []struct {
+		command  string
+		args     []string
+		expected permissions.Capability
+	}{
+		{"play", []string{"song"}, permissions.QueueAdd},
+		{"queue", []string{"add", "url"}, permissions.QueueAdd},
+		{"queue", []string{"list"}, permissions.None},
+		{"queue", []string{"clear"}, permissions.QueueClear},
+		{"queue", []string{"mode"}, permissions.None},
+		{"queue", []string{"mode", "fair"}, permissions.SettingsManage},
+		{"queue", []string{"limit", "items", "5"}, permissions.SettingsManage},
+		{"stop", nil, permissions.PlaybackControl},
+		{"skip", nil, permissions.None},
+		{"skip", []string{"threshold", "60"}, permissions.SettingsManage},
+		{"delete", []string{"5"}, permissions.ModerationDelete},
+		{"perms", nil, permissions.None},
+		{"perms", []string{"dj", "@DJ"}, permissions.PermissionsManage},
+		{"custom", []string{"list"}, permissions.None},
+		{"custom", []string{"add", "np2", "alias", "nowplaying"}, permissions.CustomManage},
+		{"uma", []string{"alias", "list"}, permissions.None},
+		{"uma", []string{"alias", "add", "character", "Mac", "Mejiro", "McQueen"}, permissions.UmaAliasManage},
+		{"uma", []string{"char", "Mac"}, permissions.None},
+		{"settings", []string{"show"}, permissions.SettingsManage},
+		{"ratelimit", []string{"set", "play", "5/30s"}, permissions.SettingsManage},
+		{"leave", []string{"123"}, permissions.OwnerLeave},
+		{"help", nil, permissions.None},
+	}
+
+	for _, tt := range tests {
+		if got := commands.RequiredCapability(tt.command, tt.args); got != tt.expected {
+			t.Errorf("RequiredCapability(%s, %v) = %q, want %q", tt.command, tt.args, got, tt.expected)
+		}
+	}
+}
+
+// TestOwnerCapabilities tests that owner.* capabilities are only held by the bot owner
+func TestOwnerCapabilities(t *testing.T) {
+	engine := permissions.NewEngine(nil, "owner-id")
+
+	if !engine.Check(nil, "guild", "owner-id", permissions.OwnerServers) {
+		t.Error("bot owner should hold owner capabilities")
+	}
+	if engine.Check(nil, "guild", "someone-else", permissions.OwnerServers) {
+		t.Error("other users should not hold owner capabilities")
+	}
+	if engine.Check(nil, "", "someone-else", permissions.ModerationDelete) {
+		t.Error("moderation capabilities should not be held outside a guild")
+	}
+	if !engine.Check(nil, "", "someone-else", permissions.QueueAdd) {
+		t.Error("other capabilities should be held outside a guild")
+	}
+	if _, err := permissions.ParseCapability("owner.leave"); err == nil {
+		t.Error("owner capabilities should not be assignable to roles")
+	}
+	if capability, err := permissions.ParseCapability("Playback.Control"); err != nil || capability != permissions.PlaybackControl {
+		t.Errorf("expected playback.control, got %q (%v)", capability, err)
+	}
+}
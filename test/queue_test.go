@@ -0,0 +1,308 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/latoulicious/HKTM/pkg/common"
+	"github.com/latoulicious/HKTM/pkg/extractor"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// TestFairQueueOrdering tests that fair mode interleaves items by requester
+func TestFairQueueOrdering(t *testing.T) {
+	queue := common.NewMusicQueue("test-guild")
+	queue.Add("a1", "A1", "alice", "alice-id", 0)
+	queue.Add("a2", "A2", "alice", "alice-id", 0)
+	queue.Add("a3", "A3", "alice", "alice-id", 0)
+	queue.Add("b1", "B1", "bob", "bob-id", 0)
+	queue.Add("c1", "C1", "carol", "carol-id", 0)
+	queue.Add("b2", "B2", "bob", "bob-id", 0)
+
+	// FIFO keeps insertion order
+	if got := titles(queue.List()); got != "A1,A2,A3,B1,C1,B2" {
+		t.Errorf("FIFO order = %s", got)
+	}
+
+	queue.SetMode(common.QueueModeFair)
+	if got := titles(queue.List()); got != "A1,B1,C1,A2,B2,A3" {
+		t.Errorf("fair order = %s", got)
+	}
+
+	// After alice's song starts, the next round should begin with someone else
+	if item := queue.Next(); item == nil || item.Title != "A1" {
+		t.Fatalf("expected A1 to play first, got %v", item)
+	}
+	if got := titles(queue.List()); got != "B1,C1,A2,B2,A3" {
+		t.Errorf("fair order after next = %s", got)
+	}
+
+	// Removal uses the displayed position
+	if err := queue.Remove(1); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if got := titles(queue.List()); got != "B1,A2,B2,A3" {
+		t.Errorf("fair order after remove = %s", got)
+	}
+	if pos := queue.PositionOf("alice-id"); pos != 4 {
+		t.Errorf("alice's latest position = %d, want 4", pos)
+	}
+}
+
+// TestFairQueueRotation tests that fair mode keeps taking turns across calls to Next
+func TestFairQueueRotation(t *testing.T) {
+	queue := common.NewMusicQueue("test-guild")
+	queue.SetMode(common.QueueModeFair)
+	for _, requester := range []string{"a", "b", "c"} {
+		for i := 1; i <= 3; i++ {
+			queue.Add(requester, fmt.Sprintf("%s%d", strings.ToUpper(requester), i), requester, requester+"-id", 0)
+		}
+	}
+
+	var played []string
+	for item := queue.Next(); item != nil; item = queue.Next() {
+		played = append(played, item.Title)
+		// Someone joining mid-rotation waits for the current round to finish
+		if len(played) == 4 {
+			queue.Add("d", "D1", "d", "d-id", 0)
+		}
+	}
+	if got := strings.Join(played, ","); got != "A1,B1,C1,A2,B2,C2,D1,A3,B3,C3" {
+		t.Errorf("fair play order = %s", got)
+	}
+}
+
+// TestQueueUserLimits tests per-user item and duration caps
+func TestQueueUserLimits(t *testing.T) {
+	queue := common.NewMusicQueue("test-guild")
+	queue.SetUserLimits(2, 10*time.Minute)
+
+	if err := queue.AddWithYouTubeData("", "u1", "v1", "One", "alice", "alice-id", 4*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := queue.AddWithYouTubeData("", "u2", "v2", "Two", "alice", "alice-id", 7*time.Minute); err == nil {
+		t.Error("expected duration limit error")
+	}
+	if err := queue.AddWithYouTubeData("", "u3", "v3", "Three", "alice", "alice-id", 5*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := queue.Add("u4", "Four", "alice", "alice-id", time.Minute)
+	var limitErr *common.LimitError
+	if !errors.As(err, &limitErr) {
+		t.Errorf("expected an item limit error, got %v", err)
+	} else if got := i18n.T(i18n.Japanese, limitErr.Key, limitErr.Args...); !strings.Contains(got, "上限") {
+		t.Errorf("Japanese limit error = %q", got)
+	}
+	if err := queue.Add("u5", "Five", "bob", "bob-id", time.Minute); err != nil {
+		t.Errorf("limits should be per user: %v", err)
+	}
+	// Streams of unknown length can't be counted against the duration cap
+	if err := queue.Add("u7", "Live", "bob", "bob-id", 0); err == nil {
+		t.Error("expected an item of unknown length to be refused under a duration cap")
+	}
+
+	// Requesters are told apart by ID, not by a display name anyone can share
+	if err := queue.Add("u8", "Eight", "alice", "other-alice-id", time.Minute); err != nil {
+		t.Errorf("limits should follow the requester ID: %v", err)
+	}
+	if item := queue.List()[queue.Size()-1]; !item.IsRequestedBy("other-alice-id") || item.IsRequestedBy("alice-id") {
+		t.Error("expected the requester to be matched by ID")
+	}
+
+	queue.SetUserLimits(0, 0)
+	if err := queue.Add("u6", "Six", "alice", "alice-id", 0); err != nil {
+		t.Errorf("disabled limits should allow adds: %v", err)
+	}
+}
+
+// TestQueueExportImport tests that exports round-trip through the import parser
+func TestQueueExportImport(t *testing.T) {
+	queue := common.NewMusicQueue("test-guild")
+	queue.AddWithYouTubeData("", "https://www.youtube.com/watch?v=aaaaaaaaaaa", "aaaaaaaaaaa", "First", "alice", "alice-id", 3*time.Minute)
+	queue.AddWithYouTubeData("", "https://www.youtube.com/watch?v=bbbbbbbbbbb", "bbbbbbbbbbb", "Second, Live", "bob", "bob-id", 90*time.Second)
+	queue.Add("https://example.com/stream.mp3", "Direct URL", "carol", "carol-id", 0)
+	queue.Next()
+
+	for _, format := range []common.PlaylistFormat{common.PlaylistFormatJSON, common.PlaylistFormatM3U} {
+		data, err := queue.Snapshot().Encode(format)
+		if err != nil {
+			t.Fatalf("%s encode failed: %v", format, err)
+		}
+
+		entries, err := common.ParsePlaylistImport(data)
+		if err != nil {
+			t.Fatalf("%s parse failed: %v", format, err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("%s: expected now playing plus 2 queued entries, got %d", format, len(entries))
+		}
+		if entries[0].Value != "https://www.youtube.com/watch?v=aaaaaaaaaaa" || entries[0].Title != "First" || entries[0].Duration != 3*time.Minute {
+			t.Errorf("%s: unexpected now playing entry %+v", format, entries[0])
+		}
+		if entries[1].Title != "Second, Live" {
+			t.Errorf("%s: title with comma not preserved: %q", format, entries[1].Title)
+		}
+		if entries[2].Value != "https://example.com/stream.mp3" {
+			t.Errorf("%s: unexpected direct URL entry %+v", format, entries[2])
+		}
+	}
+
+	// Plain text files mix URLs and search queries, skipping blanks and comments
+	entries, err := common.ParsePlaylistImport([]byte("# my list\nhttps://youtu.be/aaaaaaaaaaa\n\nsome song name\n"))
+	if err != nil {
+		t.Fatalf("text parse failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Line != 2 || entries[1].Line != 4 || entries[1].Value != "some song name" {
+		t.Errorf("unexpected text entries %+v", entries)
+	}
+
+	if _, err := common.ParsePlaylistImport([]byte("  \n")); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+}
+
+// TestQueueLoopModes tests that finished songs are replayed according to the loop mode
+func TestQueueLoopModes(t *testing.T) {
+	queue := common.NewMusicQueue("test-guild")
+	queue.Add("a", "A", "alice", "alice-id", 0)
+	queue.Add("b", "B", "bob", "bob-id", 0)
+
+	if mode := queue.GetLoopMode(); mode != common.LoopOff || mode.Next() != common.LoopTrack {
+		t.Fatalf("expected loop to start off and cycle to track, got %s", mode)
+	}
+
+	// Track loop replays the finished song before the rest of the queue
+	queue.SetLoopMode(common.LoopTrack)
+	first := queue.Next()
+	queue.RequeueFinished(first)
+	if item := queue.Next(); item == nil || item.Title != "A" || item == first {
+		t.Fatalf("expected a fresh replay of A, got %v", item)
+	}
+
+	// Queue loop sends finished songs to the back
+	queue.SetLoopMode(common.LoopQueue)
+	queue.RequeueFinished(queue.Current())
+	if got := titles(queue.List()); got != "B,A" {
+		t.Errorf("queue loop order = %s", got)
+	}
+
+	// Turning loop off drops a pending replay
+	queue.SetLoopMode(common.LoopTrack)
+	queue.RequeueFinished(queue.Current())
+	queue.SetLoopMode(common.LoopOff)
+	if item := queue.Next(); item == nil || item.Title != "B" {
+		t.Errorf("expected B after disabling loop, got %v", item)
+	}
+}
+
+func titles(items []*common.QueueItem) string {
+	result := ""
+	for i, item := range items {
+		if i > 0 {
+			result += ","
+		}
+		result += item.Title
+	}
+	return result
+}
+
+// TestQueueClips tests parsing start/end offsets and the clipped queue duration
+func TestQueueClips(t *testing.T) {
+	timestamps := map[string]time.Duration{
+		"90":      90 * time.Second,
+		"1m30s":   90 * time.Second,
+		"1:20":    80 * time.Second,
+		"1:02:03": time.Hour + 2*time.Minute + 3*time.Second,
+	}
+	for value, expected := range timestamps {
+		if got, err := common.ParseTimestamp(value); err != nil || got != expected {
+			t.Errorf("ParseTimestamp(%q) = %v, %v; expected %v", value, got, err, expected)
+		}
+	}
+	for _, value := range []string{"", "1:75", "abc", "-5"} {
+		if _, err := common.ParseTimestamp(value); err == nil {
+			t.Errorf("ParseTimestamp(%q) should fail", value)
+		}
+	}
+
+	// URL offsets are used unless an explicit range is given
+	clip, err := common.ClipForRequest("https://www.youtube.com/watch?v=aaaaaaaaaaa&t=90s", "")
+	if err != nil || clip.Start != 90*time.Second || clip.End != 0 {
+		t.Errorf("expected the t= offset, got %+v, %v", clip, err)
+	}
+	clip, err = common.ClipForRequest("https://youtu.be/aaaaaaaaaaa?t=90", "1:20-3:45")
+	if err != nil || clip.Start != 80*time.Second || clip.End != 225*time.Second {
+		t.Errorf("expected the explicit range, got %+v, %v", clip, err)
+	}
+	if _, err := common.ParseClipRange("3:45-1:20"); err == nil {
+		t.Error("expected a range ending before it starts to be rejected")
+	}
+
+	queue := common.NewMusicQueue("test-guild")
+	if err := queue.AddClipWithYouTubeData("", "u1", "v1", "One", "alice", "alice-id", 10*time.Minute, clip); err != nil {
+		t.Fatalf("AddClipWithYouTubeData() failed: %v", err)
+	}
+	if item := queue.List()[0]; item.Duration != 145*time.Second || item.Clip() != clip {
+		t.Errorf("expected a 2m25s clip, got %v %+v", item.Duration, item.Clip())
+	}
+
+	// Start offsets past the end of the video are rejected
+	if err := queue.AddClipWithYouTubeData("", "u2", "v2", "Two", "alice", "alice-id", time.Minute, clip); err == nil {
+		t.Error("expected a clip starting after the video ends to be rejected")
+	}
+}
+
+// TestQueueChapters tests finding the chapter playing, including for clipped items
+func TestQueueChapters(t *testing.T) {
+	item := &common.QueueItem{
+		Duration: 10 * time.Minute,
+		Chapters: []extractor.Chapter{
+			{Title: "Intro", Start: 0, End: time.Minute},
+			{Title: "First", Start: time.Minute, End: 5 * time.Minute},
+			{Title: "Second", Start: 5 * time.Minute, End: 10 * time.Minute},
+		},
+	}
+
+	positions := map[time.Duration]int{
+		0:                0,
+		90 * time.Second: 1,
+		5 * time.Minute:  2,
+		10 * time.Minute: 2, // The last chapter runs to the end
+	}
+	for position, expected := range positions {
+		if got := item.ChapterAt(position); got != expected {
+			t.Errorf("ChapterAt(%v) = %d, expected %d", position, got, expected)
+		}
+	}
+
+	// Positions of a clipped item count from its start offset
+	item.StartOffset = 4 * time.Minute
+	if got := item.ChapterAt(90 * time.Second); got != 2 {
+		t.Errorf("ChapterAt() for clipped item = %d, expected 2", got)
+	}
+
+	if (&common.QueueItem{}).ChapterAt(0) != -1 {
+		t.Error("expected -1 for an item without chapters")
+	}
+}
+
+// TestLeavingVoiceIntent tests that a recorded leave is reported once per guild
+func TestLeavingVoiceIntent(t *testing.T) {
+	if common.TakeLeavingVoice("leave-guild") {
+		t.Fatal("expected no leave recorded before marking")
+	}
+
+	common.MarkLeavingVoice("leave-guild")
+	if common.TakeLeavingVoice("other-guild") {
+		t.Error("expected the leave to be recorded only for its guild")
+	}
+	if !common.TakeLeavingVoice("leave-guild") {
+		t.Error("expected the recorded leave to be reported")
+	}
+	if common.TakeLeavingVoice("leave-guild") {
+		t.Error("expected the recorded leave to be cleared once taken")
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/latoulicious/HKTM/internal/ratelimit"
+)
+
+// TestRateLimiter tests the token buckets, cooldown notices and limit parsing
+func TestRateLimiter(t *testing.T) {
+	limiter := ratelimit.NewLimiter(nil)
+	limit := ratelimit.Limit{Uses: 2, Per: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		if !limiter.Allow("guild", "user", "play", limit).Allowed {
+			t.Fatalf("run %d should be allowed", i+1)
+		}
+	}
+
+	denied := limiter.Allow("guild", "user", "play", limit)
+	if denied.Allowed || !denied.Notify {
+		t.Errorf("third run = %+v, want a denial that notifies", denied)
+	}
+	if denied.RetryAfter <= 0 || denied.RetryAfter > 30*time.Minute {
+		t.Errorf("RetryAfter = %s, want up to the 30m it takes to regain a run", denied.RetryAfter)
+	}
+	if again := limiter.Allow("guild", "user", "play", limit); again.Allowed || again.Notify {
+		t.Errorf("fourth run = %+v, want a silent denial", again)
+	}
+
+	// Buckets are separate per user, guild and command
+	if !limiter.Allow("guild", "other-user", "play", limit).Allowed ||
+		!limiter.Allow("other-guild", "user", "play", limit).Allowed ||
+		!limiter.Allow("guild", "user", "search", limit).Allowed {
+		t.Error("other buckets should be unaffected")
+	}
+	if !limiter.Allow("guild", "user", "help", ratelimit.Limit{}).Allowed {
+		t.Error("commands without a limit should always be allowed")
+	}
+	if hits := limiter.Hits()["play"]; hits != 2 {
+		t.Errorf("Hits()[play] = %d, want 2", hits)
+	}
+
+	parses := []struct {
+		value    string
+		expected ratelimit.Limit
+		wantErr  bool
+	}{
+		{"3/30s", ratelimit.Limit{Uses: 3, Per: 30 * time.Second}, false},
+		{"1/10m", ratelimit.Limit{Uses: 1, Per: 10 * time.Minute}, false},
+		{"off", ratelimit.Limit{}, false},
+		{"0/30s", ratelimit.Limit{}, true},
+		{"3/forever", ratelimit.Limit{}, true},
+		{"3", ratelimit.Limit{}, true},
+	}
+	for _, tt := range parses {
+		got, err := ratelimit.ParseLimit(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLimit(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.value, got, tt.expected)
+		}
+	}
+	if s := (ratelimit.Limit{Uses: 1, Per: 10 * time.Minute}).String(); s != "1 per 10m" {
+		t.Errorf("String() = %q, want \"1 per 10m\"", s)
+	}
+}
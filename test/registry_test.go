@@ -0,0 +1,85 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/latoulicious/HKTM/internal/commands"
+	"github.com/latoulicious/HKTM/pkg/i18n"
+)
+
+// TestCommandRegistry tests alias lookup and the generated slash commands and help message
+func TestCommandRegistry(t *testing.T) {
+	aliases := map[string]string{"p": "play", "q": "queue", "np": "nowplaying", "vol": "volume", "h": "help", "PLAY": "play"}
+	for alias, name := range aliases {
+		cmd := commands.Lookup(alias)
+		if cmd == nil || cmd.Name != name {
+			t.Errorf("Lookup(%s) did not resolve to %s", alias, name)
+		}
+	}
+	if commands.Lookup("nonexistent") != nil {
+		t.Error("Lookup(nonexistent) should not resolve")
+	}
+
+	// Discord rejects descriptions over 100 characters
+	for _, definition := range commands.SlashCommands() {
+		if definition.Description == "" || len(definition.Description) > 100 {
+			t.Errorf("/%s description has %d characters", definition.Name, len(definition.Description))
+		}
+		for _, option := range definition.Options {
+			if option.Description == "" || len(option.Description) > 100 {
+				t.Errorf("/%s %s description has %d characters", definition.Name, option.Name, len(option.Description))
+			}
+		}
+	}
+
+	// The Uma searches are slash subcommands whose names autocomplete from the database
+	uma := make(map[string]bool)
+	for _, definition := range commands.SlashCommands() {
+		if definition.Name != "uma" {
+			continue
+		}
+		for _, sub := range definition.Options {
+			uma[sub.Name] = len(sub.Options) > 0 && sub.Options[0].Autocomplete
+		}
+	}
+	for _, sub := range []string{"char", "support", "skills"} {
+		if !uma[sub] {
+			t.Errorf("/uma %s is missing or doesn't autocomplete", sub)
+		}
+	}
+	for _, sub := range []string{"sync", "refresh", "cache"} {
+		if _, exists := uma[sub]; !exists {
+			t.Errorf("/uma %s is missing", sub)
+		}
+	}
+
+	// Every /queue prefix subcommand is also a slash subcommand; import takes a file
+	queue := make(map[string]*discordgo.ApplicationCommandOption)
+	for _, definition := range commands.SlashCommands() {
+		if definition.Name != "queue" {
+			continue
+		}
+		for _, sub := range definition.Options {
+			queue[sub.Name] = sub
+		}
+	}
+	for _, sub := range []string{"add", "list", "remove", "clear", "mode", "limit", "export", "import"} {
+		if queue[sub] == nil {
+			t.Errorf("/queue %s is missing", sub)
+		}
+	}
+	if sub := queue["add"]; sub != nil && len(sub.Options) != 2 {
+		t.Errorf("/queue add has %d options, want url and range", len(sub.Options))
+	}
+	if sub := queue["import"]; sub != nil && (len(sub.Options) == 0 || sub.Options[0].Type != discordgo.ApplicationCommandOptionAttachment) {
+		t.Error("/queue import doesn't take an attachment")
+	}
+
+	// Discord rejects embed fields over 1024 characters
+	for _, field := range commands.HelpEmbed("!", i18n.Default).Fields {
+		if len(field.Value) > 1024 {
+			t.Errorf("Help field %q has %d characters", field.Name, len(field.Value))
+		}
+	}
+}
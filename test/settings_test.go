@@ -0,0 +1,58 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/latoulicious/HKTM/internal/settings"
+	"github.com/latoulicious/HKTM/pkg/common"
+)
+
+// TestGuildSettings tests setting key parsing, the defaults and music channel restrictions
+func TestGuildSettings(t *testing.T) {
+	keys := []struct {
+		value    string
+		expected settings.Key
+		wantErr  bool
+	}{
+		{"prefix", settings.Prefix, false},
+		{"Idle_Timeout", settings.IdleTimeout, false},
+		{"maxqueuelength", settings.MaxQueueLength, false},
+		{"queue_mode", settings.QueueMode, false},
+		{"vote_skip_threshold", settings.VoteSkipThreshold, false},
+		{"volume", "", true},
+	}
+	for _, tt := range keys {
+		got, err := settings.ParseKey(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseKey(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseKey(%q) = %q, want %q", tt.value, got, tt.expected)
+		}
+	}
+
+	// Without storage every guild uses the defaults and changes are refused
+	service := settings.NewService(nil)
+	defaults := service.Get("123456789012345678")
+	if defaults.Prefix != settings.DefaultPrefix || defaults.IdleTimeout != settings.DefaultIdleTimeout || defaults.QueueMode != common.QueueModeFIFO {
+		t.Errorf("Get() = %+v, want the defaults", defaults)
+	}
+	if !defaults.AllowsMusicIn("223456789012345678") {
+		t.Error("default settings should allow music in every channel")
+	}
+	if _, err := service.Set(defaults.GuildID, settings.Prefix, "?", "1"); err == nil {
+		t.Error("Set() without storage should fail")
+	}
+	if _, err := service.Set(defaults.GuildID, settings.Prefix, "too long", "1"); err == nil {
+		t.Error("Set() should reject an invalid prefix")
+	}
+	if _, err := service.Set(defaults.GuildID, settings.QueueMode, "shuffle", "1"); err == nil {
+		t.Error("Set() should reject an unknown queue mode")
+	}
+
+	restricted := settings.Settings{MusicChannelIDs: []string{"223456789012345678"}}
+	if !restricted.AllowsMusicIn("223456789012345678") || restricted.AllowsMusicIn("323456789012345678") {
+		t.Error("AllowsMusicIn() should only allow the configured music channels")
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/latoulicious/HKTM/internal/shard"
+)
+
+// TestShardRouting tests guild to shard assignment and shard ID parsing
+func TestShardRouting(t *testing.T) {
+	routes := []struct {
+		guildID  string
+		count    int
+		expected int
+	}{
+		{"81384788765712384", 1, 0},
+		{"81384788765712384", 2, 0},
+		{"81384788765712384", 16, 2},
+		{"not-a-snowflake", 4, 0},
+	}
+	for _, tt := range routes {
+		if got := shard.ForGuild(tt.guildID, tt.count); got != tt.expected {
+			t.Errorf("ForGuild(%s, %d) = %d, want %d", tt.guildID, tt.count, got, tt.expected)
+		}
+	}
+
+	ids := []struct {
+		value    string
+		expected []int
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"0,2", []int{0, 2}, false},
+		{"1-3", []int{1, 2, 3}, false},
+		{"0, 2-3", []int{0, 2, 3}, false},
+		{"4", nil, true},
+		{"3-1", nil, true},
+		{"a", nil, true},
+	}
+	for _, tt := range ids {
+		got, err := shard.ParseIDs(tt.value, 4)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIDs(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseIDs(%q) = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
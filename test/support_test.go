@@ -75,7 +75,7 @@ package test
 // 	// Test navigation embed creation
 // 	t.Log("🧭 Testing navigation embed for version 1 (SSR):")
 // 	navManager := navigation.GetSupportCardNavigationManager()
-// 	navEmbed := navManager.CreateSupportCardEmbed(result.SupportCards[0], result.SupportCards, 0)
+// 	navEmbed := navManager.CreateSupportCardEmbed(result.SupportCards[0], result.SupportCards, 0, "en")
 
 // 	if navEmbed.Title == "" {
 // 		t.Error("Expected navigation embed to have a title")
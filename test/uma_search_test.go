@@ -0,0 +1,118 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/latoulicious/HKTM/pkg/uma/search"
+)
+
+// TestUmaSearchRanking tests kana, romaji and width normalization, typo tolerance and
+// when a query is ambiguous enough to ask which match was meant
+func TestUmaSearchRanking(t *testing.T) {
+	// Spellings of the same name; word breaks may differ between scripts
+	equivalent := map[string]string{
+		"オグリキャップ":            "おぐりきゃっぷ",
+		"ＯＧＵＲＩ　ＣＡＰ":          "Oguri Cap",
+		"ﾄｳｶｲﾃｲｵｰ":           "Tokai Teio",
+		"Tōkai Teiō":         "トウカイテイオー",
+		"Matikanefukukitaru": "Machikane-Fukukitaru",
+		"Special Week!":      "special-week",
+	}
+	for a, b := range equivalent {
+		normalizedA := strings.ReplaceAll(search.Normalize(a), " ", "")
+		normalizedB := strings.ReplaceAll(search.Normalize(b), " ", "")
+		if normalizedA != normalizedB {
+			t.Errorf("Normalize(%q) = %q but Normalize(%q) = %q", a, normalizedA, b, normalizedB)
+		}
+	}
+
+	names := [][]string{
+		{"Oguri Cap", "オグリキャップ"},
+		{"Oguri Cap (Christmas)", "オグリキャップ"},
+		{"Mejiro McQueen", "メジロマックイーン"},
+		{"Mejiro Ryan", "メジロライアン"},
+		{"Tokai Teio", "トウカイテイオー"},
+	}
+	cases := []struct {
+		query     string
+		want      int
+		ambiguous bool
+	}{
+		{"Oguri Cap", 0, false},
+		{"mcqeen", 2, false},
+		{"とうかいていおー", 4, false},
+		{"oguri", 1, true},
+		{"mejiro", 2, true},
+	}
+	for _, tc := range cases {
+		matches := search.Rank(tc.query, names)
+		if search.Ambiguous(matches) != tc.ambiguous {
+			t.Errorf("Ambiguous(Rank(%q)) = %v, want %v", tc.query, !tc.ambiguous, tc.ambiguous)
+		}
+
+		// Unambiguous queries must rank the wanted name first, ambiguous ones must offer it
+		contenders := search.Contenders(matches)
+		if !tc.ambiguous {
+			contenders = contenders[:min(1, len(contenders))]
+		}
+		found := false
+		for _, match := range contenders {
+			found = found || match.Index == tc.want
+		}
+		if !found {
+			t.Errorf("Rank(%q) = %v, want %s among %d contenders", tc.query, matches, names[tc.want][0], len(contenders))
+		}
+	}
+
+	if matches := search.Rank("xyz", names); len(matches) != 0 {
+		t.Errorf("Rank(xyz) = %v, want no matches", matches)
+	}
+
+	// Nicknames are stored by key, so spellings of one nickname must share it
+	for nickname, spellings := range map[string][]string{
+		"Spe-chan": {"spe chan", "SPE-CHAN", "Ｓｐｅ－ｃｈａｎ"},
+		"スペちゃん":    {"すぺちゃん", "ｽﾍﾟちゃん"},
+	} {
+		for _, spelling := range spellings {
+			if got, want := search.Key(spelling), search.Key(nickname); got != want {
+				t.Errorf("Key(%q) = %q, want %q", spelling, got, want)
+			}
+		}
+	}
+}
+
+// TestBundledUmaAliases tests that no two bundled nicknames of different targets share a
+// normalized key, since seeding keeps only the first, and that both sections are filled
+func TestBundledUmaAliases(t *testing.T) {
+	data, err := os.ReadFile("../pkg/uma/service/aliases.json")
+	if err != nil {
+		t.Fatalf("failed to read bundled aliases: %v", err)
+	}
+
+	var bundle map[string]map[string][]string
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatalf("failed to parse bundled aliases: %v", err)
+	}
+
+	for _, section := range []string{"characters", "support_cards"} {
+		if len(bundle[section]) == 0 {
+			t.Errorf("expected bundled %s aliases", section)
+		}
+
+		seen := make(map[string]string)
+		for target, aliases := range bundle[section] {
+			for _, alias := range aliases {
+				key := search.Key(alias)
+				if key == "" {
+					t.Errorf("%s alias %q of %s normalizes to nothing", section, alias, target)
+				} else if other, exists := seen[key]; exists && other != target {
+					t.Errorf("%s alias %q of %s has the same key as one of %s", section, alias, target, other)
+				}
+				seen[key] = target
+			}
+		}
+	}
+}